@@ -18,9 +18,14 @@ import (
 	"github.com/spf13/cobra"
 	"knative.dev/kn-plugin-operator/pkg"
 	"knative.dev/kn-plugin-operator/pkg/command/configure"
+	"knative.dev/kn-plugin-operator/pkg/command/disable"
+	"knative.dev/kn-plugin-operator/pkg/command/edit"
 	"knative.dev/kn-plugin-operator/pkg/command/enable"
+	"knative.dev/kn-plugin-operator/pkg/command/explain"
 	"knative.dev/kn-plugin-operator/pkg/command/install"
+	"knative.dev/kn-plugin-operator/pkg/command/migrate"
 	"knative.dev/kn-plugin-operator/pkg/command/remove"
+	"knative.dev/kn-plugin-operator/pkg/command/reset"
 	"knative.dev/kn-plugin-operator/pkg/command/uninstall"
 )
 
@@ -44,7 +49,12 @@ kn operator install -c eventing
 	rootCmd.AddCommand(install.NewInstallCommand(p))
 	rootCmd.AddCommand(uninstall.NewUninstallCommand(p))
 	rootCmd.AddCommand(enable.NewEnableCommand(p))
+	rootCmd.AddCommand(disable.NewDisableCommand(p))
 	rootCmd.AddCommand(configure.NewConfigureCommand(p))
 	rootCmd.AddCommand(remove.NewRemoveCommand(p))
+	rootCmd.AddCommand(migrate.NewMigrateSpecCommand(p))
+	rootCmd.AddCommand(explain.NewExplainCommand(p))
+	rootCmd.AddCommand(edit.NewEditCommand(p))
+	rootCmd.AddCommand(reset.NewResetCommand(p))
 	return rootCmd
 }
@@ -0,0 +1,137 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"knative.dev/operator/pkg/apis/operator/base"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+// KnativeCRState describes the target cluster, the version and the status conditions of the Knative custom resource
+type KnativeCRState struct {
+	ClusterProfileRef *base.ClusterProfileReference
+	Version           string
+	Ready             bool
+	Conditions        duckv1.Conditions
+}
+
+// ParseClusterProfileRef parses the reference to a ClusterProfile in the format of namespace/name
+func ParseClusterProfileRef(ref string) (*base.ClusterProfileReference, error) {
+	parts := strings.Split(ref, "/")
+	if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+		return nil, fmt.Errorf("The ClusterProfile %s should be in the format of namespace/name.", ref)
+	}
+	return &base.ClusterProfileReference{
+		Namespace: strings.TrimSpace(parts[0]),
+		Name:      strings.TrimSpace(parts[1]),
+	}, nil
+}
+
+// FormatClusterProfileRef returns the reference to a ClusterProfile in the format of namespace/name
+func FormatClusterProfileRef(ref *base.ClusterProfileReference) string {
+	if ref == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s", ref.Namespace, ref.Name)
+}
+
+// ValidateClusterProfileRefChange checks whether the existing Knative custom resource can target the ClusterProfile.
+// spec.clusterProfileRef cannot be added, removed or changed after the custom resource is created.
+func ValidateClusterProfileRefChange(component string, existing, target *base.ClusterProfileReference) error {
+	if existing == nil && target == nil {
+		return nil
+	}
+	if existing == nil {
+		return fmt.Errorf("Knative %s already exists in the local cluster, and spec.clusterProfileRef cannot be added after creation. Please uninstall it first.", component)
+	}
+	if target == nil || *existing != *target {
+		return fmt.Errorf("Knative %s targets the ClusterProfile %s, and spec.clusterProfileRef is immutable.", component, FormatClusterProfileRef(existing))
+	}
+	return nil
+}
+
+// GetKnativeCRState gets the state of the Knative custom resource under a certain namespace. It returns nil, if the
+// custom resource is not available in the cluster.
+func (ko *KnativeOperatorCR) GetKnativeCRState(component, namespace string) (*KnativeCRState, error) {
+	if strings.EqualFold(component, ServingComponent) {
+		ks, err := ko.GetKnativeServingInCluster(namespace)
+		if apierrs.IsNotFound(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		return &KnativeCRState{
+			ClusterProfileRef: ks.Spec.ClusterProfileRef,
+			Version:           ks.Status.Version,
+			Ready:             ks.Status.IsReady(),
+			Conditions:        ks.Status.Conditions,
+		}, nil
+	}
+
+	ke, err := ko.GetKnativeEventingInCluster(namespace)
+	if apierrs.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &KnativeCRState{
+		ClusterProfileRef: ke.Spec.ClusterProfileRef,
+		Version:           ke.Status.Version,
+		Ready:             ke.Status.IsReady(),
+		Conditions:        ke.Status.Conditions,
+	}, nil
+}
+
+// FormatKnativeCRState returns the target cluster, the version and the status conditions of the Knative custom resource
+func FormatKnativeCRState(state *KnativeCRState) string {
+	target := "local cluster"
+	if state.ClusterProfileRef != nil {
+		target = fmt.Sprintf("ClusterProfile %s", FormatClusterProfileRef(state.ClusterProfileRef))
+	}
+	version := state.Version
+	if version == "" {
+		version = "unknown"
+	}
+	contentArray := []string{
+		fmt.Sprintf("target: %s", target),
+		fmt.Sprintf("version: %s", version),
+		fmt.Sprintf("ready: %t", state.Ready),
+		"conditions:",
+	}
+	for _, condition := range state.Conditions {
+		line := fmt.Sprintf("  %s: %s", condition.Type, condition.Status)
+		if condition.Reason != "" {
+			line = fmt.Sprintf("%s (%s: %s)", line, condition.Reason, condition.Message)
+		}
+		contentArray = append(contentArray, line)
+	}
+	return fmt.Sprintf("%s\n", strings.Join(contentArray, "\n"))
+}
+
+// ReportKnativeCRState prints the state of the Knative custom resource
+func ReportKnativeCRState(out io.Writer, component, namespace string, state *KnativeCRState) {
+	if state == nil {
+		fmt.Fprintf(out, "Knative %s is not found in the namespace '%s'.\n", component, namespace)
+		return
+	}
+	fmt.Fprintf(out, "Knative %s in the namespace '%s':\n%s", component, namespace, FormatKnativeCRState(state))
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"fmt"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/kn-plugin-operator/pkg/command/testingUtil"
+	"knative.dev/operator/pkg/apis/operator/base"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func TestParseClusterProfileRef(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		ref            string
+		expectedResult *base.ClusterProfileReference
+		expectedErr    error
+	}{{
+		name:           "Valid reference",
+		ref:            "fleet-system/spoke-1",
+		expectedResult: &base.ClusterProfileReference{Namespace: "fleet-system", Name: "spoke-1"},
+	}, {
+		name:        "Reference without namespace",
+		ref:         "spoke-1",
+		expectedErr: fmt.Errorf("The ClusterProfile spoke-1 should be in the format of namespace/name."),
+	}, {
+		name:        "Reference with empty name",
+		ref:         "fleet-system/",
+		expectedErr: fmt.Errorf("The ClusterProfile fleet-system/ should be in the format of namespace/name."),
+	}, {
+		name:        "Reference with too many parts",
+		ref:         "a/b/c",
+		expectedErr: fmt.Errorf("The ClusterProfile a/b/c should be in the format of namespace/name."),
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseClusterProfileRef(tt.ref)
+			if tt.expectedErr != nil {
+				testingUtil.AssertEqual(t, err.Error(), tt.expectedErr.Error())
+			} else {
+				testingUtil.AssertEqual(t, err, nil)
+				testingUtil.AssertDeepEqual(t, result, tt.expectedResult)
+			}
+		})
+	}
+}
+
+func TestValidateClusterProfileRefChange(t *testing.T) {
+	spoke := &base.ClusterProfileReference{Namespace: "fleet-system", Name: "spoke-1"}
+	for _, tt := range []struct {
+		name        string
+		existing    *base.ClusterProfileReference
+		target      *base.ClusterProfileReference
+		expectedErr error
+	}{{
+		name: "Local cluster",
+	}, {
+		name:     "Same ClusterProfile",
+		existing: spoke,
+		target:   &base.ClusterProfileReference{Namespace: "fleet-system", Name: "spoke-1"},
+	}, {
+		name:        "Add the ClusterProfile",
+		target:      spoke,
+		expectedErr: fmt.Errorf("Knative serving already exists in the local cluster, and spec.clusterProfileRef cannot be added after creation. Please uninstall it first."),
+	}, {
+		name:        "Change the ClusterProfile",
+		existing:    spoke,
+		target:      &base.ClusterProfileReference{Namespace: "fleet-system", Name: "spoke-2"},
+		expectedErr: fmt.Errorf("Knative serving targets the ClusterProfile fleet-system/spoke-1, and spec.clusterProfileRef is immutable."),
+	}, {
+		name:        "Remove the ClusterProfile",
+		existing:    spoke,
+		expectedErr: fmt.Errorf("Knative serving targets the ClusterProfile fleet-system/spoke-1, and spec.clusterProfileRef is immutable."),
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateClusterProfileRefChange("serving", tt.existing, tt.target)
+			if tt.expectedErr != nil {
+				testingUtil.AssertEqual(t, err.Error(), tt.expectedErr.Error())
+			} else {
+				testingUtil.AssertEqual(t, err, nil)
+			}
+		})
+	}
+}
+
+func TestFormatKnativeCRState(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		state          *KnativeCRState
+		expectedResult string
+	}{{
+		name: "Local cluster",
+		state: &KnativeCRState{
+			Version: "1.8.0",
+			Ready:   true,
+			Conditions: duckv1.Conditions{{
+				Type:   apis.ConditionReady,
+				Status: corev1.ConditionTrue,
+			}},
+		},
+		expectedResult: `target: local cluster
+version: 1.8.0
+ready: true
+conditions:
+  Ready: True
+`,
+	}, {
+		name: "Remote cluster not ready",
+		state: &KnativeCRState{
+			ClusterProfileRef: &base.ClusterProfileReference{Namespace: "fleet-system", Name: "spoke-1"},
+			Conditions: duckv1.Conditions{{
+				Type:    apis.ConditionReady,
+				Status:  corev1.ConditionFalse,
+				Reason:  base.ReasonClusterProfileNotReady,
+				Message: "the ClusterProfile is not healthy",
+			}},
+		},
+		expectedResult: `target: ClusterProfile fleet-system/spoke-1
+version: unknown
+ready: false
+conditions:
+  Ready: False (ClusterProfileNotReady: the ClusterProfile is not healthy)
+`,
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := FormatKnativeCRState(tt.state)
+			testingUtil.AssertEqual(t, result, tt.expectedResult)
+		})
+	}
+}
@@ -0,0 +1,252 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	_ "embed"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"golang.org/x/mod/semver"
+)
+
+//go:embed catalogue/configmaps.yaml
+var configMapCatalogueContent []byte
+
+// The types of the keys in the catalogue of the ConfigMaps
+const (
+	StringType   = "string"
+	BoolType     = "bool"
+	IntType      = "int"
+	FloatType    = "float"
+	DurationType = "duration"
+	EnumType     = "enum"
+)
+
+// ConfigMapKey describes a known key of a Knative ConfigMap
+type ConfigMapKey struct {
+	Name        string   `json:"name"`
+	Type        string   `json:"type"`
+	Values      []string `json:"values,omitempty"`
+	Default     string   `json:"default,omitempty"`
+	Since       string   `json:"since,omitempty"`
+	Description string   `json:"description"`
+}
+
+// ConfigMapEntry describes a Knative ConfigMap and its known keys
+type ConfigMapEntry struct {
+	Component   string         `json:"component"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Keys        []ConfigMapKey `json:"keys"`
+}
+
+// GetConfigMapCatalogue returns the embedded catalogue of the Knative ConfigMaps
+func GetConfigMapCatalogue() ([]ConfigMapEntry, error) {
+	var catalogue []ConfigMapEntry
+	if err := yaml.Unmarshal(configMapCatalogueContent, &catalogue); err != nil {
+		return nil, fmt.Errorf("failed to parse the catalogue of the ConfigMaps: %w", err)
+	}
+	return catalogue, nil
+}
+
+// FindConfigMapEntries returns the entries of the ConfigMap with the name, with or without the prefix config-, in the
+// catalogue. All the components are searched, if the component is empty.
+func FindConfigMapEntries(catalogue []ConfigMapEntry, component, name string) []ConfigMapEntry {
+	entries := []ConfigMapEntry{}
+	altName := AddOrRemovePrefix(name, ConfigMapPrefix)
+	for _, entry := range catalogue {
+		if component != "" && !strings.EqualFold(entry.Component, component) {
+			continue
+		}
+		if entry.Name == name || entry.Name == altName {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// IsAvailable returns whether the key is available in the Knative version. Every key is available, if the version
+// is empty or not valid.
+func (key ConfigMapKey) IsAvailable(version string) bool {
+	return IsVersionAvailable(version, key.Since)
+}
+
+// IsVersionAvailable checks whether the version is the same as or after since. It returns true, if since is empty or
+// the version is unknown.
+func IsVersionAvailable(version, since string) bool {
+	if since == "" {
+		return true
+	}
+	if !strings.HasPrefix(version, "v") {
+		version = fmt.Sprintf("v%s", version)
+	}
+	if !semver.IsValid(version) {
+		return true
+	}
+	return semver.Compare(version, since) >= 0
+}
+
+// ValidateValue checks whether the value matches the type of the key
+func (key ConfigMapKey) ValidateValue(value string) error {
+	var err error
+	switch key.Type {
+	case BoolType:
+		_, err = strconv.ParseBool(value)
+	case IntType:
+		_, err = strconv.ParseInt(value, 10, 64)
+	case FloatType:
+		_, err = strconv.ParseFloat(value, 64)
+	case DurationType:
+		_, err = time.ParseDuration(value)
+	case EnumType:
+		if !containsFold(key.Values, value) {
+			return fmt.Errorf("The value %s of the key %s should be one of %s.", value, key.Name, strings.Join(key.Values, ", "))
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("The value %s of the key %s should be of the type %s.", value, key.Name, key.Type)
+	}
+	return nil
+}
+
+// CheckConfigMapData checks the data of the ConfigMap for the Knative version. It returns the invalid values as
+// problems, and the unknown keys and the keys not available in the version as warnings, since the catalogue does not
+// know every key of every version. The keys starting with _, like _example, are skipped.
+func CheckConfigMapData(entry ConfigMapEntry, version string, data map[string]string) ([]string, []string) {
+	keys := map[string]ConfigMapKey{}
+	names := []string{}
+	for _, key := range entry.Keys {
+		keys[key.Name] = key
+		if key.IsAvailable(version) {
+			names = append(names, key.Name)
+		}
+	}
+
+	dataKeys := make([]string, 0, len(data))
+	for dataKey := range data {
+		dataKeys = append(dataKeys, dataKey)
+	}
+	sort.Strings(dataKeys)
+
+	problems := []string{}
+	warnings := []string{}
+	for _, dataKey := range dataKeys {
+		if strings.HasPrefix(dataKey, "_") {
+			continue
+		}
+		key, ok := keys[dataKey]
+		if !ok {
+			warning := fmt.Sprintf("The key %s is unknown in the ConfigMap %s.", dataKey, entry.Name)
+			if suggestion := SuggestName(names, dataKey); suggestion != "" {
+				warning = fmt.Sprintf("%s Did you mean %s?", warning, suggestion)
+			}
+			warnings = append(warnings, warning)
+			continue
+		}
+		if !key.IsAvailable(version) {
+			warnings = append(warnings, fmt.Sprintf("The key %s in the ConfigMap %s is only available since Knative %s.",
+				dataKey, entry.Name, key.Since))
+			continue
+		}
+		if err := key.ValidateValue(data[dataKey]); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	return problems, warnings
+}
+
+// containsFold returns whether the value is in the values, ignoring the case
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// SuggestName returns the candidate closest to the name, or an empty string if none of them is close enough
+func SuggestName(candidates []string, name string) string {
+	suggestion := ""
+	// Allow a typo every four characters, but at least one
+	minDistance := len(name)/4 + 1
+	for _, candidate := range candidates {
+		if distance := editDistance(candidate, name); distance <= minDistance {
+			if distance < minDistance || suggestion == "" {
+				suggestion = candidate
+				minDistance = distance
+			}
+		}
+	}
+	return suggestion
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+// FormatConfigMapEntry returns the description, the types, the defaults and the availability of the keys of the
+// ConfigMap for the Knative version
+func FormatConfigMapEntry(entry ConfigMapEntry, version string) string {
+	contentArray := []string{
+		fmt.Sprintf("%s (Knative %s)", entry.Name, entry.Component),
+		fmt.Sprintf("  %s", entry.Description),
+		"",
+		"KEYS:",
+	}
+	for _, key := range entry.Keys {
+		if !key.IsAvailable(version) {
+			continue
+		}
+		contentArray = append(contentArray, fmt.Sprintf("  %s", key.Name))
+		keyType := key.Type
+		if key.Type == EnumType {
+			keyType = fmt.Sprintf("%s (%s)", key.Type, strings.Join(key.Values, ", "))
+		}
+		contentArray = append(contentArray, fmt.Sprintf("    type: %s", keyType))
+		if key.Default != "" {
+			contentArray = append(contentArray, fmt.Sprintf("    default: %s", key.Default))
+		}
+		if key.Since != "" {
+			contentArray = append(contentArray, fmt.Sprintf("    since: %s", key.Since))
+		}
+		contentArray = append(contentArray, fmt.Sprintf("    %s", key.Description))
+	}
+	return fmt.Sprintf("%s\n", strings.Join(contentArray, "\n"))
+}
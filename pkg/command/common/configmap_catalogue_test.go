@@ -0,0 +1,216 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	"knative.dev/kn-plugin-operator/pkg/command/testingUtil"
+)
+
+func TestGetConfigMapCatalogue(t *testing.T) {
+	catalogue, err := GetConfigMapCatalogue()
+	testingUtil.AssertEqual(t, err, nil)
+	for _, entry := range catalogue {
+		testingUtil.AssertEqual(t, entry.Component == ServingComponent || entry.Component == EventingComponent, true)
+		testingUtil.AssertEqual(t, len(entry.Keys) > 0, true)
+		for _, key := range entry.Keys {
+			testingUtil.AssertEqual(t, Contains([]string{StringType, BoolType, IntType, FloatType, DurationType, EnumType}, key.Type), true)
+			if key.Default != "" {
+				testingUtil.AssertEqual(t, key.ValidateValue(key.Default), nil)
+			}
+		}
+	}
+}
+
+func TestFindConfigMapEntries(t *testing.T) {
+	catalogue, err := GetConfigMapCatalogue()
+	testingUtil.AssertEqual(t, err, nil)
+	for _, tt := range []struct {
+		name               string
+		component          string
+		cmName             string
+		expectedComponents []string
+	}{{
+		name:               "ConfigMap with the prefix",
+		component:          "serving",
+		cmName:             "config-autoscaler",
+		expectedComponents: []string{"serving"},
+	}, {
+		name:               "ConfigMap without the prefix",
+		component:          "serving",
+		cmName:             "autoscaler",
+		expectedComponents: []string{"serving"},
+	}, {
+		name:               "ConfigMap of both components",
+		component:          "",
+		cmName:             "config-features",
+		expectedComponents: []string{"serving", "eventing"},
+	}, {
+		name:               "ConfigMap of the other component",
+		component:          "eventing",
+		cmName:             "config-autoscaler",
+		expectedComponents: []string{},
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			components := []string{}
+			for _, entry := range FindConfigMapEntries(catalogue, tt.component, tt.cmName) {
+				components = append(components, entry.Component)
+			}
+			testingUtil.AssertDeepEqual(t, components, tt.expectedComponents)
+		})
+	}
+}
+
+func TestCheckConfigMapData(t *testing.T) {
+	entry := ConfigMapEntry{
+		Component: "serving",
+		Name:      "config-network",
+		Keys: []ConfigMapKey{{
+			Name: "rollout-duration",
+			Type: IntType,
+		}, {
+			Name:   "http-protocol",
+			Type:   EnumType,
+			Values: []string{"Enabled", "Disabled", "Redirected"},
+		}, {
+			Name:   "external-domain-tls",
+			Type:   EnumType,
+			Values: []string{"Enabled", "Disabled"},
+			Since:  "v1.13",
+		}},
+	}
+	for _, tt := range []struct {
+		name             string
+		version          string
+		data             map[string]string
+		expectedProblems []string
+		expectedWarnings []string
+	}{{
+		name:    "Valid data",
+		version: "1.13.0",
+		data: map[string]string{
+			"rollout-duration":    "60",
+			"http-protocol":       "Redirected",
+			"external-domain-tls": "Enabled",
+			"_example":            "example",
+		},
+		expectedProblems: []string{},
+		expectedWarnings: []string{},
+	}, {
+		name:    "Enum values in any case",
+		version: "1.13.0",
+		data: map[string]string{
+			"http-protocol":       "redirected",
+			"external-domain-tls": "ENABLED",
+		},
+		expectedProblems: []string{},
+		expectedWarnings: []string{},
+	}, {
+		name:             "Unknown key with a suggestion",
+		version:          "",
+		data:             map[string]string{"rollout-duraton": "60"},
+		expectedProblems: []string{},
+		expectedWarnings: []string{
+			"The key rollout-duraton is unknown in the ConfigMap config-network. Did you mean rollout-duration?",
+		},
+	}, {
+		name:             "Unknown key without a suggestion",
+		version:          "",
+		data:             map[string]string{"ingress": "kourier"},
+		expectedProblems: []string{},
+		expectedWarnings: []string{
+			"The key ingress is unknown in the ConfigMap config-network.",
+		},
+	}, {
+		name:    "Invalid values",
+		version: "v1.13",
+		data: map[string]string{
+			"rollout-duration": "1m",
+			"http-protocol":    "on",
+		},
+		expectedProblems: []string{
+			"The value on of the key http-protocol should be one of Enabled, Disabled, Redirected.",
+			"The value 1m of the key rollout-duration should be of the type int.",
+		},
+		expectedWarnings: []string{},
+	}, {
+		name:             "Key not available in the version",
+		version:          "1.12.2",
+		data:             map[string]string{"external-domain-tls": "Enabled"},
+		expectedProblems: []string{},
+		expectedWarnings: []string{
+			"The key external-domain-tls in the ConfigMap config-network is only available since Knative v1.13.",
+		},
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			problems, warnings := CheckConfigMapData(entry, tt.version, tt.data)
+			testingUtil.AssertDeepEqual(t, problems, tt.expectedProblems)
+			testingUtil.AssertDeepEqual(t, warnings, tt.expectedWarnings)
+		})
+	}
+}
+
+func TestSuggestName(t *testing.T) {
+	candidates := []string{"enable-scale-to-zero", "scale-to-zero-grace-period", "min-scale", "max-scale"}
+	testingUtil.AssertEqual(t, SuggestName(candidates, "enable-scale-to-zer0"), "enable-scale-to-zero")
+	testingUtil.AssertEqual(t, SuggestName(candidates, "mx-scale"), "max-scale")
+	testingUtil.AssertEqual(t, SuggestName(candidates, "target"), "")
+}
+
+func TestFormatConfigMapEntry(t *testing.T) {
+	entry := ConfigMapEntry{
+		Component:   "serving",
+		Name:        "config-network",
+		Description: "The settings of the ingress.",
+		Keys: []ConfigMapKey{{
+			Name:        "rollout-duration",
+			Type:        IntType,
+			Default:     "0",
+			Description: "The number of seconds to roll out.",
+		}, {
+			Name:        "external-domain-tls",
+			Type:        EnumType,
+			Values:      []string{"Enabled", "Disabled"},
+			Since:       "v1.13",
+			Description: "Whether the TLS is enabled.",
+		}},
+	}
+	expectedResult := `config-network (Knative serving)
+  The settings of the ingress.
+
+KEYS:
+  rollout-duration
+    type: int
+    default: 0
+    The number of seconds to roll out.
+  external-domain-tls
+    type: enum (Enabled, Disabled)
+    since: v1.13
+    Whether the TLS is enabled.
+`
+	testingUtil.AssertEqual(t, FormatConfigMapEntry(entry, ""), expectedResult)
+	testingUtil.AssertEqual(t, FormatConfigMapEntry(entry, "1.12.0"), `config-network (Knative serving)
+  The settings of the ingress.
+
+KEYS:
+  rollout-duration
+    type: int
+    default: 0
+    The number of seconds to roll out.
+`)
+}
@@ -0,0 +1,349 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DefaultCustomManifestsName is the name of the custom manifests, if no name is specified. It matches the key
+	// custom-manifests.yaml used by the earlier versions of the plugin.
+	DefaultCustomManifestsName = "custom-manifests"
+	// MaxConfigMapDataSize is the size of the data stored in a ConfigMap, leaving room for the metadata under the
+	// limit of 1 MiB of a Kubernetes object.
+	MaxConfigMapDataSize = 1024*1024 - 64*1024
+
+	manifestsExtension = ".yaml"
+)
+
+var (
+	customManifestsNameRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+	customManifestsPartRegexp = regexp.MustCompile(`^(.*)\.part([0-9]+)$`)
+	documentSeparatorRegexp   = regexp.MustCompile(`(?m)^---[ \t]*$`)
+)
+
+// CustomManifests is a named set of the custom manifests stored in the ConfigMaps mounted into the Knative Operator
+type CustomManifests struct {
+	Name string
+	Data string
+}
+
+// ValidateCustomManifestsName checks the name of the custom manifests, which is used in the keys of the ConfigMaps
+func ValidateCustomManifestsName(name string) error {
+	if !customManifestsNameRegexp.MatchString(name) {
+		return fmt.Errorf("The name %s of the custom manifests should consist of lower case alphanumeric characters or '-', and start and end with an alphanumeric character.", name)
+	}
+	return nil
+}
+
+// ReadManifestsFiles returns the manifests in the files. The YAML files in a directory are read in the alphabetical
+// order, including the subdirectories.
+func ReadManifestsFiles(paths []string) (string, error) {
+	documents := []string{}
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return "", err
+		}
+		files := []string{path}
+		if info.IsDir() {
+			files = []string{}
+			err = filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+				if err != nil {
+					return err
+				}
+				if !info.IsDir() && (strings.HasSuffix(file, ".yaml") || strings.HasSuffix(file, ".yml")) {
+					files = append(files, file)
+				}
+				return nil
+			})
+			if err != nil {
+				return "", err
+			}
+			if len(files) == 0 {
+				return "", fmt.Errorf("No YAML file is found in the directory %s.", path)
+			}
+		}
+		for _, file := range files {
+			data, err := ReadFile(file)
+			if err != nil {
+				return "", err
+			}
+			documents = append(documents, SplitManifestsDocuments(data)...)
+		}
+	}
+	if len(documents) == 0 {
+		return "", fmt.Errorf("No manifest is found in %s.", strings.Join(paths, ", "))
+	}
+	return JoinManifestsDocuments(documents), nil
+}
+
+// SplitManifestsDocuments splits the YAML stream into the documents, dropping the empty ones
+func SplitManifestsDocuments(data string) []string {
+	documents := []string{}
+	for _, document := range documentSeparatorRegexp.Split(data, -1) {
+		if document = strings.TrimSpace(document); document != "" {
+			documents = append(documents, document)
+		}
+	}
+	return documents
+}
+
+// JoinManifestsDocuments joins the documents into a YAML stream
+func JoinManifestsDocuments(documents []string) string {
+	return fmt.Sprintf("%s\n", strings.Join(documents, fmt.Sprintf("%s%s%s", LineWrapper, Separator, LineWrapper)))
+}
+
+// SetCustomManifests adds the custom manifests with the name. The data is appended to the existing custom manifests
+// with the same name, unless overwrite is true.
+func SetCustomManifests(manifests []CustomManifests, name, data string, overwrite bool) []CustomManifests {
+	for i := range manifests {
+		if manifests[i].Name == name {
+			if !overwrite {
+				data = JoinManifestsDocuments(append(SplitManifestsDocuments(manifests[i].Data), SplitManifestsDocuments(data)...))
+			}
+			manifests[i].Data = data
+			return manifests
+		}
+	}
+	return append(manifests, CustomManifests{Name: name, Data: data})
+}
+
+// RemoveCustomManifests removes the custom manifests with the names
+func RemoveCustomManifests(manifests []CustomManifests, names []string) ([]CustomManifests, error) {
+	result := []CustomManifests{}
+	found := []string{}
+	for _, manifest := range manifests {
+		if Contains(names, manifest.Name) {
+			found = append(found, manifest.Name)
+			continue
+		}
+		result = append(result, manifest)
+	}
+	for _, name := range names {
+		if !Contains(found, name) {
+			return nil, fmt.Errorf("The custom manifests %s are not found.", name)
+		}
+	}
+	return result, nil
+}
+
+// CustomManifestsConfigMapName returns the name of the ConfigMap with the index storing the custom manifests
+func CustomManifestsConfigMapName(index int) string {
+	if index == 0 {
+		return ConfigMapName
+	}
+	return fmt.Sprintf("%s-%d", ConfigMapName, index)
+}
+
+// PackCustomManifests distributes the custom manifests into the data of the ConfigMaps, so that the data of each
+// ConfigMap is within the limit. The custom manifests exceeding the limit are split into parts at the boundaries of
+// the documents, stored under the keys name.yaml, name.part1.yaml, name.part2.yaml and so on.
+func PackCustomManifests(manifests []CustomManifests, limit int) ([]map[string]string, error) {
+	sorted := append([]CustomManifests{}, manifests...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	cmDataArray := []map[string]string{}
+	current := map[string]string{}
+	size := 0
+	for _, manifest := range sorted {
+		parts, err := splitCustomManifests(manifest, limit)
+		if err != nil {
+			return nil, err
+		}
+		for i, part := range parts {
+			key := fmt.Sprintf("%s%s", manifest.Name, manifestsExtension)
+			if i > 0 {
+				key = fmt.Sprintf("%s.part%d%s", manifest.Name, i, manifestsExtension)
+			}
+			if size+len(key)+len(part) > limit && len(current) > 0 {
+				cmDataArray = append(cmDataArray, current)
+				current = map[string]string{}
+				size = 0
+			}
+			current[key] = part
+			size += len(key) + len(part)
+		}
+	}
+	if len(current) > 0 {
+		cmDataArray = append(cmDataArray, current)
+	}
+	return cmDataArray, nil
+}
+
+// splitCustomManifests splits the custom manifests into the parts within the limit, leaving room for the key
+func splitCustomManifests(manifest CustomManifests, limit int) ([]string, error) {
+	// The longest key of a part, assuming there are less than a million parts
+	limit -= len(manifest.Name) + len(".part999999") + len(manifestsExtension)
+	parts := []string{}
+	documents := []string{}
+	size := 0
+	for _, document := range SplitManifestsDocuments(manifest.Data) {
+		documentSize := len(document) + len(Separator) + 2*len(LineWrapper)
+		if documentSize > limit {
+			return nil, fmt.Errorf("A manifest in the custom manifests %s is larger than the limit %d bytes of a ConfigMap.", manifest.Name, limit)
+		}
+		if size+documentSize > limit && len(documents) > 0 {
+			parts = append(parts, JoinManifestsDocuments(documents))
+			documents = []string{}
+			size = 0
+		}
+		documents = append(documents, document)
+		size += documentSize
+	}
+	if len(documents) > 0 {
+		parts = append(parts, JoinManifestsDocuments(documents))
+	}
+	return parts, nil
+}
+
+// UnpackCustomManifests collects the custom manifests from the data of the ConfigMaps, joining the parts
+func UnpackCustomManifests(cmDataArray []map[string]string) []CustomManifests {
+	parts := map[string]map[int]string{}
+	for _, cmData := range cmDataArray {
+		for key, data := range cmData {
+			name := strings.TrimSuffix(key, manifestsExtension)
+			index := 0
+			if match := customManifestsPartRegexp.FindStringSubmatch(name); match != nil {
+				name = match[1]
+				index, _ = strconv.Atoi(match[2])
+			}
+			if parts[name] == nil {
+				parts[name] = map[int]string{}
+			}
+			// The data written by the earlier versions of the plugin starts with a vertical bar
+			parts[name][index] = strings.TrimPrefix(data, fmt.Sprintf("%s%s", VerticalDelimiter, LineWrapper))
+		}
+	}
+
+	manifests := []CustomManifests{}
+	for name, partMap := range parts {
+		indexes := []int{}
+		for index := range partMap {
+			indexes = append(indexes, index)
+		}
+		sort.Ints(indexes)
+		documents := []string{}
+		for _, index := range indexes {
+			documents = append(documents, SplitManifestsDocuments(partMap[index])...)
+		}
+		manifests = append(manifests, CustomManifests{Name: name, Data: JoinManifestsDocuments(documents)})
+	}
+	sort.Slice(manifests, func(i, j int) bool { return manifests[i].Name < manifests[j].Name })
+	return manifests
+}
+
+// GetCustomManifests gets the custom manifests stored in the ConfigMaps under a certain namespace
+func (kr *KubeResource) GetCustomManifests(namespace string) ([]CustomManifests, error) {
+	cmDataArray := []map[string]string{}
+	for index := 0; ; index++ {
+		cm, err := kr.getConfigMap(CustomManifestsConfigMapName(index), namespace)
+		if err != nil {
+			return nil, err
+		}
+		if cm == nil || !isCustomManifestsConfigMap(cm, index) {
+			break
+		}
+		cmDataArray = append(cmDataArray, cm.Data)
+	}
+	return UnpackCustomManifests(cmDataArray), nil
+}
+
+// SaveCustomManifests stores the custom manifests in the ConfigMaps under a certain namespace, and deletes the
+// ConfigMaps no longer needed. It returns the names of the ConfigMaps storing the custom manifests. The existing
+// ConfigMaps, which do not store the custom manifests, are neither changed nor deleted.
+func (kr *KubeResource) SaveCustomManifests(namespace string, manifests []CustomManifests) ([]string, error) {
+	cmDataArray, err := PackCustomManifests(manifests, MaxConfigMapDataSize)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for index, cmData := range cmDataArray {
+		name := CustomManifestsConfigMapName(index)
+		names = append(names, name)
+		cm, err := kr.getConfigMap(name, namespace)
+		if err != nil {
+			return nil, err
+		}
+		if cm == nil {
+			cm = &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: namespace,
+					Labels:    map[string]string{ManagedByLabel: PluginName},
+				},
+				Data: cmData,
+			}
+			if _, err = kr.KubeClient.CoreV1().ConfigMaps(namespace).Create(context.TODO(), cm, metav1.CreateOptions{}); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if !isCustomManifestsConfigMap(cm, index) {
+			return nil, notManagedByPluginError(ConfigMapType, name, namespace)
+		}
+		// Adopt the ConfigMap written by the earlier versions of the plugin
+		if cm.Labels == nil {
+			cm.Labels = map[string]string{}
+		}
+		cm.Labels[ManagedByLabel] = PluginName
+		cm.Data = cmData
+		if _, err = kr.KubeClient.CoreV1().ConfigMaps(namespace).Update(context.TODO(), cm, metav1.UpdateOptions{}); err != nil {
+			return nil, err
+		}
+	}
+
+	for index := len(cmDataArray); ; index++ {
+		name := CustomManifestsConfigMapName(index)
+		cm, err := kr.getConfigMap(name, namespace)
+		if err != nil {
+			return nil, err
+		}
+		if cm == nil || !isCustomManifestsConfigMap(cm, index) {
+			break
+		}
+		err = kr.KubeClient.CoreV1().ConfigMaps(namespace).Delete(context.TODO(), name, metav1.DeleteOptions{})
+		if err != nil && !apierrs.IsNotFound(err) {
+			return nil, err
+		}
+	}
+	return names, nil
+}
+
+// isCustomManifestsConfigMap checks whether the ConfigMap with the index stores the custom manifests. The ConfigMaps
+// are labeled as managed by the plugin, except the ConfigMap config-manifest written by the earlier versions of the
+// plugin, which is recognized by the key custom-manifests.yaml.
+func isCustomManifestsConfigMap(cm *v1.ConfigMap, index int) bool {
+	if isManagedByPlugin(cm.Labels) {
+		return true
+	}
+	_, found := cm.Data[CustomDataKey]
+	return index == 0 && found
+}
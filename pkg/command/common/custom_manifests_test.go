@@ -0,0 +1,173 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/kn-plugin-operator/pkg/command/testingUtil"
+)
+
+func TestValidateCustomManifestsName(t *testing.T) {
+	testingUtil.AssertEqual(t, ValidateCustomManifestsName("monitoring-1"), nil)
+	for _, name := range []string{"Monitoring", "monitoring.part1", "-monitoring", ""} {
+		testingUtil.AssertEqual(t, ValidateCustomManifestsName(name).Error(), fmt.Sprintf("The name %s of the custom manifests should consist of lower case alphanumeric characters or '-', and start and end with an alphanumeric character.", name))
+	}
+}
+
+func TestReadManifestsFiles(t *testing.T) {
+	result, err := ReadManifestsFiles([]string{"testdata/manifests"})
+	testingUtil.AssertEqual(t, err, nil)
+	testingUtil.AssertEqual(t, result, `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: a
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: b
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: c
+`)
+
+	result, err = ReadManifestsFiles([]string{"testdata/manifests/nested/c.yml", "testdata/manifests/a.yaml"})
+	testingUtil.AssertEqual(t, err, nil)
+	testingUtil.AssertEqual(t, len(SplitManifestsDocuments(result)), 3)
+	testingUtil.AssertEqual(t, strings.HasPrefix(result, "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: c\n---\n"), true)
+
+	_, err = ReadManifestsFiles([]string{"testdata/manifests/README.md"})
+	testingUtil.AssertEqual(t, err == nil, true)
+}
+
+func TestSetCustomManifests(t *testing.T) {
+	manifests := []CustomManifests{{Name: "monitoring", Data: "a: 1\n"}}
+	result := SetCustomManifests(manifests, "monitoring", "b: 2\n", false)
+	testingUtil.AssertDeepEqual(t, result, []CustomManifests{{Name: "monitoring", Data: "a: 1\n---\nb: 2\n"}})
+
+	result = SetCustomManifests(result, "monitoring", "c: 3\n", true)
+	testingUtil.AssertDeepEqual(t, result, []CustomManifests{{Name: "monitoring", Data: "c: 3\n"}})
+
+	result = SetCustomManifests(result, "policies", "d: 4\n", false)
+	testingUtil.AssertDeepEqual(t, result, []CustomManifests{{Name: "monitoring", Data: "c: 3\n"}, {Name: "policies", Data: "d: 4\n"}})
+}
+
+func TestRemoveCustomManifests(t *testing.T) {
+	manifests := []CustomManifests{{Name: "monitoring", Data: "a: 1\n"}, {Name: "policies", Data: "b: 2\n"}}
+	result, err := RemoveCustomManifests(manifests, []string{"policies"})
+	testingUtil.AssertEqual(t, err, nil)
+	testingUtil.AssertDeepEqual(t, result, []CustomManifests{{Name: "monitoring", Data: "a: 1\n"}})
+
+	_, err = RemoveCustomManifests(manifests, []string{"policies", "dashboards"})
+	testingUtil.AssertEqual(t, err.Error(), "The custom manifests dashboards are not found.")
+}
+
+func TestCustomManifestsConfigMapName(t *testing.T) {
+	testingUtil.AssertEqual(t, CustomManifestsConfigMapName(0), "config-manifest")
+	testingUtil.AssertEqual(t, CustomManifestsConfigMapName(2), "config-manifest-2")
+}
+
+func TestPackCustomManifests(t *testing.T) {
+	document := strings.Repeat("a", 40)
+	manifests := []CustomManifests{{
+		Name: "small",
+		Data: JoinManifestsDocuments([]string{document}),
+	}, {
+		Name: "large",
+		Data: JoinManifestsDocuments([]string{document, document, document}),
+	}}
+
+	result, err := PackCustomManifests(manifests, 120)
+	testingUtil.AssertEqual(t, err, nil)
+	testingUtil.AssertDeepEqual(t, result, []map[string]string{{
+		"large.yaml": fmt.Sprintf("%s\n---\n%s\n", document, document),
+	}, {
+		"large.part1.yaml": fmt.Sprintf("%s\n", document),
+		"small.yaml":       fmt.Sprintf("%s\n", document),
+	}})
+	testingUtil.AssertDeepEqual(t, UnpackCustomManifests(result), []CustomManifests{manifests[1], manifests[0]})
+
+	result, err = PackCustomManifests(manifests, MaxConfigMapDataSize)
+	testingUtil.AssertEqual(t, err, nil)
+	testingUtil.AssertEqual(t, len(result), 1)
+	testingUtil.AssertDeepEqual(t, UnpackCustomManifests(result), []CustomManifests{manifests[1], manifests[0]})
+
+	_, err = PackCustomManifests(manifests, 60)
+	testingUtil.AssertEqual(t, err != nil, true)
+}
+
+func TestUnpackCustomManifests(t *testing.T) {
+	result := UnpackCustomManifests([]map[string]string{{
+		CustomDataKey: "|\napiVersion: v1\nkind: ConfigMap\n",
+	}})
+	testingUtil.AssertDeepEqual(t, result, []CustomManifests{{
+		Name: DefaultCustomManifestsName,
+		Data: "apiVersion: v1\nkind: ConfigMap\n",
+	}})
+}
+
+func TestIsCustomManifestsConfigMap(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		cm             *v1.ConfigMap
+		index          int
+		expectedResult bool
+	}{{
+		name: "ConfigMap created by the plugin",
+		cm: &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "config-manifest-1", Labels: map[string]string{ManagedByLabel: PluginName}},
+			Data:       map[string]string{"kourier.yaml": "kind: Service"},
+		},
+		index:          1,
+		expectedResult: true,
+	}, {
+		name: "Legacy ConfigMap with the custom manifests key",
+		cm: &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "config-manifest"},
+			Data:       map[string]string{CustomDataKey: "kind: Service"},
+		},
+		index:          0,
+		expectedResult: true,
+	}, {
+		name: "Unrelated ConfigMap with the same name",
+		cm: &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "config-manifest"},
+			Data:       map[string]string{"app.properties": "debug=true"},
+		},
+		index:          0,
+		expectedResult: false,
+	}, {
+		name: "Unlabeled ConfigMap with the custom manifests key and an index",
+		cm: &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "config-manifest-2"},
+			Data:       map[string]string{CustomDataKey: "kind: Service"},
+		},
+		index:          2,
+		expectedResult: false,
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			testingUtil.AssertEqual(t, isCustomManifestsConfigMap(tt.cm, tt.index), tt.expectedResult)
+		})
+	}
+}
@@ -19,6 +19,7 @@ package common
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -30,8 +31,17 @@ const (
 	KnativeOperatorName       = "knative-operator"
 	KnativeServingActivator   = "activator"
 	KnativeEventingController = "eventing-controller"
+
+	// ComponentNameLabel is the label set by Knative on its deployments to indicate the component they belong to
+	ComponentNameLabel = "app.kubernetes.io/name"
 )
 
+// WorkloadTarget identifies a container of a deployment to configure
+type WorkloadTarget struct {
+	DeployName    string
+	ContainerName string
+}
+
 // Deployment is used to access the cluster to check if the deployment of the knative operator exists
 type Deployment struct {
 	Client kubernetes.Interface
@@ -84,3 +94,84 @@ func (d *Deployment) CheckIfKnativeServingInstalled() (bool, string, string, err
 func (d *Deployment) CheckIfKnativeEventingInstalled() (bool, string, string, error) {
 	return d.CheckIfKeyDeploymentInstalled(KnativeEventingController)
 }
+
+// GetPodLabels returns the labels selecting the pods of the deployment under a certain namespace
+func (d *Deployment) GetPodLabels(name, namespace string) (map[string]string, error) {
+	deploy, err := d.Client.AppsV1().Deployments(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if deploy.Spec.Selector == nil {
+		return nil, nil
+	}
+	return deploy.Spec.Selector.MatchLabels, nil
+}
+
+// GetContainerDeployments returns the names of the deployments under a certain namespace, indexed by the names of
+// their containers
+func (d *Deployment) GetContainerDeployments(namespace string) (map[string][]string, error) {
+	deployList, err := d.Client.AppsV1().Deployments(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	containerDeployments := map[string][]string{}
+	for _, deploy := range deployList.Items {
+		for _, container := range deploy.Spec.Template.Spec.Containers {
+			containerDeployments[container.Name] = append(containerDeployments[container.Name], deploy.Name)
+		}
+	}
+	return containerDeployments, nil
+}
+
+// GetComponentDeployments returns the containers of the deployments of the Knative component under a certain namespace,
+// indexed by the names of the deployments
+func (d *Deployment) GetComponentDeployments(component, namespace string) (map[string][]string, error) {
+	name := KnativeServingName
+	if strings.EqualFold(component, EventingComponent) {
+		name = KnativeEventingName
+	}
+	deployList, err := d.Client.AppsV1().Deployments(namespace).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", ComponentNameLabel, name),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	deploymentContainers := map[string][]string{}
+	for _, deploy := range deployList.Items {
+		for _, container := range deploy.Spec.Template.Spec.Containers {
+			deploymentContainers[deploy.Name] = append(deploymentContainers[deploy.Name], container.Name)
+		}
+	}
+	return deploymentContainers, nil
+}
+
+// DeploymentNames returns the sorted names of the deployments
+func DeploymentNames(deploymentContainers map[string][]string) []string {
+	names := make([]string, 0, len(deploymentContainers))
+	for name := range deploymentContainers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetWorkloadTargets returns the containers of the deployments sorted by name. If the container is specified, only
+// the deployments with the container are returned.
+func GetWorkloadTargets(deploymentContainers map[string][]string, container string) []WorkloadTarget {
+	targets := []WorkloadTarget{}
+	for _, name := range DeploymentNames(deploymentContainers) {
+		containers := append([]string{}, deploymentContainers[name]...)
+		sort.Strings(containers)
+		for _, c := range containers {
+			if container == "" || c == container {
+				targets = append(targets, WorkloadTarget{DeployName: name, ContainerName: c})
+			}
+		}
+	}
+	return targets
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	"knative.dev/kn-plugin-operator/pkg/command/testingUtil"
+)
+
+func TestGetWorkloadTargets(t *testing.T) {
+	deploymentContainers := map[string][]string{
+		"controller": {"controller"},
+		"activator":  {"activator"},
+		"webhook":    {"webhook", "sidecar"},
+	}
+	for _, tt := range []struct {
+		name           string
+		container      string
+		expectedResult []WorkloadTarget
+	}{{
+		name: "All the containers",
+		expectedResult: []WorkloadTarget{
+			{DeployName: "activator", ContainerName: "activator"},
+			{DeployName: "controller", ContainerName: "controller"},
+			{DeployName: "webhook", ContainerName: "sidecar"},
+			{DeployName: "webhook", ContainerName: "webhook"},
+		},
+	}, {
+		name:      "Only the specified container",
+		container: "sidecar",
+		expectedResult: []WorkloadTarget{
+			{DeployName: "webhook", ContainerName: "sidecar"},
+		},
+	}, {
+		name:           "Container not found",
+		container:      "queue-proxy",
+		expectedResult: []WorkloadTarget{},
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := GetWorkloadTargets(deploymentContainers, tt.container)
+			testingUtil.AssertDeepEqual(t, result, tt.expectedResult)
+		})
+	}
+}
+
+func TestDeploymentNames(t *testing.T) {
+	result := DeploymentNames(map[string][]string{
+		"webhook":    {"webhook"},
+		"activator":  {"activator"},
+		"controller": {"controller"},
+	})
+	testingUtil.AssertDeepEqual(t, result, []string{"activator", "controller", "webhook"})
+}
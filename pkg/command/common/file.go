@@ -17,10 +17,14 @@ limitations under the License.
 package common
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"os"
+
+	"github.com/ghodss/yaml"
 )
 
 // WriteFile creates a file with the string content
@@ -49,6 +53,24 @@ func ReadFile(path string) (string, error) {
 	return string(b), nil
 }
 
+// ReadYamlFile decodes the yaml content of the file into obj, rejecting the fields unknown to obj
+func ReadYamlFile(path string, obj interface{}) error {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	jsonData, err := yaml.YAMLToJSON(b)
+	if err != nil {
+		return fmt.Errorf("failed to parse the file %s: %w", path, err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(jsonData))
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(obj); err != nil {
+		return fmt.Errorf("failed to parse the file %s: %w", path, err)
+	}
+	return nil
+}
+
 // DeleteFile deletes the file
 func DeleteFile(path string) error {
 	err := os.Remove(path)
@@ -21,6 +21,7 @@ import (
 	"os"
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
 	"knative.dev/kn-plugin-operator/pkg/command/testingUtil"
 )
 
@@ -47,3 +48,28 @@ func TestReadWriteDeleteFile(t *testing.T) {
 	_, err = os.Stat(path)
 	testingUtil.AssertEqual(t, errors.Is(err, os.ErrNotExist), true)
 }
+
+func TestReadYamlFile(t *testing.T) {
+	affinity := corev1.Affinity{}
+	err := ReadYamlFile("testdata/affinity.yaml", &affinity)
+	testingUtil.AssertEqual(t, err == nil, true)
+	testingUtil.AssertDeepEqual(t, affinity, corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+					MatchExpressions: []corev1.NodeSelectorRequirement{{
+						Key:      "node-role",
+						Operator: corev1.NodeSelectorOpIn,
+						Values:   []string{"knative"},
+					}},
+				}},
+			},
+		},
+	})
+
+	err = ReadYamlFile("testdata/affinity_unknown.yaml", &corev1.Affinity{})
+	testingUtil.AssertEqual(t, err != nil, true)
+
+	err = ReadYamlFile("testdata/missing.yaml", &corev1.Affinity{})
+	testingUtil.AssertEqual(t, errors.Is(err, os.ErrNotExist), true)
+}
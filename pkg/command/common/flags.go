@@ -16,12 +16,22 @@ limitations under the License.
 
 package common
 
+import (
+	"fmt"
+	"strings"
+)
+
 type CMsFlags struct {
 	Value     string
 	Key       string
 	Component string
 	Namespace string
 	CMName    string
+	Set       []string
+	SetFile   []string
+	FromFile  string
+	Mode      string
+	Force     bool
 }
 
 type KeyValueFlags struct {
@@ -35,4 +45,68 @@ type KeyValueFlags struct {
 	NodeSelector bool
 	Annotation   bool
 	Label        bool
+
+	AllDeployments bool
+	Set            []string
+	SetFile        []string
+}
+
+// KeyValue is a key value pair specified by --key and --value, --set or --set-file
+type KeyValue struct {
+	Key   string
+	Value string
+}
+
+// ParseKeyValue splits the pair in the format of key=value specified by the flag
+func ParseKeyValue(pair, flag string) (string, string, error) {
+	parts := strings.SplitN(pair, "=", 2)
+	if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+		return "", "", fmt.Errorf("The value %s of %s should be in the format of key=value.", pair, flag)
+	}
+	return strings.TrimSpace(parts[0]), parts[1], nil
+}
+
+// ValidateSetFlags checks the format of the pairs specified by --set and --set-file
+func ValidateSetFlags(set, setFile []string) error {
+	for _, pair := range set {
+		if _, _, err := ParseKeyValue(pair, "--set"); err != nil {
+			return err
+		}
+	}
+	for _, pair := range setFile {
+		if _, path, err := ParseKeyValue(pair, "--set-file"); err != nil {
+			return err
+		} else if path == "" {
+			return fmt.Errorf("You need to specify the path of the file in %s.", pair)
+		}
+	}
+	return nil
+}
+
+// GetKeyValuePairs returns the pair of the key and the value if the key is not empty, followed by the pairs specified
+// by --set, and the pairs specified by --set-file with the values read from the files
+func GetKeyValuePairs(key, value string, set, setFile []string) ([]KeyValue, error) {
+	pairs := []KeyValue{}
+	if key != "" {
+		pairs = append(pairs, KeyValue{Key: key, Value: value})
+	}
+	for _, pair := range set {
+		k, v, err := ParseKeyValue(pair, "--set")
+		if err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, KeyValue{Key: k, Value: v})
+	}
+	for _, pair := range setFile {
+		k, path, err := ParseKeyValue(pair, "--set-file")
+		if err != nil {
+			return nil, err
+		}
+		v, err := ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, KeyValue{Key: k, Value: v})
+	}
+	return pairs, nil
 }
@@ -0,0 +1,99 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"fmt"
+	"testing"
+
+	"knative.dev/kn-plugin-operator/pkg/command/testingUtil"
+)
+
+func TestValidateSetFlags(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		set         []string
+		setFile     []string
+		expectedErr error
+	}{{
+		name:    "Valid pairs",
+		set:     []string{"container-concurrency-target-default=100", "enable-scale-to-zero=false", "empty="},
+		setFile: []string{"_example=testdata/test.txt"},
+	}, {
+		name:        "Pair without the equal sign",
+		set:         []string{"enable-scale-to-zero"},
+		expectedErr: fmt.Errorf("The value enable-scale-to-zero of --set should be in the format of key=value."),
+	}, {
+		name:        "Pair without the key",
+		set:         []string{"=false"},
+		expectedErr: fmt.Errorf("The value =false of --set should be in the format of key=value."),
+	}, {
+		name:        "File pair without the path",
+		setFile:     []string{"_example="},
+		expectedErr: fmt.Errorf("You need to specify the path of the file in _example=."),
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSetFlags(tt.set, tt.setFile)
+			if tt.expectedErr != nil {
+				testingUtil.AssertEqual(t, err.Error(), tt.expectedErr.Error())
+			} else {
+				testingUtil.AssertEqual(t, err, nil)
+			}
+		})
+	}
+}
+
+func TestGetKeyValuePairs(t *testing.T) {
+	content, err := ReadFile("testdata/test.txt")
+	testingUtil.AssertEqual(t, err, nil)
+
+	for _, tt := range []struct {
+		name           string
+		key            string
+		value          string
+		set            []string
+		setFile        []string
+		expectedResult []KeyValue
+	}{{
+		name:           "Key and value only",
+		key:            "key",
+		value:          "value",
+		expectedResult: []KeyValue{{Key: "key", Value: "value"}},
+	}, {
+		name:    "Key and value with set and set file",
+		key:     "key",
+		value:   "value",
+		set:     []string{"a=1", "b=x=y"},
+		setFile: []string{"c=testdata/test.txt"},
+		expectedResult: []KeyValue{
+			{Key: "key", Value: "value"},
+			{Key: "a", Value: "1"},
+			{Key: "b", Value: "x=y"},
+			{Key: "c", Value: content},
+		},
+	}, {
+		name:           "Set only",
+		set:            []string{"a=1"},
+		expectedResult: []KeyValue{{Key: "a", Value: "1"}},
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := GetKeyValuePairs(tt.key, tt.value, tt.set, tt.setFile)
+			testingUtil.AssertEqual(t, err, nil)
+			testingUtil.AssertDeepEqual(t, result, tt.expectedResult)
+		})
+	}
+}
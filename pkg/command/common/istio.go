@@ -0,0 +1,81 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"knative.dev/operator/pkg/apis/operator/base"
+	servingv1beta1 "knative.dev/operator/pkg/apis/operator/v1beta1"
+)
+
+const (
+	IstioIngressGateway       = "ingress"
+	IstioLocalGateway         = "local"
+	KnativeIngressGatewayName = "knative-ingress-gateway"
+	KnativeLocalGatewayName   = "knative-local-gateway"
+)
+
+// GetIstioGatewayName returns the name of the istio gateway for the short name ingress or local
+func GetIstioGatewayName(gateway string) (string, error) {
+	if strings.EqualFold(gateway, IstioIngressGateway) {
+		return KnativeIngressGatewayName, nil
+	} else if strings.EqualFold(gateway, IstioLocalGateway) {
+		return KnativeLocalGatewayName, nil
+	}
+	return "", fmt.Errorf("You need to specify the gateway to one of the following values: ingress or local.")
+}
+
+// GetIstioGatewayOverrideFromSpec returns the override of the istio gateway in the Knative Serving spec
+func GetIstioGatewayOverrideFromSpec(spec *servingv1beta1.KnativeServingSpec, gatewayName string) *base.IstioGatewayOverride {
+	if spec.Ingress == nil {
+		return nil
+	}
+	if gatewayName == KnativeLocalGatewayName {
+		return spec.Ingress.Istio.KnativeLocalGateway
+	}
+	return spec.Ingress.Istio.KnativeIngressGateway
+}
+
+// SetIstioGatewayOverrideInSpec sets the override of the istio gateway in the Knative Serving spec
+func SetIstioGatewayOverrideInSpec(spec *servingv1beta1.KnativeServingSpec, gatewayName string, override *base.IstioGatewayOverride) {
+	if spec.Ingress == nil {
+		if override == nil {
+			return
+		}
+		spec.Ingress = &servingv1beta1.IngressConfigs{}
+	}
+	if gatewayName == KnativeLocalGatewayName {
+		spec.Ingress.Istio.KnativeLocalGateway = override
+	} else {
+		spec.Ingress.Istio.KnativeIngressGateway = override
+	}
+}
+
+// GetIstioGatewayOverride gets the override of the istio gateway in the Knative Serving custom resource. It returns
+// nil, if the custom resource is not available in the cluster.
+func (ko *KnativeOperatorCR) GetIstioGatewayOverride(namespace, gatewayName string) (*base.IstioGatewayOverride, error) {
+	ks, err := ko.GetKnativeServingInCluster(namespace)
+	if apierrs.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return GetIstioGatewayOverrideFromSpec(&ks.Spec, gatewayName), nil
+}
@@ -18,6 +18,8 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/ghodss/yaml"
+	"github.com/pmezard/go-difflib/difflib"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/retry"
@@ -145,23 +147,26 @@ func (ko *KnativeOperatorCR) UpdateConfigMaps(component, namespace string, cmDat
 	return ko.UpdateCommonSpec(component, namespace, commonSpec)
 }
 
-func (ko *KnativeOperatorCR) GetDeployments(component, namespace string) ([]base.WorkloadOverride, error) {
-	var workloadOverrides []base.WorkloadOverride
-	if strings.EqualFold(component, ServingComponent) {
-		ks, err := ko.GetKnativeServingInCluster(namespace)
-		if err != nil {
-			return workloadOverrides, err
-		}
-		workloadOverrides = ks.Spec.DeploymentOverride
-	} else if strings.EqualFold(component, EventingComponent) {
-		ke, err := ko.GetKnativeEventingInCluster(namespace)
-		if err != nil {
-			return workloadOverrides, err
-		}
-		workloadOverrides = ke.Spec.DeploymentOverride
+// UpdateWorkloadOverrides updates the workload overrides of the Knative custom resource under a certain namespace
+// with the update function. The operator applies the overrides in both spec.workloads and the deprecated
+// spec.deployments, so the update function is applied to both lists.
+func (ko *KnativeOperatorCR) UpdateWorkloadOverrides(component, namespace string, update func([]base.WorkloadOverride) []base.WorkloadOverride) error {
+	commonSpec, err := ko.GetCommonSpec(component, namespace)
+	if err != nil {
+		return err
 	}
+	return ko.UpdateCommonSpec(component, namespace, ApplyToWorkloadOverrides(commonSpec, update))
+}
 
-	return workloadOverrides, nil
+// ApplyToWorkloadOverrides applies the update function to spec.workloads and the deprecated spec.deployments
+func ApplyToWorkloadOverrides(commonSpec *base.CommonSpec, update func([]base.WorkloadOverride) []base.WorkloadOverride) *base.CommonSpec {
+	if len(commonSpec.Workloads) > 0 {
+		commonSpec.Workloads = update(commonSpec.Workloads)
+	}
+	if len(commonSpec.DeploymentOverride) > 0 {
+		commonSpec.DeploymentOverride = update(commonSpec.DeploymentOverride)
+	}
+	return commonSpec
 }
 
 func (ko *KnativeOperatorCR) GetServices(component, namespace string) ([]base.ServiceOverride, error) {
@@ -183,15 +188,6 @@ func (ko *KnativeOperatorCR) GetServices(component, namespace string) ([]base.Se
 	return serviceOverrides, nil
 }
 
-func (ko *KnativeOperatorCR) UpdateDeployments(component, namespace string, workloadOverrides []base.WorkloadOverride) error {
-	commonSpec, err := ko.GetCommonSpec(component, namespace)
-	if err != nil {
-		return err
-	}
-	commonSpec.DeploymentOverride = workloadOverrides
-	return ko.UpdateCommonSpec(component, namespace, commonSpec)
-}
-
 func (ko *KnativeOperatorCR) UpdateServices(component, namespace string, serviceOverrides []base.ServiceOverride) error {
 	commonSpec, err := ko.GetCommonSpec(component, namespace)
 	if err != nil {
@@ -221,6 +217,18 @@ func (ko *KnativeOperatorCR) GetCommonSpec(component, namespace string) (*base.C
 	return &commonSpec, nil
 }
 
+// GetNamespaceConfiguration gets the labels and annotations configured for the namespace of the Knative custom
+// resource. It returns nil, if the custom resource is not available in the cluster.
+func (ko *KnativeOperatorCR) GetNamespaceConfiguration(component, namespace string) (*base.NamespaceConfiguration, error) {
+	commonSpec, err := ko.GetCommonSpec(component, namespace)
+	if apierrs.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return commonSpec.NamespaceConfiguration, nil
+}
+
 func (ko *KnativeOperatorCR) UpdateCommonSpec(component, namespace string, commonSpec *base.CommonSpec) error {
 	if strings.EqualFold(component, ServingComponent) {
 		ks, err := ko.GetKnativeServingInCluster(namespace)
@@ -248,6 +256,104 @@ func (ko *KnativeOperatorCR) UpdateCommonSpec(component, namespace string, commo
 	return nil
 }
 
+// GetCommonSpecWithResourceVersion gets the common spec and the resource version of the Knative custom resource under a
+// certain namespace
+func (ko *KnativeOperatorCR) GetCommonSpecWithResourceVersion(component, namespace string) (*base.CommonSpec, string, error) {
+	if strings.EqualFold(component, EventingComponent) {
+		ke, err := ko.GetKnativeEventingInCluster(namespace)
+		if err != nil {
+			return nil, "", err
+		}
+		return &ke.Spec.CommonSpec, ke.ResourceVersion, nil
+	}
+	ks, err := ko.GetKnativeServingInCluster(namespace)
+	if err != nil {
+		return nil, "", err
+	}
+	return &ks.Spec.CommonSpec, ks.ResourceVersion, nil
+}
+
+// UpdateCommonSpecAtResourceVersion updates the common spec of the Knative custom resource, only if the custom resource
+// still has the resource version. Otherwise, it returns the conflict error of the API server.
+func (ko *KnativeOperatorCR) UpdateCommonSpecAtResourceVersion(component, namespace string, commonSpec *base.CommonSpec, resourceVersion string) error {
+	if strings.EqualFold(component, EventingComponent) {
+		ke, err := ko.GetKnativeEventingInCluster(namespace)
+		if err != nil {
+			return err
+		}
+		ke.Spec.CommonSpec = *commonSpec
+		ke.ResourceVersion = resourceVersion
+		_, err = ko.UpdateKnativeEventing(ke)
+		return err
+	}
+	ks, err := ko.GetKnativeServingInCluster(namespace)
+	if err != nil {
+		return err
+	}
+	ks.Spec.CommonSpec = *commonSpec
+	ks.ResourceVersion = resourceVersion
+	_, err = ko.UpdateKnativeServing(ks)
+	return err
+}
+
+// GetAnnotation gets the value of the annotation of the Knative custom resource under a certain namespace
+func (ko *KnativeOperatorCR) GetAnnotation(component, namespace, key string) (string, error) {
+	var annotations map[string]string
+	if strings.EqualFold(component, ServingComponent) {
+		ks, err := ko.GetKnativeServingInCluster(namespace)
+		if err != nil {
+			return "", err
+		}
+		annotations = ks.Annotations
+	} else if strings.EqualFold(component, EventingComponent) {
+		ke, err := ko.GetKnativeEventingInCluster(namespace)
+		if err != nil {
+			return "", err
+		}
+		annotations = ke.Annotations
+	}
+	return annotations[key], nil
+}
+
+// UpdateCommonSpecWithAnnotation updates the common spec and the annotation of the Knative custom resource in a single
+// update. The annotation is removed, if the value is empty.
+func (ko *KnativeOperatorCR) UpdateCommonSpecWithAnnotation(component, namespace string, commonSpec *base.CommonSpec, key, value string) error {
+	if strings.EqualFold(component, ServingComponent) {
+		ks, err := ko.GetKnativeServingInCluster(namespace)
+		if err != nil {
+			return err
+		}
+		ks.Spec.CommonSpec = *commonSpec
+		ks.Annotations = setAnnotation(ks.Annotations, key, value)
+		if _, err = ko.UpdateKnativeServing(ks); err != nil {
+			return err
+		}
+	} else if strings.EqualFold(component, EventingComponent) {
+		ke, err := ko.GetKnativeEventingInCluster(namespace)
+		if err != nil {
+			return err
+		}
+		ke.Spec.CommonSpec = *commonSpec
+		ke.Annotations = setAnnotation(ke.Annotations, key, value)
+		if _, err = ko.UpdateKnativeEventing(ke); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setAnnotation(annotations map[string]string, key, value string) map[string]string {
+	if value == "" {
+		delete(annotations, key)
+		return annotations
+	}
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[key] = value
+	return annotations
+}
+
 // GetKnativeServingInCluster gets the Knative Serving custom resource in the cluster under a certain namespace
 func (ko *KnativeOperatorCR) GetKnativeServingInCluster(namespace string) (*servingv1beta1.KnativeServing, error) {
 	return ko.KnativeOperatorClient.OperatorV1beta1().KnativeServings(namespace).Get(context.TODO(),
@@ -317,6 +423,44 @@ func GenerateOperatorCRString(component, namespace string, p *pkg.OperatorParams
 	return yamlGenerator.GenerateYamlOutput()
 }
 
+// OverlayValues pairs the ytt overlay with the data values it is rendered with
+type OverlayValues struct {
+	Overlay string
+	Values  string
+}
+
+// RenderOverlays renders the overlays one after another on the template, so that each overlay is applied on the
+// output of the previous one
+func RenderOverlays(yamlTemplateString string, overlays []OverlayValues) (string, error) {
+	output := yamlTemplateString
+	for _, overlay := range overlays {
+		yttp := YttProcessor{
+			BaseData:    []byte(output),
+			OverlayData: []byte(overlay.Overlay),
+			ValuesData:  []byte(overlay.Values),
+		}
+		result, err := yttp.GenerateOutput()
+		if err != nil {
+			return "", err
+		}
+		output = result
+	}
+	return output, nil
+}
+
+// ApplyManifestsWithOverlays renders all the overlays on the template, and applies the result in a single update
+func ApplyManifestsWithOverlays(yamlTemplateString string, overlays []OverlayValues, p *pkg.OperatorParams) error {
+	if len(overlays) == 0 {
+		return nil
+	}
+	last := len(overlays) - 1
+	yamlTemplateString, err := RenderOverlays(yamlTemplateString, overlays[:last])
+	if err != nil {
+		return err
+	}
+	return ApplyManifests(yamlTemplateString, overlays[last].Overlay, overlays[last].Values, p)
+}
+
 func ApplyManifests(yamlTemplateString, overlayContent, yamlValuesContent string, p *pkg.OperatorParams) error {
 	restConfig, err := p.RestConfig()
 	if err != nil {
@@ -344,3 +488,22 @@ func ApplyManifests(yamlTemplateString, overlayContent, yamlValuesContent string
 
 	return nil
 }
+
+// GetCommonSpecDiff returns the unified diff between the YAML of the current spec and the changed spec
+func GetCommonSpecDiff(current, changed *base.CommonSpec, fromFile, toFile string) (string, error) {
+	currentYaml, err := yaml.Marshal(current)
+	if err != nil {
+		return "", err
+	}
+	changedYaml, err := yaml.Marshal(changed)
+	if err != nil {
+		return "", err
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(strings.TrimSuffix(string(currentYaml), "\n")),
+		B:        difflib.SplitLines(strings.TrimSuffix(string(changedYaml), "\n")),
+		FromFile: fromFile,
+		ToFile:   toFile,
+		Context:  3,
+	})
+}
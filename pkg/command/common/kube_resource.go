@@ -36,6 +36,12 @@ const (
 	MountPath         = "/knative-custom-manifest"
 	CustomVolumeName  = "config-manifest-volume"
 	ConfigMapName     = "config-manifest"
+
+	// ManagedByLabel marks the resources created by the plugin, so that they can be cleaned up.
+	ManagedByLabel = "app.kubernetes.io/managed-by"
+	PluginName     = "kn-plugin-operator"
+	SecretType     = "Secret"
+	ConfigMapType  = "ConfigMap"
 )
 
 // KubeResource is used to access the Kubernetes resources in the Kubernetes cluster.
@@ -102,8 +108,157 @@ func (kr *KubeResource) getConfigMap(name, namespace string) (*v1.ConfigMap, err
 	return cm, nil
 }
 
-// UpdateOperatorDeployment updates the deployment of the operator
-func (kr *KubeResource) UpdateOperatorDeployment(name, namespace string) error {
+// CreateOrUpdateDockerRegistrySecret creates or updates the docker-registry Secret, labeled as managed by the plugin,
+// with the docker config under a certain namespace. An existing Secret, which was not created by the plugin, is not changed.
+func (kr *KubeResource) CreateOrUpdateDockerRegistrySecret(name, namespace string, dockerConfig []byte) error {
+	secret, err := kr.getSecret(name, namespace)
+	if err != nil {
+		return err
+	}
+
+	if secret == nil {
+		// Create the Secret
+		secret = &v1.Secret{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Secret",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels:    map[string]string{ManagedByLabel: PluginName},
+			},
+			Type: v1.SecretTypeDockerConfigJson,
+			Data: map[string][]byte{v1.DockerConfigJsonKey: dockerConfig},
+		}
+
+		if _, err := kr.KubeClient.CoreV1().Secrets(namespace).Create(context.TODO(),
+			secret, metav1.CreateOptions{}); err != nil {
+			return err
+		}
+	} else {
+		// Update the Secret
+		if err := checkDockerRegistrySecret(secret); err != nil {
+			return err
+		}
+		secret.Data = map[string][]byte{v1.DockerConfigJsonKey: dockerConfig}
+		if _, err := kr.KubeClient.CoreV1().Secrets(namespace).Update(context.TODO(),
+			secret, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkDockerRegistrySecret checks whether the existing Secret can be updated with the docker config
+func checkDockerRegistrySecret(secret *v1.Secret) error {
+	if !isManagedByPlugin(secret.Labels) {
+		return notManagedByPluginError(SecretType, secret.Name, secret.Namespace)
+	}
+	if secret.Type != v1.SecretTypeDockerConfigJson {
+		return fmt.Errorf("The Secret %s in the namespace %s is not of the type %s.", secret.Name, secret.Namespace, v1.SecretTypeDockerConfigJson)
+	}
+	return nil
+}
+
+// getSecret gets the Secret under a certain namespace
+func (kr *KubeResource) getSecret(name, namespace string) (*v1.Secret, error) {
+	secret, err := kr.KubeClient.CoreV1().Secrets(namespace).Get(context.TODO(),
+		name, metav1.GetOptions{})
+
+	if apierrs.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return secret, nil
+}
+
+// CreateOrUpdateManagedResource creates or updates the Secret or the ConfigMap, labeled as managed by the plugin,
+// containing the data under the key in a certain namespace. An existing Secret or ConfigMap, which was not created
+// by the plugin, is not changed.
+func (kr *KubeResource) CreateOrUpdateManagedResource(resourceType, name, namespace, key string, data []byte) error {
+	objectMeta := metav1.ObjectMeta{
+		Name:      name,
+		Namespace: namespace,
+		Labels:    map[string]string{ManagedByLabel: PluginName},
+	}
+
+	if resourceType == SecretType {
+		secret, err := kr.getSecret(name, namespace)
+		if err != nil {
+			return err
+		}
+		if secret == nil {
+			secret = &v1.Secret{ObjectMeta: objectMeta, Data: map[string][]byte{key: data}}
+			_, err = kr.KubeClient.CoreV1().Secrets(namespace).Create(context.TODO(), secret, metav1.CreateOptions{})
+			return err
+		}
+		if !isManagedByPlugin(secret.Labels) {
+			return notManagedByPluginError(resourceType, name, namespace)
+		}
+		secret.Data = map[string][]byte{key: data}
+		_, err = kr.KubeClient.CoreV1().Secrets(namespace).Update(context.TODO(), secret, metav1.UpdateOptions{})
+		return err
+	}
+
+	cm, err := kr.getConfigMap(name, namespace)
+	if err != nil {
+		return err
+	}
+	if cm == nil {
+		cm = &v1.ConfigMap{ObjectMeta: objectMeta, Data: map[string]string{key: string(data)}}
+		_, err = kr.KubeClient.CoreV1().ConfigMaps(namespace).Create(context.TODO(), cm, metav1.CreateOptions{})
+		return err
+	}
+	if !isManagedByPlugin(cm.Labels) {
+		return notManagedByPluginError(resourceType, name, namespace)
+	}
+	cm.Data = map[string]string{key: string(data)}
+	_, err = kr.KubeClient.CoreV1().ConfigMaps(namespace).Update(context.TODO(), cm, metav1.UpdateOptions{})
+	return err
+}
+
+// DeleteManagedResource deletes the Secret or the ConfigMap under a certain namespace, if it was created by the plugin.
+func (kr *KubeResource) DeleteManagedResource(resourceType, name, namespace string) error {
+	var labels map[string]string
+	if resourceType == SecretType {
+		secret, err := kr.getSecret(name, namespace)
+		if err != nil || secret == nil {
+			return err
+		}
+		labels = secret.Labels
+	} else {
+		cm, err := kr.getConfigMap(name, namespace)
+		if err != nil || cm == nil {
+			return err
+		}
+		labels = cm.Labels
+	}
+
+	if !isManagedByPlugin(labels) {
+		return nil
+	}
+	if resourceType == SecretType {
+		return kr.KubeClient.CoreV1().Secrets(namespace).Delete(context.TODO(), name, metav1.DeleteOptions{})
+	}
+	return kr.KubeClient.CoreV1().ConfigMaps(namespace).Delete(context.TODO(), name, metav1.DeleteOptions{})
+}
+
+// isManagedByPlugin checks whether the labels mark the resource as created by the plugin
+func isManagedByPlugin(labels map[string]string) bool {
+	return labels[ManagedByLabel] == PluginName
+}
+
+// notManagedByPluginError returns the error for an existing resource, which was not created by the plugin
+func notManagedByPluginError(resourceType, name, namespace string) error {
+	return fmt.Errorf("The %s %s in the namespace %s already exists and is not managed by %s. Please specify another name or remove the %s first.",
+		resourceType, name, namespace, PluginName, resourceType)
+}
+
+// UpdateOperatorDeployment updates the deployment of the operator to mount the ConfigMaps of the custom manifests
+func (kr *KubeResource) UpdateOperatorDeployment(name, namespace string, cmNames []string) error {
 	deploy, err := kr.getDeployment(name, namespace)
 	if err != nil {
 		return err
@@ -112,7 +267,7 @@ func (kr *KubeResource) UpdateOperatorDeployment(name, namespace string) error {
 		return fmt.Errorf("The Knative Operator is not install.")
 	}
 
-	deploy.Spec.Template.Spec.Volumes = updateVolumes(deploy.Spec.Template.Spec.Volumes)
+	deploy.Spec.Template.Spec.Volumes = updateVolumes(deploy.Spec.Template.Spec.Volumes, cmNames)
 	deploy.Spec.Template.Spec.Containers = updateContainers(deploy.Spec.Template.Spec.Containers)
 	if _, err := kr.KubeClient.AppsV1().Deployments(namespace).Update(context.TODO(),
 		deploy, metav1.UpdateOptions{}); err != nil {
@@ -176,30 +331,38 @@ func updateVolumeMounts(volumeMounts []v1.VolumeMount) []v1.VolumeMount {
 	return volumeMounts
 }
 
-func updateVolumes(volumes []v1.Volume) []v1.Volume {
+// updateVolumes mounts the ConfigMaps storing the custom manifests into a single directory with a projected volume.
+// The ConfigMaps are optional, so that the operator keeps running when some of them are deleted.
+func updateVolumes(volumes []v1.Volume, cmNames []string) []v1.Volume {
+	optional := true
+	sources := []v1.VolumeProjection{}
+	for _, cmName := range cmNames {
+		sources = append(sources, v1.VolumeProjection{
+			ConfigMap: &v1.ConfigMapProjection{
+				LocalObjectReference: v1.LocalObjectReference{
+					Name: cmName,
+				},
+				Optional: &optional,
+			},
+		})
+	}
+	volumeSource := v1.VolumeSource{
+		Projected: &v1.ProjectedVolumeSource{
+			Sources: sources,
+		},
+	}
+
 	found := false
 	for i := range volumes {
 		if volumes[i].Name == CustomVolumeName {
 			found = true
-			volumes[i].VolumeSource = v1.VolumeSource{
-				ConfigMap: &v1.ConfigMapVolumeSource{
-					LocalObjectReference: v1.LocalObjectReference{
-						Name: ConfigMapName,
-					},
-				},
-			}
+			volumes[i].VolumeSource = volumeSource
 		}
 	}
 	if !found {
 		volumes = append(volumes, v1.Volume{
-			Name: CustomVolumeName,
-			VolumeSource: v1.VolumeSource{
-				ConfigMap: &v1.ConfigMapVolumeSource{
-					LocalObjectReference: v1.LocalObjectReference{
-						Name: ConfigMapName,
-					},
-				},
-			},
+			Name:         CustomVolumeName,
+			VolumeSource: volumeSource,
 		})
 	}
 	return volumes
@@ -17,9 +17,11 @@ limitations under the License.
 package common
 
 import (
+	"fmt"
 	"testing"
 
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/kn-plugin-operator/pkg/command/testingUtil"
 )
 
@@ -91,6 +93,26 @@ new data`,
 }
 
 func TestUpdateVolumes(t *testing.T) {
+	optional := true
+	projectedSource := v1.VolumeSource{
+		Projected: &v1.ProjectedVolumeSource{
+			Sources: []v1.VolumeProjection{{
+				ConfigMap: &v1.ConfigMapProjection{
+					LocalObjectReference: v1.LocalObjectReference{
+						Name: ConfigMapName,
+					},
+					Optional: &optional,
+				},
+			}, {
+				ConfigMap: &v1.ConfigMapProjection{
+					LocalObjectReference: v1.LocalObjectReference{
+						Name: "config-manifest-1",
+					},
+					Optional: &optional,
+				},
+			}},
+		},
+	}
 	for _, tt := range []struct {
 		name           string
 		input          []v1.Volume
@@ -99,14 +121,8 @@ func TestUpdateVolumes(t *testing.T) {
 		name:  "Update the empty volumes",
 		input: []v1.Volume{},
 		expectedResult: []v1.Volume{{
-			Name: CustomVolumeName,
-			VolumeSource: v1.VolumeSource{
-				ConfigMap: &v1.ConfigMapVolumeSource{
-					LocalObjectReference: v1.LocalObjectReference{
-						Name: ConfigMapName,
-					},
-				},
-			},
+			Name:         CustomVolumeName,
+			VolumeSource: projectedSource,
 		}},
 	}, {
 		name: "Update the volumes with existing volume of other name",
@@ -136,14 +152,8 @@ func TestUpdateVolumes(t *testing.T) {
 				},
 			},
 		}, {
-			Name: CustomVolumeName,
-			VolumeSource: v1.VolumeSource{
-				ConfigMap: &v1.ConfigMapVolumeSource{
-					LocalObjectReference: v1.LocalObjectReference{
-						Name: ConfigMapName,
-					},
-				},
-			},
+			Name:         CustomVolumeName,
+			VolumeSource: projectedSource,
 		}},
 	}, {
 		name: "Update the volumes with existing volumes",
@@ -152,27 +162,18 @@ func TestUpdateVolumes(t *testing.T) {
 			VolumeSource: v1.VolumeSource{
 				ConfigMap: &v1.ConfigMapVolumeSource{
 					LocalObjectReference: v1.LocalObjectReference{
-						Name: "test",
+						Name: ConfigMapName,
 					},
 				},
-				HostPath: &v1.HostPathVolumeSource{
-					Path: "test-path",
-				},
 			},
 		}},
 		expectedResult: []v1.Volume{{
-			Name: CustomVolumeName,
-			VolumeSource: v1.VolumeSource{
-				ConfigMap: &v1.ConfigMapVolumeSource{
-					LocalObjectReference: v1.LocalObjectReference{
-						Name: ConfigMapName,
-					},
-				},
-			},
+			Name:         CustomVolumeName,
+			VolumeSource: projectedSource,
 		}},
 	}} {
 		t.Run(tt.name, func(t *testing.T) {
-			result := updateVolumes(tt.input)
+			result := updateVolumes(tt.input, []string{ConfigMapName, "config-manifest-1"})
 			testingUtil.AssertDeepEqual(t, result, tt.expectedResult)
 		})
 	}
@@ -294,3 +295,66 @@ func TestUpdateContainers(t *testing.T) {
 		})
 	}
 }
+
+func TestIsManagedByPlugin(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		labels         map[string]string
+		expectedResult bool
+	}{{
+		name:           "Resource created by the plugin",
+		labels:         map[string]string{ManagedByLabel: PluginName},
+		expectedResult: true,
+	}, {
+		name:           "Resource managed by another tool",
+		labels:         map[string]string{ManagedByLabel: "helm"},
+		expectedResult: false,
+	}, {
+		name:           "Resource without labels",
+		labels:         nil,
+		expectedResult: false,
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			testingUtil.AssertEqual(t, isManagedByPlugin(tt.labels), tt.expectedResult)
+		})
+	}
+}
+
+func TestCheckDockerRegistrySecret(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		secret      *v1.Secret
+		expectedErr error
+	}{{
+		name: "Docker registry Secret created by the plugin",
+		secret: &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "registry-creds", Namespace: "knative-serving",
+				Labels: map[string]string{ManagedByLabel: PluginName}},
+			Type: v1.SecretTypeDockerConfigJson,
+		},
+	}, {
+		name: "Unmanaged docker registry Secret is refused",
+		secret: &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "corp-pull-secret", Namespace: "knative-serving"},
+			Type:       v1.SecretTypeDockerConfigJson,
+		},
+		expectedErr: fmt.Errorf("The Secret corp-pull-secret in the namespace knative-serving already exists and is not managed by kn-plugin-operator. Please specify another name or remove the Secret first."),
+	}, {
+		name: "Managed Secret of another type",
+		secret: &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "registry-creds", Namespace: "knative-serving",
+				Labels: map[string]string{ManagedByLabel: PluginName}},
+			Type: v1.SecretTypeOpaque,
+		},
+		expectedErr: fmt.Errorf("The Secret registry-creds in the namespace knative-serving is not of the type kubernetes.io/dockerconfigjson."),
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkDockerRegistrySecret(tt.secret)
+			if tt.expectedErr != nil {
+				testingUtil.AssertEqual(t, err.Error(), tt.expectedErr.Error())
+			} else {
+				testingUtil.AssertEqual(t, err, nil)
+			}
+		})
+	}
+}
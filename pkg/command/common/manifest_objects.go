@@ -0,0 +1,209 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+)
+
+// ManifestObject is a Kubernetes object in the custom manifests
+type ManifestObject struct {
+	APIVersion string
+	Kind       string
+	Name       string
+	Namespace  string
+}
+
+// The kinds of the API groups of Knative, which are installed by the bundled manifests
+var knativeKinds = map[string][]string{
+	"operator.knative.dev":             {"KnativeEventing", "KnativeServing"},
+	"serving.knative.dev":              {"Configuration", "DomainMapping", "Revision", "Route", "Service"},
+	"autoscaling.internal.knative.dev": {"Metric", "PodAutoscaler"},
+	"networking.internal.knative.dev":  {"Certificate", "ClusterDomainClaim", "Ingress", "ServerlessService"},
+	"caching.internal.knative.dev":     {"Image"},
+	"eventing.knative.dev":             {"Broker", "EventPolicy", "EventType", "RequestReply", "Trigger"},
+	"messaging.knative.dev":            {"Channel", "InMemoryChannel", "KafkaChannel", "Subscription"},
+	"flows.knative.dev":                {"Parallel", "Sequence"},
+	"sources.knative.dev":              {"ApiServerSource", "ContainerSource", "IntegrationSource", "KafkaSource", "PingSource", "SinkBinding"},
+	"sinks.knative.dev":                {"IntegrationSink", "JobSink", "KafkaSink"},
+}
+
+// The built-in and Knative kinds, which are cluster-scoped
+var clusterScopedKinds = []string{
+	"APIService",
+	"CertificateSigningRequest",
+	"ClusterDomainClaim",
+	"ClusterRole",
+	"ClusterRoleBinding",
+	"CSIDriver",
+	"CSINode",
+	"CustomResourceDefinition",
+	"FlowSchema",
+	"IngressClass",
+	"MutatingWebhookConfiguration",
+	"Namespace",
+	"Node",
+	"PersistentVolume",
+	"PriorityClass",
+	"PriorityLevelConfiguration",
+	"RuntimeClass",
+	"StorageClass",
+	"ValidatingAdmissionPolicy",
+	"ValidatingAdmissionPolicyBinding",
+	"ValidatingWebhookConfiguration",
+	"VolumeAttachment",
+}
+
+// customResourceDefinition has the fields of a CustomResourceDefinition to know the kind it defines
+type customResourceDefinition struct {
+	Spec struct {
+		Group string `json:"group"`
+		Names struct {
+			Kind string `json:"kind"`
+		} `json:"names"`
+		Scope string `json:"scope"`
+	} `json:"spec"`
+}
+
+// String returns the kind, the namespace and the name of the object
+func (o ManifestObject) String() string {
+	if o.Namespace == "" {
+		return fmt.Sprintf("%s %s", o.Kind, o.Name)
+	}
+	return fmt.Sprintf("%s %s/%s", o.Kind, o.Namespace, o.Name)
+}
+
+// ManifestObjectsCheck is the result of checking the objects in the custom manifests
+type ManifestObjectsCheck struct {
+	Objects []ManifestObject
+	// Problems are the objects of unknown kinds
+	Problems []string
+	// Warnings are the cluster-scoped objects and the objects in other namespaces
+	Warnings []string
+}
+
+// CheckManifestObjects parses the custom manifests into Kubernetes objects and checks their kinds and namespaces. A
+// kind is known, if it is built into Kubernetes, belongs to Knative, or is defined by a CustomResourceDefinition in
+// the same manifests.
+func CheckManifestObjects(data, namespace string) (*ManifestObjectsCheck, error) {
+	result := &ManifestObjectsCheck{Objects: []ManifestObject{}, Problems: []string{}, Warnings: []string{}}
+	definedKinds := map[schema.GroupKind]bool{}
+	for i, document := range SplitManifestsDocuments(data) {
+		jsonData, err := yaml.YAMLToJSON([]byte(document))
+		if err != nil {
+			return nil, fmt.Errorf("The manifest %d cannot be parsed: %w", i+1, err)
+		}
+		var object struct {
+			APIVersion string `json:"apiVersion"`
+			Kind       string `json:"kind"`
+			Metadata   struct {
+				Name      string `json:"name"`
+				Namespace string `json:"namespace"`
+			} `json:"metadata"`
+		}
+		if err = json.Unmarshal(jsonData, &object); err != nil {
+			return nil, fmt.Errorf("The manifest %d cannot be parsed: %w", i+1, err)
+		}
+		if object.APIVersion == "" || object.Kind == "" || object.Metadata.Name == "" {
+			return nil, fmt.Errorf("The manifest %d should have apiVersion, kind and metadata.name.", i+1)
+		}
+		if _, err = schema.ParseGroupVersion(object.APIVersion); err != nil {
+			return nil, fmt.Errorf("The manifest %d cannot be parsed: %w", i+1, err)
+		}
+		if object.Kind == "CustomResourceDefinition" {
+			crd := customResourceDefinition{}
+			if err = json.Unmarshal(jsonData, &crd); err != nil {
+				return nil, fmt.Errorf("The manifest %d cannot be parsed: %w", i+1, err)
+			}
+			definedKinds[schema.GroupKind{Group: crd.Spec.Group, Kind: crd.Spec.Names.Kind}] = crd.Spec.Scope == "Cluster"
+		}
+		result.Objects = append(result.Objects, ManifestObject{
+			APIVersion: object.APIVersion,
+			Kind:       object.Kind,
+			Name:       object.Metadata.Name,
+			Namespace:  object.Metadata.Namespace,
+		})
+	}
+
+	for _, object := range result.Objects {
+		gvk := schema.FromAPIVersionAndKind(object.APIVersion, object.Kind)
+		clusterScoped, defined := definedKinds[gvk.GroupKind()]
+		switch {
+		case defined:
+		case clientgoscheme.Scheme.Recognizes(gvk) || (gvk.Group == "apiextensions.k8s.io" && gvk.Kind == "CustomResourceDefinition"):
+			clusterScoped = Contains(clusterScopedKinds, gvk.Kind)
+		case Contains(knativeKinds[gvk.Group], gvk.Kind):
+			clusterScoped = Contains(clusterScopedKinds, gvk.Kind)
+		case len(knativeKinds[gvk.Group]) > 0:
+			problem := fmt.Sprintf("The kind %s of %s in %s is unknown.", gvk.Kind, object, object.APIVersion)
+			if suggestion := SuggestName(knativeKinds[gvk.Group], gvk.Kind); suggestion != "" {
+				problem = fmt.Sprintf("%s Did you mean %s?", problem, suggestion)
+			}
+			result.Problems = append(result.Problems, problem)
+			continue
+		default:
+			result.Problems = append(result.Problems, fmt.Sprintf("The kind %s of %s in %s is unknown.", gvk.Kind, object, object.APIVersion))
+			continue
+		}
+		if clusterScoped {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("The %s is cluster-scoped.", object))
+		} else if object.Namespace != "" && object.Namespace != namespace {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("The %s is not in the namespace %s of Knative.", object, namespace))
+		}
+	}
+	return result, nil
+}
+
+// SubtractManifestObjects returns the objects, which are not in the bundled objects. The objects are matched by the API
+// group, the kind, the namespace and the name, regardless of the API version.
+func SubtractManifestObjects(objects, bundled []ManifestObject) []ManifestObject {
+	keys := map[string]bool{}
+	for _, object := range bundled {
+		keys[object.key()] = true
+	}
+	result := []ManifestObject{}
+	for _, object := range objects {
+		if !keys[object.key()] {
+			result = append(result, object)
+		}
+	}
+	return result
+}
+
+// key returns the API group, the kind, the namespace and the name of the object
+func (o ManifestObject) key() string {
+	gvk := schema.FromAPIVersionAndKind(o.APIVersion, o.Kind)
+	return fmt.Sprintf("%s/%s/%s/%s", gvk.Group, gvk.Kind, o.Namespace, o.Name)
+}
+
+// FormatManifestObjects returns the objects to add, sorted by the kind, the namespace and the name
+func FormatManifestObjects(objects []ManifestObject) string {
+	sorted := append([]ManifestObject{}, objects...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].String() < sorted[j].String() })
+	contentArray := []string{}
+	for _, object := range sorted {
+		contentArray = append(contentArray, fmt.Sprintf("- %s (%s)", object, object.APIVersion))
+	}
+	return fmt.Sprintf("%s\n", strings.Join(contentArray, "\n"))
+}
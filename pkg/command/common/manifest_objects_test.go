@@ -0,0 +1,160 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"strings"
+	"testing"
+
+	"knative.dev/kn-plugin-operator/pkg/command/testingUtil"
+)
+
+const testManifestObjects = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: dashboards
+  namespace: knative-serving
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: monitoring
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: exporter
+  namespace: monitoring
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: dashboards.example.com
+spec:
+  group: example.com
+  names:
+    kind: Dashboard
+  scope: Namespaced
+---
+apiVersion: example.com/v1
+kind: Dashboard
+metadata:
+  name: serving
+---
+apiVersion: serving.knative.dev/v1
+kind: Service
+metadata:
+  name: hello
+  namespace: knative-serving
+---
+apiVersion: serving.knative.dev/v1
+kind: Servce
+metadata:
+  name: typo
+  namespace: knative-serving
+---
+apiVersion: monitoring.coreos.com/v1
+kind: ServiceMonitor
+metadata:
+  name: activator
+  namespace: knative-serving
+`
+
+func TestCheckManifestObjects(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		data           string
+		expectedResult *ManifestObjectsCheck
+		expectedErr    string
+	}{{
+		name: "Objects of known and unknown kinds",
+		data: testManifestObjects,
+		expectedResult: &ManifestObjectsCheck{
+			Objects: []ManifestObject{
+				{APIVersion: "v1", Kind: "ConfigMap", Name: "dashboards", Namespace: "knative-serving"},
+				{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRole", Name: "monitoring"},
+				{APIVersion: "apps/v1", Kind: "Deployment", Name: "exporter", Namespace: "monitoring"},
+				{APIVersion: "apiextensions.k8s.io/v1", Kind: "CustomResourceDefinition", Name: "dashboards.example.com"},
+				{APIVersion: "example.com/v1", Kind: "Dashboard", Name: "serving"},
+				{APIVersion: "serving.knative.dev/v1", Kind: "Service", Name: "hello", Namespace: "knative-serving"},
+				{APIVersion: "serving.knative.dev/v1", Kind: "Servce", Name: "typo", Namespace: "knative-serving"},
+				{APIVersion: "monitoring.coreos.com/v1", Kind: "ServiceMonitor", Name: "activator", Namespace: "knative-serving"},
+			},
+			Problems: []string{
+				"The kind Servce of Servce knative-serving/typo in serving.knative.dev/v1 is unknown. Did you mean Service?",
+				"The kind ServiceMonitor of ServiceMonitor knative-serving/activator in monitoring.coreos.com/v1 is unknown.",
+			},
+			Warnings: []string{
+				"The ClusterRole monitoring is cluster-scoped.",
+				"The Deployment monitoring/exporter is not in the namespace knative-serving of Knative.",
+				"The CustomResourceDefinition dashboards.example.com is cluster-scoped.",
+			},
+		},
+	}, {
+		name:        "Invalid YAML",
+		data:        "apiVersion: v1\nkind: [ConfigMap\n",
+		expectedErr: "The manifest 1 cannot be parsed",
+	}, {
+		name:        "Missing name",
+		data:        "apiVersion: v1\nkind: ConfigMap\n---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  namespace: default\n",
+		expectedErr: "The manifest 1 should have apiVersion, kind and metadata.name.",
+	}, {
+		name:        "Invalid apiVersion",
+		data:        "apiVersion: a/b/c\nkind: ConfigMap\nmetadata:\n  name: test\n",
+		expectedErr: "The manifest 1 cannot be parsed",
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := CheckManifestObjects(tt.data, "knative-serving")
+			if tt.expectedErr != "" {
+				testingUtil.AssertEqual(t, err != nil, true)
+				testingUtil.AssertEqual(t, strings.HasPrefix(err.Error(), tt.expectedErr), true)
+				return
+			}
+			testingUtil.AssertEqual(t, err, nil)
+			testingUtil.AssertDeepEqual(t, result, tt.expectedResult)
+		})
+	}
+}
+
+func TestSubtractManifestObjects(t *testing.T) {
+	objects := []ManifestObject{
+		{APIVersion: "v1", Kind: "ConfigMap", Name: "config-network", Namespace: "knative-serving"},
+		{APIVersion: "v1", Kind: "ConfigMap", Name: "dashboards", Namespace: "knative-serving"},
+		{APIVersion: "autoscaling/v2", Kind: "HorizontalPodAutoscaler", Name: "activator", Namespace: "knative-serving"},
+		{APIVersion: "policy/v1", Kind: "PodDisruptionBudget", Name: "webhook-pdb", Namespace: "knative-serving"},
+	}
+	bundled := []ManifestObject{
+		{APIVersion: "v1", Kind: "ConfigMap", Name: "config-network", Namespace: "knative-serving"},
+		{APIVersion: "autoscaling/v1", Kind: "HorizontalPodAutoscaler", Name: "activator", Namespace: "knative-serving"},
+		{APIVersion: "policy/v1", Kind: "PodDisruptionBudget", Name: "webhook-pdb", Namespace: "knative-eventing"},
+	}
+	testingUtil.AssertDeepEqual(t, SubtractManifestObjects(objects, bundled), []ManifestObject{
+		{APIVersion: "v1", Kind: "ConfigMap", Name: "dashboards", Namespace: "knative-serving"},
+		{APIVersion: "policy/v1", Kind: "PodDisruptionBudget", Name: "webhook-pdb", Namespace: "knative-serving"},
+	})
+}
+
+func TestFormatManifestObjects(t *testing.T) {
+	objects := []ManifestObject{
+		{APIVersion: "v1", Kind: "ConfigMap", Name: "dashboards", Namespace: "knative-serving"},
+		{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRole", Name: "monitoring"},
+	}
+	expected := "- ClusterRole monitoring (rbac.authorization.k8s.io/v1)\n- ConfigMap knative-serving/dashboards (v1)\n"
+	testingUtil.AssertEqual(t, FormatManifestObjects(objects), expected)
+	// The objects are not sorted in place
+	testingUtil.AssertEqual(t, objects[0].Kind, "ConfigMap")
+}
@@ -18,7 +18,6 @@ package common
 
 import (
 	"context"
-	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -28,8 +27,10 @@ import (
 
 // Namespace is used to access the namespace resource in the Kubernetes cluster.
 type Namespace struct {
-	Client    kubernetes.Interface
-	Component string
+	Client kubernetes.Interface
+	// Labels and Annotations are set on the namespace when it is created.
+	Labels      map[string]string
+	Annotations map[string]string
 }
 
 // CreateNamespace creates the namespace if it is not available in the Kubernetes cluster
@@ -38,11 +39,8 @@ func (ns *Namespace) CreateNamespace(namespace string) error {
 		// Create the namespace if it is not available
 		_, err := ns.Client.CoreV1().Namespaces().Get(context.TODO(), namespace, metav1.GetOptions{})
 		if apierrors.IsNotFound(err) {
-			nspace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}
-			if strings.EqualFold(ns.Component, ServingComponent) {
-				nspace = &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace,
-					Labels: map[string]string{"istio-injection": "enabled"}}}
-			}
+			nspace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace,
+				Labels: ns.Labels, Annotations: ns.Annotations}}
 			ns.Client.CoreV1().Namespaces().Create(context.TODO(), nspace, metav1.CreateOptions{})
 		} else if err != nil {
 			return err
@@ -0,0 +1,296 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"golang.org/x/mod/semver"
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+//go:embed profiles/*.yaml
+var builtInProfiles embed.FS
+
+// BuiltInProfileSource is the source of the profiles shipped with the plugin
+const BuiltInProfileSource = "built-in"
+
+// Profile is a named set of overrides of the Knative custom resources
+type Profile struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Overrides   []ProfileOverride `json:"overrides"`
+	// Source is the file of a user-defined profile, or built-in
+	Source string `json:"-"`
+}
+
+// ProfileOverride is a fragment of the spec of the component, which is available from the Knative version since on
+type ProfileOverride struct {
+	Component string          `json:"component"`
+	Since     string          `json:"since,omitempty"`
+	Spec      json.RawMessage `json:"spec"`
+}
+
+// The fields identifying the items of the lists in the spec, which are merged item by item
+var specListKeys = []string{"name", "container"}
+
+// GetProfiles returns the built-in profiles and the user-defined profiles in the YAML files of the directory, sorted
+// by name. A user-defined profile replaces the built-in profile with the same name.
+func GetProfiles(dir string) ([]Profile, error) {
+	profiles := map[string]Profile{}
+	entries, err := builtInProfiles.ReadDir("profiles")
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		content, err := builtInProfiles.ReadFile(fmt.Sprintf("profiles/%s", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		profile, err := parseProfile(content, BuiltInProfileSource)
+		if err != nil {
+			return nil, err
+		}
+		profiles[profile.Name] = *profile
+	}
+
+	if dir != "" {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the profiles in %s: %w", dir, err)
+		}
+		for _, entry := range entries {
+			ext := strings.ToLower(filepath.Ext(entry.Name()))
+			if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return nil, err
+			}
+			profile, err := parseProfile(content, path)
+			if err != nil {
+				return nil, err
+			}
+			profiles[profile.Name] = *profile
+		}
+	}
+
+	result := []Profile{}
+	for _, profile := range profiles {
+		result = append(result, profile)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+// parseProfile parses and validates the profile. The spec of every override needs to be a valid common spec.
+func parseProfile(content []byte, source string) (*Profile, error) {
+	profile := &Profile{Source: source}
+	if err := yaml.Unmarshal(content, profile); err != nil {
+		return nil, fmt.Errorf("failed to parse the profile in %s: %w", source, err)
+	}
+	if profile.Name == "" {
+		return nil, fmt.Errorf("The profile in %s needs a name.", source)
+	}
+	for i, override := range profile.Overrides {
+		if !strings.EqualFold(override.Component, ServingComponent) && !strings.EqualFold(override.Component, EventingComponent) {
+			return nil, fmt.Errorf("The override %d of the profile %s needs the component serving or eventing.", i+1, profile.Name)
+		}
+		if override.Since != "" && !semver.IsValid(override.Since) {
+			return nil, fmt.Errorf("The version %s of the override %d of the profile %s is not valid, like v1.13.", override.Since, i+1, profile.Name)
+		}
+		decoder := json.NewDecoder(bytes.NewReader(override.Spec))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&base.CommonSpec{}); err != nil {
+			return nil, fmt.Errorf("The spec of the override %d of the profile %s is not valid: %w", i+1, profile.Name, err)
+		}
+	}
+	return profile, nil
+}
+
+// FindProfile returns the profile with the name, or nil if it is not found
+func FindProfile(profiles []Profile, name string) *Profile {
+	for i := range profiles {
+		if profiles[i].Name == name {
+			return &profiles[i]
+		}
+	}
+	return nil
+}
+
+// HasComponent returns whether the profile has overrides for the component
+func (profile *Profile) HasComponent(component string) bool {
+	for _, override := range profile.Overrides {
+		if strings.EqualFold(override.Component, component) {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyProfile merges the overrides of the profile for the component and the Knative version into a copy of the
+// spec. The maps are merged, the lists of workloads, services, containers and the like are merged item by item with
+// the same name or container, and the other values are replaced. It returns the merged spec, and the notes for the
+// overrides skipped, since they are not available in the version.
+func ApplyProfile(profile *Profile, component, version string, commonSpec *base.CommonSpec) (*base.CommonSpec, []string, error) {
+	if !profile.HasComponent(component) {
+		return nil, nil, fmt.Errorf("The profile %s has no overrides for Knative %s.", profile.Name, component)
+	}
+	var current interface{}
+	if err := convertJSONValue(commonSpec, &current); err != nil {
+		return nil, nil, err
+	}
+
+	notes := []string{}
+	for _, override := range profile.Overrides {
+		if !strings.EqualFold(override.Component, component) {
+			continue
+		}
+		if !IsVersionAvailable(version, override.Since) {
+			notes = append(notes, fmt.Sprintf("The overrides of the profile %s since %s are skipped for Knative %s %s.",
+				profile.Name, override.Since, component, version))
+			continue
+		}
+		var spec interface{}
+		if err := convertJSONValue(override.Spec, &spec); err != nil {
+			return nil, nil, err
+		}
+		matchConfigMapNames(current, spec)
+		current = mergeSpecValues(current, spec)
+	}
+
+	merged := &base.CommonSpec{}
+	if err := convertJSONValue(current, merged); err != nil {
+		return nil, nil, err
+	}
+	return merged, notes, nil
+}
+
+// convertJSONValue converts the value into the result through JSON, keeping the numbers as they are
+func convertJSONValue(value, result interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(result)
+}
+
+// matchConfigMapNames renames the ConfigMaps in the config of the spec to the names in the current config, which
+// are with or without the prefix config-, so that their data is merged.
+func matchConfigMapNames(current, spec interface{}) {
+	currentMap, _ := current.(map[string]interface{})
+	specMap, _ := spec.(map[string]interface{})
+	currentConfig, _ := currentMap["config"].(map[string]interface{})
+	specConfig, _ := specMap["config"].(map[string]interface{})
+	for name, data := range specConfig {
+		if _, found := currentConfig[name]; found {
+			continue
+		}
+		altName := AddOrRemovePrefix(name, ConfigMapPrefix)
+		if _, found := currentConfig[altName]; found {
+			delete(specConfig, name)
+			specConfig[altName] = data
+		}
+	}
+}
+
+// mergeSpecValues merges the value into the current value, and returns the merged value
+func mergeSpecValues(current, value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		currentMap, ok := current.(map[string]interface{})
+		if !ok {
+			return value
+		}
+		for key, item := range value {
+			currentMap[key] = mergeSpecValues(currentMap[key], item)
+		}
+		return currentMap
+	case []interface{}:
+		currentList, ok := current.([]interface{})
+		key := getListKey(value)
+		if !ok || key == "" {
+			return value
+		}
+		for _, item := range value {
+			itemMap := item.(map[string]interface{})
+			found := false
+			for i, currentItem := range currentList {
+				if currentItemMap, ok := currentItem.(map[string]interface{}); ok && currentItemMap[key] == itemMap[key] {
+					currentList[i] = mergeSpecValues(currentItemMap, itemMap)
+					found = true
+					break
+				}
+			}
+			if !found {
+				currentList = append(currentList, item)
+			}
+		}
+		return currentList
+	}
+	return value
+}
+
+// getListKey returns the field identifying every item of the list, or an empty string if there is none
+func getListKey(list []interface{}) string {
+	for _, key := range specListKeys {
+		found := len(list) > 0
+		for _, item := range list {
+			itemMap, ok := item.(map[string]interface{})
+			if !ok {
+				found = false
+				break
+			}
+			if _, ok = itemMap[key].(string); !ok {
+				found = false
+				break
+			}
+		}
+		if found {
+			return key
+		}
+	}
+	return ""
+}
+
+// FormatProfiles returns the names, the components and the descriptions of the profiles
+func FormatProfiles(profiles []Profile) string {
+	contentArray := []string{}
+	for _, profile := range profiles {
+		components := []string{}
+		for _, component := range []string{ServingComponent, EventingComponent} {
+			if profile.HasComponent(component) {
+				components = append(components, component)
+			}
+		}
+		contentArray = append(contentArray, fmt.Sprintf("%s (%s, %s): %s", profile.Name, strings.Join(components, ", "),
+			profile.Source, profile.Description))
+	}
+	return fmt.Sprintf("%s\n", strings.Join(contentArray, "\n"))
+}
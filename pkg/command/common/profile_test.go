@@ -0,0 +1,212 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"knative.dev/kn-plugin-operator/pkg/command/testingUtil"
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+func TestGetProfiles(t *testing.T) {
+	profiles, err := GetProfiles("")
+	testingUtil.AssertEqual(t, err, nil)
+	testingUtil.AssertEqual(t, len(profiles), 3)
+	for i, name := range []string{"development", "minimal", "production"} {
+		testingUtil.AssertEqual(t, profiles[i].Name, name)
+		testingUtil.AssertEqual(t, profiles[i].Source, BuiltInProfileSource)
+		testingUtil.AssertEqual(t, profiles[i].HasComponent(ServingComponent), true)
+		testingUtil.AssertEqual(t, profiles[i].HasComponent(EventingComponent), true)
+	}
+
+	profiles, err = GetProfiles("testdata/profiles")
+	testingUtil.AssertEqual(t, err, nil)
+	testingUtil.AssertEqual(t, len(profiles), 4)
+	production := FindProfile(profiles, "production")
+	testingUtil.AssertEqual(t, production.Source, "testdata/profiles/production.yaml")
+	testingUtil.AssertEqual(t, production.HasComponent(EventingComponent), false)
+	testingUtil.AssertEqual(t, FindProfile(profiles, "team").Source, "testdata/profiles/team.yml")
+	testingUtil.AssertEqual(t, FindProfile(profiles, "unknown") == nil, true)
+
+	_, err = GetProfiles("testdata/invalid-profiles")
+	testingUtil.AssertEqual(t, err.Error(), `The spec of the override 1 of the profile invalid is not valid: json: unknown field "workload"`)
+
+	_, err = GetProfiles("testdata/unknown")
+	testingUtil.AssertEqual(t, err != nil, true)
+}
+
+func TestParseProfile(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		content     string
+		expectedErr string
+	}{{
+		name:        "Missing name",
+		content:     "description: test\n",
+		expectedErr: "The profile in test.yaml needs a name.",
+	}, {
+		name:        "Invalid component",
+		content:     "name: test\noverrides:\n- component: operator\n  spec: {}\n",
+		expectedErr: "The override 1 of the profile test needs the component serving or eventing.",
+	}, {
+		name:        "Invalid version",
+		content:     "name: test\noverrides:\n- component: serving\n  since: \"1.13\"\n  spec: {}\n",
+		expectedErr: "The version 1.13 of the override 1 of the profile test is not valid, like v1.13.",
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseProfile([]byte(tt.content), "test.yaml")
+			testingUtil.AssertEqual(t, err.Error(), tt.expectedErr)
+		})
+	}
+}
+
+func TestApplyProfile(t *testing.T) {
+	profiles, err := GetProfiles("testdata/profiles")
+	testingUtil.AssertEqual(t, err, nil)
+	team := FindProfile(profiles, "team")
+
+	replicas := int32(2)
+	commonSpec := &base.CommonSpec{
+		Config: base.ConfigMapData{
+			"autoscaler": {"stable-window": "60s", "min-scale": "1"},
+		},
+		Workloads: []base.WorkloadOverride{{
+			Name:     "activator",
+			Replicas: &replicas,
+			Labels:   map[string]string{"app": "activator"},
+			Resources: []base.ResourceRequirementsOverride{{
+				Container: "activator",
+				ResourceRequirements: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("60Mi")},
+					Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+				},
+			}},
+		}, {
+			Name:     "webhook",
+			Replicas: &replicas,
+		}},
+		Version: "1.12",
+	}
+
+	merged, notes, err := ApplyProfile(team, ServingComponent, "1.12.2", commonSpec)
+	testingUtil.AssertEqual(t, err, nil)
+	testingUtil.AssertDeepEqual(t, notes, []string{"The overrides of the profile team since v1.13 are skipped for Knative serving 1.12.2."})
+	testingUtil.AssertDeepEqual(t, merged.Config, base.ConfigMapData{
+		"autoscaler": {"stable-window": "120s", "min-scale": "1"},
+	})
+	testingUtil.AssertEqual(t, len(merged.Workloads), 2)
+	activator := merged.Workloads[0]
+	testingUtil.AssertEqual(t, *activator.Replicas, int32(2))
+	testingUtil.AssertDeepEqual(t, activator.Labels, map[string]string{"app": "activator", "team": "serving"})
+	testingUtil.AssertEqual(t, len(activator.Resources), 1)
+	testingUtil.AssertEqual(t, activator.Resources[0].Requests.Cpu().String(), "500m")
+	testingUtil.AssertEqual(t, activator.Resources[0].Requests.Memory().String(), "60Mi")
+	testingUtil.AssertEqual(t, activator.Resources[0].Limits.Cpu().String(), "1")
+	testingUtil.AssertDeepEqual(t, merged.Workloads[1], commonSpec.Workloads[1])
+	testingUtil.AssertEqual(t, merged.Version, "1.12")
+	// The spec is not changed in place
+	testingUtil.AssertDeepEqual(t, commonSpec.Config["autoscaler"]["stable-window"], "60s")
+
+	merged, notes, err = ApplyProfile(team, ServingComponent, "1.13.0", &base.CommonSpec{})
+	testingUtil.AssertEqual(t, err, nil)
+	testingUtil.AssertDeepEqual(t, notes, []string{})
+	testingUtil.AssertDeepEqual(t, merged.Config, base.ConfigMapData{
+		"config-autoscaler": {"stable-window": "120s"},
+		"network":           {"external-domain-tls": "Enabled"},
+	})
+
+	_, _, err = ApplyProfile(team, EventingComponent, "", &base.CommonSpec{})
+	testingUtil.AssertEqual(t, err.Error(), "The profile team has no overrides for Knative eventing.")
+}
+
+func TestApplyBuiltInProfiles(t *testing.T) {
+	profiles, err := GetProfiles("")
+	testingUtil.AssertEqual(t, err, nil)
+	for _, profile := range profiles {
+		for _, component := range []string{ServingComponent, EventingComponent} {
+			merged, _, err := ApplyProfile(&profile, component, "", &base.CommonSpec{})
+			testingUtil.AssertEqual(t, err, nil)
+			testingUtil.AssertEqual(t, merged.HighAvailability != nil, true)
+		}
+	}
+
+	production := FindProfile(profiles, "production")
+	merged, notes, err := ApplyProfile(production, EventingComponent, "v1.12.0", &base.CommonSpec{})
+	testingUtil.AssertEqual(t, err, nil)
+	testingUtil.AssertEqual(t, len(notes), 0)
+	testingUtil.AssertEqual(t, len(merged.Config), 0)
+	testingUtil.AssertEqual(t, *merged.HighAvailability.Replicas, int32(3))
+	testingUtil.AssertEqual(t, len(merged.PodDisruptionBudgetOverride), 1)
+	testingUtil.AssertEqual(t, merged.PodDisruptionBudgetOverride[0].MinAvailable.String(), "80%")
+}
+
+func TestMergeSpecValues(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		current  interface{}
+		value    interface{}
+		expected interface{}
+	}{{
+		name:     "Scalar",
+		current:  "a",
+		value:    "b",
+		expected: "b",
+	}, {
+		name:     "Maps",
+		current:  map[string]interface{}{"a": "1", "b": map[string]interface{}{"c": "2"}},
+		value:    map[string]interface{}{"b": map[string]interface{}{"d": "3"}},
+		expected: map[string]interface{}{"a": "1", "b": map[string]interface{}{"c": "2", "d": "3"}},
+	}, {
+		name: "Lists with names",
+		current: []interface{}{
+			map[string]interface{}{"name": "a", "replicas": "1"},
+			map[string]interface{}{"name": "b"},
+		},
+		value: []interface{}{
+			map[string]interface{}{"name": "a", "labels": map[string]interface{}{"k": "v"}},
+			map[string]interface{}{"name": "c"},
+		},
+		expected: []interface{}{
+			map[string]interface{}{"name": "a", "replicas": "1", "labels": map[string]interface{}{"k": "v"}},
+			map[string]interface{}{"name": "b"},
+			map[string]interface{}{"name": "c"},
+		},
+	}, {
+		name:     "Lists without names",
+		current:  []interface{}{map[string]interface{}{"key": "a"}},
+		value:    []interface{}{map[string]interface{}{"key": "b"}},
+		expected: []interface{}{map[string]interface{}{"key": "b"}},
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			testingUtil.AssertDeepEqual(t, mergeSpecValues(tt.current, tt.value), tt.expected)
+		})
+	}
+}
+
+func TestFormatProfiles(t *testing.T) {
+	profiles, err := GetProfiles("testdata/profiles")
+	testingUtil.AssertEqual(t, err, nil)
+	expected := `development (serving, eventing, built-in): Single replica control plane with fast scale to zero and quick rollouts.
+minimal (serving, eventing, built-in): Single replica control plane with small resource requests for small clusters.
+production (serving, testdata/profiles/production.yaml): The production settings of the team.
+team (serving, testdata/profiles/team.yml): The settings of the team.
+`
+	testingUtil.AssertEqual(t, FormatProfiles(profiles), expected)
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"knative.dev/kn-plugin-operator/pkg"
+)
+
+const (
+	// SecurityGuardDeployment is the deployment installed by the operator when the security guard is enabled.
+	SecurityGuardDeployment = "guard-service"
+	// ConfigFeaturesName is the ConfigMap containing the feature flags of Knative Serving.
+	ConfigFeaturesName = "config-features"
+	// QueueProxyFeaturePrefix is the prefix of the feature flags for the queue-proxy.
+	QueueProxyFeaturePrefix = "queueproxy."
+
+	// DeploymentPollInterval specifies the time between two polls of a deployment.
+	DeploymentPollInterval = 5 * time.Second
+	// DeploymentPollTimeout specifies the timeout to wait for a deployment to appear or disappear.
+	DeploymentPollTimeout = 5 * time.Minute
+)
+
+// WaitForDeploymentPresence polls the deployment under a certain namespace until it exists, if present is true,
+// or until it is gone, if present is false.
+func (d *Deployment) WaitForDeploymentPresence(name, namespace string, present bool) error {
+	return wait.PollImmediate(DeploymentPollInterval, DeploymentPollTimeout, func() (bool, error) {
+		_, err := d.Client.AppsV1().Deployments(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+		if apierrs.IsNotFound(err) {
+			return !present, nil
+		} else if err != nil {
+			return false, err
+		}
+		return present, nil
+	})
+}
+
+// GetQueueProxyFeatures returns the feature flags of the queue-proxy in the ConfigMap config-features
+func (kr *KubeResource) GetQueueProxyFeatures(namespace string) (map[string]string, error) {
+	cm, err := kr.getConfigMap(ConfigFeaturesName, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if cm == nil {
+		return nil, fmt.Errorf("The ConfigMap %s is not available in the namespace %s.", ConfigFeaturesName, namespace)
+	}
+	return FilterQueueProxyFeatures(cm.Data), nil
+}
+
+// FilterQueueProxyFeatures returns the feature flags starting with the queue-proxy prefix
+func FilterQueueProxyFeatures(data map[string]string) map[string]string {
+	features := map[string]string{}
+	for key, value := range data {
+		if strings.HasPrefix(key, QueueProxyFeaturePrefix) {
+			features[key] = value
+		}
+	}
+	return features
+}
+
+// FormatFeatures formats the feature flags into lines sorted by the key
+func FormatFeatures(features map[string]string) string {
+	keys := make([]string, 0, len(features))
+	for key := range features {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	lines := make([]string, 0, len(keys))
+	for _, key := range keys {
+		lines = append(lines, fmt.Sprintf("  %s: %s", key, features[key]))
+	}
+	return strings.Join(lines, LineWrapper)
+}
+
+// ReportSecurityGuardStatus waits for the deployment of the security guard to appear, if enabled is true,
+// or to disappear, if enabled is false, and prints the resulting queue-proxy feature flags. If Knative Serving
+// runs in a remote cluster, the deployment and the ConfigMap are not available locally, so only the state of
+// the custom resource is reported.
+func ReportSecurityGuardStatus(out io.Writer, namespace string, enabled bool, p *pkg.OperatorParams) error {
+	ksCR, err := GetKnativeOperatorCR(p)
+	if err != nil {
+		return err
+	}
+	state, err := ksCR.GetKnativeCRState(ServingComponent, namespace)
+	if err != nil {
+		return err
+	}
+	if state != nil && state.ClusterProfileRef != nil {
+		fmt.Fprintf(out, "Knative Serving runs in the remote cluster of the ClusterProfile %s, so the deployment %s is not checked.\n",
+			FormatClusterProfileRef(state.ClusterProfileRef), SecurityGuardDeployment)
+		if state.Ready {
+			fmt.Fprintf(out, "The custom resource of Knative Serving in the namespace %s is ready.\n", namespace)
+		} else {
+			fmt.Fprintf(out, "The custom resource of Knative Serving in the namespace %s is not ready yet.\n", namespace)
+		}
+		return nil
+	}
+
+	client, err := p.NewKubeClient()
+	if err != nil {
+		return fmt.Errorf("cannot get source cluster kube config, please use --kubeconfig or export environment variable KUBECONFIG to set\n")
+	}
+
+	deploy := Deployment{
+		Client: client,
+	}
+	if err = deploy.WaitForDeploymentPresence(SecurityGuardDeployment, namespace, enabled); err != nil {
+		return fmt.Errorf("Failed to wait for the deployment %s in the namespace %s: %v", SecurityGuardDeployment, namespace, err)
+	}
+	if enabled {
+		fmt.Fprintf(out, "The deployment %s is available.\n", SecurityGuardDeployment)
+	} else {
+		fmt.Fprintf(out, "The deployment %s is removed.\n", SecurityGuardDeployment)
+	}
+
+	kubeResource := KubeResource{
+		KubeClient: client,
+	}
+	features, err := kubeResource.GetQueueProxyFeatures(namespace)
+	if err != nil {
+		return err
+	}
+	if len(features) == 0 {
+		fmt.Fprintf(out, "No queue-proxy feature flags are set in the ConfigMap %s.\n", ConfigFeaturesName)
+		return nil
+	}
+	fmt.Fprintf(out, "The queue-proxy feature flags in the ConfigMap %s:\n%s\n", ConfigFeaturesName, FormatFeatures(features))
+	return nil
+}
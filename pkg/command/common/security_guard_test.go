@@ -0,0 +1,77 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package common
+
+import (
+	"testing"
+
+	"knative.dev/kn-plugin-operator/pkg/command/testingUtil"
+)
+
+func TestFilterQueueProxyFeatures(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		input          map[string]string
+		expectedResult map[string]string
+	}{{
+		name: "Config features with queue-proxy flags",
+		input: map[string]string{
+			"queueproxy.mount-podinfo":     "enabled",
+			"kubernetes.podspec-fieldref":  "disabled",
+			"queueproxy.resource-defaults": "disabled",
+		},
+		expectedResult: map[string]string{
+			"queueproxy.mount-podinfo":     "enabled",
+			"queueproxy.resource-defaults": "disabled",
+		},
+	}, {
+		name: "Config features without queue-proxy flags",
+		input: map[string]string{
+			"kubernetes.podspec-fieldref": "disabled",
+		},
+		expectedResult: map[string]string{},
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := FilterQueueProxyFeatures(tt.input)
+			testingUtil.AssertDeepEqual(t, result, tt.expectedResult)
+		})
+	}
+}
+
+func TestFormatFeatures(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		input          map[string]string
+		expectedResult string
+	}{{
+		name: "Feature flags sorted by the key",
+		input: map[string]string{
+			"queueproxy.resource-defaults": "disabled",
+			"queueproxy.mount-podinfo":     "enabled",
+		},
+		expectedResult: `  queueproxy.mount-podinfo: enabled
+  queueproxy.resource-defaults: disabled`,
+	}, {
+		name:           "No feature flags",
+		input:          map[string]string{},
+		expectedResult: "",
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := FormatFeatures(tt.input)
+			testingUtil.AssertEqual(t, result, tt.expectedResult)
+		})
+	}
+}
@@ -16,6 +16,14 @@ limitations under the License.
 
 package common
 
+import (
+	"fmt"
+	"strings"
+)
+
+// ConfigMapPrefix is the prefix of the names of the Knative ConfigMaps, which is optional in spec.config
+const ConfigMapPrefix = "config-"
+
 func Contains(s []string, searchterm string) bool {
 	set := make(map[string]struct{}, len(s))
 	for _, s := range s {
@@ -25,3 +33,14 @@ func Contains(s []string, searchterm string) bool {
 	_, ok := set[searchterm]
 	return ok
 }
+
+// AddOrRemovePrefix removes the prefix from val if val has it, or adds the prefix to val otherwise
+func AddOrRemovePrefix(val, prefix string) string {
+	result := ""
+	if strings.HasPrefix(val, prefix) {
+		result = val[len(prefix):]
+	} else {
+		result = fmt.Sprintf("%s%s", prefix, val)
+	}
+	return result
+}
@@ -29,3 +29,11 @@ func TestContains(t *testing.T) {
 	exists = Contains(input, "bird-not-exist")
 	testingUtil.AssertEqual(t, exists, false)
 }
+
+func TestAddOrRemovePrefix(t *testing.T) {
+	result := AddOrRemovePrefix("test", "config-")
+	testingUtil.AssertDeepEqual(t, result, "config-test")
+
+	result = AddOrRemovePrefix("config-test", "config-")
+	testingUtil.AssertDeepEqual(t, result, "test")
+}
@@ -70,3 +70,51 @@ spec:
 	testingUtil.AssertEqual(t, err == nil, true)
 	testingUtil.AssertEqual(t, finalContent, expectedYAMLTplData)
 }
+
+func TestRenderOverlays(t *testing.T) {
+	yamlTplData := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: knative-operator
+  namespace: default
+spec:
+  replicas: 1
+`
+
+	overlayData := `#@ load("@ytt:overlay", "overlay")
+#@ load("@ytt:data", "data")
+
+#@overlay/match by=overlay.subset({"kind": "Deployment", "metadata":{"name":"knative-operator"}}),expects=1
+---
+metadata:
+  #@overlay/match missing_ok=True
+  labels:
+    #@overlay/match missing_ok=True
+    #@yaml/text-templated-strings
+    (@= data.values.key @): #@ data.values.value
+`
+
+	overlays := []OverlayValues{{
+		Overlay: overlayData,
+		Values:  "#@data/values\n---\nkey: first\nvalue: a",
+	}, {
+		Overlay: overlayData,
+		Values:  "#@data/values\n---\nkey: second\nvalue: b",
+	}}
+
+	expectedYAMLTplData := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: knative-operator
+  namespace: default
+  labels:
+    first: a
+    second: b
+spec:
+  replicas: 1
+`
+
+	finalContent, err := RenderOverlays(yamlTplData, overlays)
+	testingUtil.AssertEqual(t, err == nil, true)
+	testingUtil.AssertEqual(t, finalContent, expectedYAMLTplData)
+}
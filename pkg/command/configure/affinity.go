@@ -0,0 +1,316 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configure
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc" // from https://github.com/kubernetes/client-go/issues/345
+
+	"knative.dev/kn-plugin-operator/pkg"
+	"knative.dev/kn-plugin-operator/pkg/command/common"
+)
+
+//go:embed overlay/ks_affinity.yaml
+var servingAffinityOverlay string
+
+//go:embed overlay/ke_affinity.yaml
+var eventingAffinityOverlay string
+
+type AffinityFlags struct {
+	NodeAffinityKey      string
+	NodeAffinityOperator string
+	NodeAffinityValues   string
+	PodAntiAffinity      string
+	Required             bool
+	File                 string
+	Component            string
+	Namespace            string
+	DeployName           string
+}
+
+var affinityCMDFlags AffinityFlags
+
+// antiAffinityTopologyKeys maps the presets of the pod anti-affinity to their topology keys
+var antiAffinityTopologyKeys = map[string]string{
+	"hostname": corev1.LabelHostname,
+	"zone":     corev1.LabelTopologyZone,
+}
+
+func getValidNodeAffinityOperators() []string {
+	return []string{"In", "NotIn", "Exists", "DoesNotExist", "Gt", "Lt"}
+}
+
+// newAffinityCommand represents the configure commands to configure the affinity for Knative deployments
+func newAffinityCommand(p *pkg.OperatorParams) *cobra.Command {
+	var configureAffinityCmd = &cobra.Command{
+		Use:   "affinity",
+		Short: "Configure the affinity for Knative Serving and Eventing deployments",
+		Example: `
+  # Spread the replicas of the deployment activator across zones
+  kn operator configure affinity --component serving --deployName activator --podAntiAffinity zone --namespace knative-serving
+  # Schedule the deployment webhook only on the nodes with the label node-role=knative
+  kn operator configure affinity --component serving --deployName webhook --nodeAffinityKey node-role --nodeAffinityValues knative --required --namespace knative-serving
+  # Configure the affinity for the deployment eventing-controller with the content of a local file
+  kn operator configure affinity --component eventing --deployName eventing-controller --from-file affinity.yaml --namespace knative-eventing`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateAffinityFlags(affinityCMDFlags); err != nil {
+				return err
+			}
+
+			err := configureAffinity(affinityCMDFlags, p)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "The specified affinity has been configured for the deployment %s in the namespace '%s'.\n",
+				affinityCMDFlags.DeployName, affinityCMDFlags.Namespace)
+			return nil
+		},
+	}
+
+	configureAffinityCmd.Flags().StringVar(&affinityCMDFlags.NodeAffinityKey, "nodeAffinityKey", "", "The key of the node label for the node affinity")
+	configureAffinityCmd.Flags().StringVar(&affinityCMDFlags.NodeAffinityOperator, "nodeAffinityOperator", "In", "The operator for the node affinity: In, NotIn, Exists, DoesNotExist, Gt or Lt")
+	configureAffinityCmd.Flags().StringVar(&affinityCMDFlags.NodeAffinityValues, "nodeAffinityValues", "", "The comma separated values of the node label for the node affinity")
+	configureAffinityCmd.Flags().StringVar(&affinityCMDFlags.PodAntiAffinity, "podAntiAffinity", "", "The preset to spread the pods of the deployment: hostname or zone")
+	configureAffinityCmd.Flags().BoolVar(&affinityCMDFlags.Required, "required", false, "The flag to make the affinity a hard requirement instead of a preference")
+	configureAffinityCmd.Flags().StringVar(&affinityCMDFlags.File, "from-file", "", "The path to the local file with the full affinity in yaml")
+	configureAffinityCmd.Flags().StringVar(&affinityCMDFlags.DeployName, "deployName", "", "The flag to specify the deployment name")
+	configureAffinityCmd.Flags().StringVarP(&affinityCMDFlags.Component, "component", "c", "", "The flag to specify the component name")
+	configureAffinityCmd.Flags().StringVarP(&affinityCMDFlags.Namespace, "namespace", "n", "", "The namespace of the Knative Operator or the Knative component")
+
+	return configureAffinityCmd
+}
+
+func validateAffinityFlags(affinityCMDFlags AffinityFlags) error {
+	if affinityCMDFlags.File == "" && affinityCMDFlags.NodeAffinityKey == "" && affinityCMDFlags.PodAntiAffinity == "" {
+		return fmt.Errorf("You need to specify at least one of the following parameters: nodeAffinityKey, podAntiAffinity or from-file.")
+	}
+	if affinityCMDFlags.File != "" && (affinityCMDFlags.NodeAffinityKey != "" || affinityCMDFlags.PodAntiAffinity != "") {
+		return fmt.Errorf("You cannot specify from-file together with nodeAffinityKey or podAntiAffinity.")
+	}
+	if affinityCMDFlags.PodAntiAffinity != "" {
+		if _, ok := antiAffinityTopologyKeys[affinityCMDFlags.PodAntiAffinity]; !ok {
+			return fmt.Errorf("You need to specify the podAntiAffinity to one of the following values: hostname or zone.")
+		}
+	}
+	if affinityCMDFlags.NodeAffinityKey != "" {
+		if !common.Contains(getValidNodeAffinityOperators(), affinityCMDFlags.NodeAffinityOperator) {
+			return fmt.Errorf("You need to specify the nodeAffinityOperator to one of the following values: In, NotIn, Exists, DoesNotExist, Gt or Lt.")
+		}
+		values := getNodeAffinityValues(affinityCMDFlags)
+		switch affinityCMDFlags.NodeAffinityOperator {
+		case "In", "NotIn":
+			if len(values) == 0 {
+				return fmt.Errorf("You need to specify the nodeAffinityValues, if the nodeAffinityOperator is In or NotIn.")
+			}
+		case "Exists", "DoesNotExist":
+			if len(values) != 0 {
+				return fmt.Errorf("You cannot specify the nodeAffinityValues, if the nodeAffinityOperator is Exists or DoesNotExist.")
+			}
+		case "Gt", "Lt":
+			if len(values) != 1 {
+				return fmt.Errorf("You need to specify exactly one value in nodeAffinityValues, if the nodeAffinityOperator is Gt or Lt.")
+			}
+		}
+	}
+	if affinityCMDFlags.DeployName == "" {
+		return fmt.Errorf("You need to specify the name of the deployment.")
+	}
+	if affinityCMDFlags.Namespace == "" {
+		return fmt.Errorf("You need to specify the namespace.")
+	}
+	if affinityCMDFlags.Component == "" {
+		return fmt.Errorf("You need to specify the component name.")
+	}
+	if !strings.EqualFold(affinityCMDFlags.Component, common.ServingComponent) && !strings.EqualFold(affinityCMDFlags.Component, common.EventingComponent) {
+		return fmt.Errorf("You need to specify the component for Knative: serving or eventing.")
+	}
+	return nil
+}
+
+func configureAffinity(affinityCMDFlags AffinityFlags, p *pkg.OperatorParams) error {
+	component := common.ServingComponent
+	if strings.EqualFold(affinityCMDFlags.Component, common.EventingComponent) {
+		component = common.EventingComponent
+	}
+	yamlTemplateString, err := common.GenerateOperatorCRString(component, affinityCMDFlags.Namespace, p)
+	if err != nil {
+		return err
+	}
+
+	overlayContent := getOverlayYamlContentAffinity(affinityCMDFlags)
+	valuesYaml, err := getYamlValuesContentAffinity(affinityCMDFlags)
+	if err != nil {
+		return err
+	}
+
+	if err = common.ApplyManifests(yamlTemplateString, overlayContent, valuesYaml, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+func getOverlayYamlContentAffinity(affinityCMDFlags AffinityFlags) string {
+	baseOverlayContent := servingAffinityOverlay
+	if strings.EqualFold(affinityCMDFlags.Component, common.EventingComponent) {
+		baseOverlayContent = eventingAffinityOverlay
+	}
+	affinityContent := getAffinityConfiguration(affinityCMDFlags)
+	baseOverlayContent = fmt.Sprintf("%s\n%s", baseOverlayContent, affinityContent)
+	return baseOverlayContent
+}
+
+func getAffinityConfiguration(affinityCMDFlags AffinityFlags) string {
+	resourceArray := []string{}
+	if affinityCMDFlags.File != "" {
+		// The affinity loaded from the file replaces the whole existing affinity
+		tag := fmt.Sprintf("%s%s", common.Spaces(4), common.YttMatchingTag)
+		resourceArray = append(resourceArray, tag)
+		tag = fmt.Sprintf("%s%s", common.Spaces(4), common.YttReplaceTag)
+		resourceArray = append(resourceArray, tag)
+		affinityField := fmt.Sprintf("%s%s", common.Spaces(4), "affinity: #@ data.values.affinity")
+		resourceArray = append(resourceArray, affinityField)
+		return strings.Join(resourceArray, "\n")
+	}
+
+	tag := fmt.Sprintf("%s%s", common.Spaces(4), common.YttMatchingTag)
+	resourceArray = append(resourceArray, tag)
+	affinityField := fmt.Sprintf("%s%s", common.Spaces(4), "affinity:")
+	resourceArray = append(resourceArray, affinityField)
+
+	if affinityCMDFlags.NodeAffinityKey != "" {
+		tag = fmt.Sprintf("%s%s", common.Spaces(6), common.YttMatchingTag)
+		resourceArray = append(resourceArray, tag)
+		tag = fmt.Sprintf("%s%s", common.Spaces(6), common.YttReplaceTag)
+		resourceArray = append(resourceArray, tag)
+		nodeAffinityField := fmt.Sprintf("%s%s", common.Spaces(6), "nodeAffinity: #@ data.values.nodeAffinity")
+		resourceArray = append(resourceArray, nodeAffinityField)
+	}
+
+	if affinityCMDFlags.PodAntiAffinity != "" {
+		tag = fmt.Sprintf("%s%s", common.Spaces(6), common.YttMatchingTag)
+		resourceArray = append(resourceArray, tag)
+		tag = fmt.Sprintf("%s%s", common.Spaces(6), common.YttReplaceTag)
+		resourceArray = append(resourceArray, tag)
+		podAntiAffinityField := fmt.Sprintf("%s%s", common.Spaces(6), "podAntiAffinity: #@ data.values.podAntiAffinity")
+		resourceArray = append(resourceArray, podAntiAffinityField)
+	}
+
+	return strings.Join(resourceArray, "\n")
+}
+
+func getYamlValuesContentAffinity(affinityCMDFlags AffinityFlags) (string, error) {
+	contentArray := []string{}
+	header := "#@data/values\n---"
+	contentArray = append(contentArray, header)
+	namespace := fmt.Sprintf("namespace: %s", affinityCMDFlags.Namespace)
+	contentArray = append(contentArray, namespace)
+	deployName := fmt.Sprintf("deployName: %s", affinityCMDFlags.DeployName)
+	contentArray = append(contentArray, deployName)
+
+	if affinityCMDFlags.File != "" {
+		affinity := &corev1.Affinity{}
+		if err := common.ReadYamlFile(affinityCMDFlags.File, affinity); err != nil {
+			return "", err
+		}
+		value, err := json.Marshal(affinity)
+		if err != nil {
+			return "", err
+		}
+		contentArray = append(contentArray, fmt.Sprintf("affinity: %s", value))
+		return strings.Join(contentArray, "\n"), nil
+	}
+
+	if affinityCMDFlags.NodeAffinityKey != "" {
+		value, err := json.Marshal(getNodeAffinity(affinityCMDFlags))
+		if err != nil {
+			return "", err
+		}
+		contentArray = append(contentArray, fmt.Sprintf("nodeAffinity: %s", value))
+	}
+
+	if affinityCMDFlags.PodAntiAffinity != "" {
+		value, err := json.Marshal(getPodAntiAffinity(affinityCMDFlags))
+		if err != nil {
+			return "", err
+		}
+		contentArray = append(contentArray, fmt.Sprintf("podAntiAffinity: %s", value))
+	}
+
+	return strings.Join(contentArray, "\n"), nil
+}
+
+func getNodeAffinityValues(affinityCMDFlags AffinityFlags) []string {
+	var values []string
+	for _, value := range strings.Split(affinityCMDFlags.NodeAffinityValues, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func getNodeAffinity(affinityCMDFlags AffinityFlags) *corev1.NodeAffinity {
+	term := corev1.NodeSelectorTerm{
+		MatchExpressions: []corev1.NodeSelectorRequirement{{
+			Key:      affinityCMDFlags.NodeAffinityKey,
+			Operator: corev1.NodeSelectorOperator(affinityCMDFlags.NodeAffinityOperator),
+			Values:   getNodeAffinityValues(affinityCMDFlags),
+		}},
+	}
+
+	if affinityCMDFlags.Required {
+		return &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{term},
+			},
+		}
+	}
+	return &corev1.NodeAffinity{
+		PreferredDuringSchedulingIgnoredDuringExecution: []corev1.PreferredSchedulingTerm{{
+			Weight:     100,
+			Preference: term,
+		}},
+	}
+}
+
+func getPodAntiAffinity(affinityCMDFlags AffinityFlags) *corev1.PodAntiAffinity {
+	// The pods of the Knative deployments are labelled with app set to the name of the deployment
+	term := corev1.PodAffinityTerm{
+		LabelSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{"app": affinityCMDFlags.DeployName},
+		},
+		TopologyKey: antiAffinityTopologyKeys[affinityCMDFlags.PodAntiAffinity],
+	}
+
+	if affinityCMDFlags.Required {
+		return &corev1.PodAntiAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{term},
+		}
+	}
+	return &corev1.PodAntiAffinity{
+		PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{{
+			Weight:          100,
+			PodAffinityTerm: term,
+		}},
+	}
+}
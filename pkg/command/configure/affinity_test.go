@@ -0,0 +1,287 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configure
+
+import (
+	"fmt"
+	"testing"
+
+	"knative.dev/kn-plugin-operator/pkg/command/testingUtil"
+)
+
+func TestValidateAffinityFlags(t *testing.T) {
+	for _, tt := range []struct {
+		name             string
+		affinityCMDFlags AffinityFlags
+		expectedResult   error
+	}{{
+		name: "Affinity flags with pod anti-affinity",
+		affinityCMDFlags: AffinityFlags{
+			PodAntiAffinity: "zone",
+			Component:       "serving",
+			Namespace:       "test-serving",
+			DeployName:      "activator",
+		},
+		expectedResult: nil,
+	}, {
+		name: "Affinity flags with node affinity",
+		affinityCMDFlags: AffinityFlags{
+			NodeAffinityKey:      "node-role",
+			NodeAffinityOperator: "In",
+			NodeAffinityValues:   "knative,serving",
+			Component:            "serving",
+			Namespace:            "test-serving",
+			DeployName:           "activator",
+		},
+		expectedResult: nil,
+	}, {
+		name: "Affinity flags without any affinity",
+		affinityCMDFlags: AffinityFlags{
+			Component:  "serving",
+			Namespace:  "test-serving",
+			DeployName: "activator",
+		},
+		expectedResult: fmt.Errorf("You need to specify at least one of the following parameters: nodeAffinityKey, podAntiAffinity or from-file."),
+	}, {
+		name: "Affinity flags with the file and the pod anti-affinity",
+		affinityCMDFlags: AffinityFlags{
+			File:            "affinity.yaml",
+			PodAntiAffinity: "zone",
+			Component:       "serving",
+			Namespace:       "test-serving",
+			DeployName:      "activator",
+		},
+		expectedResult: fmt.Errorf("You cannot specify from-file together with nodeAffinityKey or podAntiAffinity."),
+	}, {
+		name: "Affinity flags with invalid pod anti-affinity",
+		affinityCMDFlags: AffinityFlags{
+			PodAntiAffinity: "region",
+			Component:       "serving",
+			Namespace:       "test-serving",
+			DeployName:      "activator",
+		},
+		expectedResult: fmt.Errorf("You need to specify the podAntiAffinity to one of the following values: hostname or zone."),
+	}, {
+		name: "Affinity flags with invalid node affinity operator",
+		affinityCMDFlags: AffinityFlags{
+			NodeAffinityKey:      "node-role",
+			NodeAffinityOperator: "Equal",
+			NodeAffinityValues:   "knative",
+			Component:            "serving",
+			Namespace:            "test-serving",
+			DeployName:           "activator",
+		},
+		expectedResult: fmt.Errorf("You need to specify the nodeAffinityOperator to one of the following values: In, NotIn, Exists, DoesNotExist, Gt or Lt."),
+	}, {
+		name: "Affinity flags with the operator In and no values",
+		affinityCMDFlags: AffinityFlags{
+			NodeAffinityKey:      "node-role",
+			NodeAffinityOperator: "In",
+			Component:            "serving",
+			Namespace:            "test-serving",
+			DeployName:           "activator",
+		},
+		expectedResult: fmt.Errorf("You need to specify the nodeAffinityValues, if the nodeAffinityOperator is In or NotIn."),
+	}, {
+		name: "Affinity flags with the operator Exists and values",
+		affinityCMDFlags: AffinityFlags{
+			NodeAffinityKey:      "node-role",
+			NodeAffinityOperator: "Exists",
+			NodeAffinityValues:   "knative",
+			Component:            "serving",
+			Namespace:            "test-serving",
+			DeployName:           "activator",
+		},
+		expectedResult: fmt.Errorf("You cannot specify the nodeAffinityValues, if the nodeAffinityOperator is Exists or DoesNotExist."),
+	}, {
+		name: "Affinity flags with the operator Gt and multiple values",
+		affinityCMDFlags: AffinityFlags{
+			NodeAffinityKey:      "cpu-count",
+			NodeAffinityOperator: "Gt",
+			NodeAffinityValues:   "4,8",
+			Component:            "serving",
+			Namespace:            "test-serving",
+			DeployName:           "activator",
+		},
+		expectedResult: fmt.Errorf("You need to specify exactly one value in nodeAffinityValues, if the nodeAffinityOperator is Gt or Lt."),
+	}, {
+		name: "Affinity flags without deployment name",
+		affinityCMDFlags: AffinityFlags{
+			PodAntiAffinity: "hostname",
+			Component:       "serving",
+			Namespace:       "test-serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify the name of the deployment."),
+	}, {
+		name: "Affinity flags with invalid component",
+		affinityCMDFlags: AffinityFlags{
+			PodAntiAffinity: "hostname",
+			Component:       "test",
+			Namespace:       "test-serving",
+			DeployName:      "activator",
+		},
+		expectedResult: fmt.Errorf("You need to specify the component for Knative: serving or eventing."),
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := validateAffinityFlags(tt.affinityCMDFlags)
+			if tt.expectedResult == nil {
+				testingUtil.AssertEqual(t, result, nil)
+			} else {
+				testingUtil.AssertEqual(t, result.Error(), tt.expectedResult.Error())
+			}
+		})
+	}
+}
+
+func TestGetOverlayYamlContentAffinity(t *testing.T) {
+	for _, tt := range []struct {
+		name             string
+		affinityCMDFlags AffinityFlags
+		expectedResult   string
+	}{{
+		name: "Knative Serving with node affinity and pod anti-affinity",
+		affinityCMDFlags: AffinityFlags{
+			NodeAffinityKey: "node-role",
+			PodAntiAffinity: "zone",
+			Component:       "serving",
+			Namespace:       "test-serving",
+			DeployName:      "activator",
+		},
+		expectedResult: `#@ load("@ytt:overlay", "overlay")
+#@ load("@ytt:data", "data")
+
+#@overlay/match by=overlay.subset({"kind": "KnativeServing"}),expects=1
+---
+apiVersion: operator.knative.dev/v1beta1
+kind: KnativeServing
+metadata:
+  #@overlay/match missing_ok=True
+  namespace: #@ data.values.namespace
+#@overlay/match missing_ok=True
+spec:
+  #@overlay/match missing_ok=True
+  workloads:
+  #@overlay/match by="name",missing_ok=True
+  - name: #@ data.values.deployName
+
+    #@overlay/match missing_ok=True
+    affinity:
+      #@overlay/match missing_ok=True
+      #@overlay/replace or_add=True
+      nodeAffinity: #@ data.values.nodeAffinity
+      #@overlay/match missing_ok=True
+      #@overlay/replace or_add=True
+      podAntiAffinity: #@ data.values.podAntiAffinity`,
+	}, {
+		name: "Knative Eventing with the affinity from the file",
+		affinityCMDFlags: AffinityFlags{
+			File:       "testdata/affinity.yaml",
+			Component:  "eventing",
+			Namespace:  "test-eventing",
+			DeployName: "eventing-controller",
+		},
+		expectedResult: `#@ load("@ytt:overlay", "overlay")
+#@ load("@ytt:data", "data")
+
+#@overlay/match by=overlay.subset({"kind": "KnativeEventing"}),expects=1
+---
+apiVersion: operator.knative.dev/v1beta1
+kind: KnativeEventing
+metadata:
+  #@overlay/match missing_ok=True
+  namespace: #@ data.values.namespace
+#@overlay/match missing_ok=True
+spec:
+  #@overlay/match missing_ok=True
+  workloads:
+  #@overlay/match by="name",missing_ok=True
+  - name: #@ data.values.deployName
+
+    #@overlay/match missing_ok=True
+    #@overlay/replace or_add=True
+    affinity: #@ data.values.affinity`,
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := getOverlayYamlContentAffinity(tt.affinityCMDFlags)
+			testingUtil.AssertEqual(t, result, tt.expectedResult)
+		})
+	}
+}
+
+func TestGetYamlValuesContentAffinity(t *testing.T) {
+	for _, tt := range []struct {
+		name             string
+		affinityCMDFlags AffinityFlags
+		expectedResult   string
+		expectedErr      bool
+	}{{
+		name: "Knative Serving with preferred pod anti-affinity",
+		affinityCMDFlags: AffinityFlags{
+			PodAntiAffinity: "zone",
+			Component:       "serving",
+			Namespace:       "test-serving",
+			DeployName:      "activator",
+		},
+		expectedResult: `#@data/values
+---
+namespace: test-serving
+deployName: activator
+podAntiAffinity: {"preferredDuringSchedulingIgnoredDuringExecution":[{"weight":100,"podAffinityTerm":{"labelSelector":{"matchLabels":{"app":"activator"}},"topologyKey":"topology.kubernetes.io/zone"}}]}`,
+	}, {
+		name: "Knative Serving with required node affinity",
+		affinityCMDFlags: AffinityFlags{
+			NodeAffinityKey:      "node-role",
+			NodeAffinityOperator: "In",
+			NodeAffinityValues:   "knative, serving",
+			Required:             true,
+			Component:            "serving",
+			Namespace:            "test-serving",
+			DeployName:           "webhook",
+		},
+		expectedResult: `#@data/values
+---
+namespace: test-serving
+deployName: webhook
+nodeAffinity: {"requiredDuringSchedulingIgnoredDuringExecution":{"nodeSelectorTerms":[{"matchExpressions":[{"key":"node-role","operator":"In","values":["knative","serving"]}]}]}}`,
+	}, {
+		name: "Knative Eventing with the affinity from the file",
+		affinityCMDFlags: AffinityFlags{
+			File:       "testdata/affinity.yaml",
+			Component:  "eventing",
+			Namespace:  "test-eventing",
+			DeployName: "eventing-controller",
+		},
+		expectedResult: `#@data/values
+---
+namespace: test-eventing
+deployName: eventing-controller
+affinity: {"nodeAffinity":{"requiredDuringSchedulingIgnoredDuringExecution":{"nodeSelectorTerms":[{"matchExpressions":[{"key":"node-role","operator":"In","values":["knative"]}]}]}}}`,
+	}, {
+		name: "Knative Eventing with a missing file",
+		affinityCMDFlags: AffinityFlags{
+			File:       "testdata/missing.yaml",
+			Component:  "eventing",
+			Namespace:  "test-eventing",
+			DeployName: "eventing-controller",
+		},
+		expectedErr: true,
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := getYamlValuesContentAffinity(tt.affinityCMDFlags)
+			testingUtil.AssertEqual(t, err != nil, tt.expectedErr)
+			testingUtil.AssertEqual(t, result, tt.expectedResult)
+		})
+	}
+}
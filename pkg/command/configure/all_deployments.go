@@ -0,0 +1,80 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configure
+
+import (
+	"fmt"
+
+	"knative.dev/kn-plugin-operator/pkg"
+	"knative.dev/kn-plugin-operator/pkg/command/common"
+)
+
+const allDeploymentsUsage = "The flag to apply the configuration to all the deployments of the Knative component installed in the cluster"
+
+// getComponentDeployments discovers the deployments of the Knative component and their containers from the cluster
+func getComponentDeployments(component, namespace string, p *pkg.OperatorParams) (map[string][]string, error) {
+	client, err := p.NewKubeClient()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get source cluster kube config, please use --kubeconfig or export environment variable KUBECONFIG to set\n")
+	}
+	deploy := common.Deployment{
+		Client: client,
+	}
+	deploymentContainers, err := deploy.GetComponentDeployments(component, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if len(deploymentContainers) == 0 {
+		return nil, fmt.Errorf("No deployment of Knative %s is found in the namespace %s.", component, namespace)
+	}
+	return deploymentContainers, nil
+}
+
+// getTargetDeployments returns all the deployments of the Knative component, if allDeployments is true. Otherwise, it
+// returns the specified deployment only.
+func getTargetDeployments(allDeployments bool, component, namespace, deployName string, p *pkg.OperatorParams) ([]string, error) {
+	if !allDeployments {
+		return []string{deployName}, nil
+	}
+	deploymentContainers, err := getComponentDeployments(component, namespace, p)
+	if err != nil {
+		return nil, err
+	}
+	return common.DeploymentNames(deploymentContainers), nil
+}
+
+// getTargetWorkloads returns the containers of all the deployments of the Knative component, if allDeployments is
+// true. Otherwise, it returns the specified container of the specified deployment only.
+func getTargetWorkloads(allDeployments bool, component, namespace, deployName, container string, p *pkg.OperatorParams) ([]common.WorkloadTarget, error) {
+	if !allDeployments {
+		return []common.WorkloadTarget{{DeployName: deployName, ContainerName: container}}, nil
+	}
+	deploymentContainers, err := getComponentDeployments(component, namespace, p)
+	if err != nil {
+		return nil, err
+	}
+	targets := common.GetWorkloadTargets(deploymentContainers, container)
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("No deployment of Knative %s has the container %s in the namespace %s.", component, container, namespace)
+	}
+	return targets, nil
+}
+
+func validateAllDeploymentsFlags(allDeployments bool, deployName string) error {
+	if allDeployments && deployName != "" {
+		return fmt.Errorf("You cannot specify the name of the deployment together with --all-deployments.")
+	}
+	return nil
+}
@@ -42,7 +42,11 @@ func newAnnotationCommand(p *pkg.OperatorParams) *cobra.Command {
   # Configure the annotations for Knative Serving and Eventing deployments
   kn operator configure annotations --component eventing --deployName eventing-controller --key key --value value --namespace knative-eventing
   # Configure the annotations for Knative Serving and Eventing services
-  kn operator configure annotations --component eventing --serviceName eventing-controller --key key --value value --namespace knative-eventing`,
+  kn operator configure annotations --component eventing --serviceName eventing-controller --key key --value value --namespace knative-eventing
+  # Configure the annotations for all the deployments of Knative Serving
+  kn operator configure annotations --component serving --all-deployments --key key --value value --namespace knative-serving
+  # Configure multiple annotations for the deployment of Knative Serving at once
+  kn operator configure annotations --component serving --deployName controller --set key1=value1 --set-file key2=path --namespace knative-serving`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := validateLabelsAnnotationsFlags(annotationCMDFlags); err != nil {
 				return err
@@ -54,7 +58,7 @@ func newAnnotationCommand(p *pkg.OperatorParams) *cobra.Command {
 			}
 
 			fmt.Fprintf(cmd.OutOrStdout(), "The specified annotation has been configured for the deployment %s in the deployment '%s'.\n",
-				annotationCMDFlags.DeployName, annotationCMDFlags.Namespace)
+				getDeploymentDescription(annotationCMDFlags), annotationCMDFlags.Namespace)
 			return nil
 		},
 	}
@@ -63,6 +67,9 @@ func newAnnotationCommand(p *pkg.OperatorParams) *cobra.Command {
 	configureLabelsCmd.Flags().StringVar(&annotationCMDFlags.Value, "value", "", "The value of the data in the configmap")
 	configureLabelsCmd.Flags().StringVar(&annotationCMDFlags.DeployName, "deployName", "", "The flag to specify the deployment name")
 	configureLabelsCmd.Flags().StringVar(&annotationCMDFlags.ServiceName, "serviceName", "", "The flag to specify the service name")
+	configureLabelsCmd.Flags().BoolVar(&annotationCMDFlags.AllDeployments, "all-deployments", false, allDeploymentsUsage)
+	configureLabelsCmd.Flags().StringArrayVar(&annotationCMDFlags.Set, "set", []string{}, "The key value pair in the format of key=value. It can be specified multiple times.")
+	configureLabelsCmd.Flags().StringArrayVar(&annotationCMDFlags.SetFile, "set-file", []string{}, "The key and the path of the file to read the value from, in the format of key=path. It can be specified multiple times.")
 	configureLabelsCmd.Flags().StringVarP(&annotationCMDFlags.Component, "component", "c", "", "The flag to specify the component name")
 	configureLabelsCmd.Flags().StringVarP(&annotationCMDFlags.Namespace, "namespace", "n", "", "The namespace of the Knative Operator or the Knative component")
 
@@ -79,12 +86,11 @@ func configureAnnotations(annotationCMDFlags common.KeyValueFlags, p *pkg.Operat
 		return err
 	}
 
-	annotationOverlayContent := getOverlayYamlContentAnnotation(annotationCMDFlags)
-	valuesYaml := getYamlValuesContent(annotationCMDFlags)
-	if err := common.ApplyManifests(yamlTemplateString, annotationOverlayContent, valuesYaml, p); err != nil {
+	overlays, err := getKeyValueOverlays(annotationCMDFlags, component, getOverlayYamlContentAnnotation, p)
+	if err != nil {
 		return err
 	}
-	return nil
+	return common.ApplyManifestsWithOverlays(yamlTemplateString, overlays, p)
 }
 
 func getOverlayYamlContentAnnotation(annotationCMDFlags common.KeyValueFlags) string {
@@ -104,7 +110,7 @@ func getAnnotationConfiguration(annotationCMDFlags common.KeyValueFlags) string
 	resourceArray = append(resourceArray, tag)
 
 	if annotationCMDFlags.DeployName != "" {
-		field := fmt.Sprintf("%s%s:", common.Spaces(2), "deployments")
+		field := fmt.Sprintf("%s%s:", common.Spaces(2), "workloads")
 		resourceArray = append(resourceArray, field)
 	} else {
 		field := fmt.Sprintf("%s%s:", common.Spaces(2), "services")
@@ -49,7 +49,7 @@ metadata:
 spec:
 
   #@overlay/match missing_ok=True
-  deployments:
+  workloads:
   #@overlay/match by="name",missing_ok=True
   - name: #@ data.values.deployName
     #@overlay/match missing_ok=True
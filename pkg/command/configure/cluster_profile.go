@@ -0,0 +1,168 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configure
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc" // from https://github.com/kubernetes/client-go/issues/345
+
+	"knative.dev/kn-plugin-operator/pkg"
+	"knative.dev/kn-plugin-operator/pkg/command/common"
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+//go:embed overlay/ks_cluster_profile.yaml
+var servingClusterProfileOverlay string
+
+//go:embed overlay/ke_cluster_profile.yaml
+var eventingClusterProfileOverlay string
+
+type ClusterProfileFlags struct {
+	ClusterProfile string
+	Component      string
+	Namespace      string
+	List           bool
+}
+
+var clusterProfileCMDFlags ClusterProfileFlags
+
+// newClusterProfileCommand represents the configure commands to target a remote cluster via a ClusterProfile
+func newClusterProfileCommand(p *pkg.OperatorParams) *cobra.Command {
+	var configureClusterProfileCmd = &cobra.Command{
+		Use:   "cluster-profile",
+		Short: "Configure the ClusterProfile of the remote cluster for Knative Serving or Eventing",
+		Long: `Configure the ClusterProfile of the remote cluster for Knative Serving or Eventing.
+
+spec.clusterProfileRef cannot be added, removed or changed after the custom resource is created, so the ClusterProfile
+can only be configured before Knative is installed. Use --list to report the target cluster and the status conditions.`,
+		Example: `
+  # Configure Knative Serving to be deployed into the remote cluster referenced by the ClusterProfile fleet-system/spoke-1
+  kn operator configure cluster-profile --cluster-profile fleet-system/spoke-1 --component serving --namespace knative-serving
+  # Report the target cluster and the status of Knative Serving
+  kn operator configure cluster-profile --list --component serving --namespace knative-serving`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateClusterProfileFlags(clusterProfileCMDFlags); err != nil {
+				return err
+			}
+
+			if clusterProfileCMDFlags.List {
+				return listClusterProfile(cmd, clusterProfileCMDFlags, p)
+			}
+
+			err := configureClusterProfile(clusterProfileCMDFlags, p)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Knative %s in the namespace '%s' targets the ClusterProfile '%s'.\n",
+				clusterProfileCMDFlags.Component, clusterProfileCMDFlags.Namespace, clusterProfileCMDFlags.ClusterProfile)
+			return nil
+		},
+	}
+
+	configureClusterProfileCmd.Flags().StringVar(&clusterProfileCMDFlags.ClusterProfile, "cluster-profile", "", "The ClusterProfile of the remote cluster, in the format of namespace/name")
+	configureClusterProfileCmd.Flags().StringVarP(&clusterProfileCMDFlags.Component, "component", "c", "", "The name of the Knative Component: serving or eventing")
+	configureClusterProfileCmd.Flags().StringVarP(&clusterProfileCMDFlags.Namespace, "namespace", "n", "", "The namespace of the Knative component")
+	configureClusterProfileCmd.Flags().BoolVar(&clusterProfileCMDFlags.List, "list", false, "The flag to report the target cluster and the status conditions")
+
+	return configureClusterProfileCmd
+}
+
+func validateClusterProfileFlags(clusterProfileCMDFlags ClusterProfileFlags) error {
+	if clusterProfileCMDFlags.List {
+		if clusterProfileCMDFlags.ClusterProfile != "" {
+			return fmt.Errorf("You cannot specify --cluster-profile together with --list.")
+		}
+	} else {
+		if clusterProfileCMDFlags.ClusterProfile == "" {
+			return fmt.Errorf("You need to specify the ClusterProfile.")
+		}
+		if _, err := common.ParseClusterProfileRef(clusterProfileCMDFlags.ClusterProfile); err != nil {
+			return err
+		}
+	}
+	if !strings.EqualFold(clusterProfileCMDFlags.Component, common.ServingComponent) && !strings.EqualFold(clusterProfileCMDFlags.Component, common.EventingComponent) {
+		return fmt.Errorf("You need to specify the component for Knative: serving or eventing.")
+	}
+	if clusterProfileCMDFlags.Namespace == "" {
+		return fmt.Errorf("You need to specify the namespace.")
+	}
+	return nil
+}
+
+func configureClusterProfile(clusterProfileCMDFlags ClusterProfileFlags, p *pkg.OperatorParams) error {
+	ref, err := common.ParseClusterProfileRef(clusterProfileCMDFlags.ClusterProfile)
+	if err != nil {
+		return err
+	}
+
+	ksCR, err := common.GetKnativeOperatorCR(p)
+	if err != nil {
+		return err
+	}
+	state, err := ksCR.GetKnativeCRState(clusterProfileCMDFlags.Component, clusterProfileCMDFlags.Namespace)
+	if err != nil {
+		return err
+	}
+	if state != nil {
+		// The custom resource exists, and the reference can only be kept as it is
+		return common.ValidateClusterProfileRefChange(clusterProfileCMDFlags.Component, state.ClusterProfileRef, ref)
+	}
+
+	yamlTemplateString, err := common.GenerateOperatorCRString(clusterProfileCMDFlags.Component, clusterProfileCMDFlags.Namespace, p)
+	if err != nil {
+		return err
+	}
+
+	overlayContent := getOverlayYamlContentClusterProfile(clusterProfileCMDFlags)
+	valuesYaml := getYamlValuesContentClusterProfile(clusterProfileCMDFlags.Namespace, ref)
+	if err = common.ApplyManifests(yamlTemplateString, overlayContent, valuesYaml, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+func listClusterProfile(cmd *cobra.Command, clusterProfileCMDFlags ClusterProfileFlags, p *pkg.OperatorParams) error {
+	ksCR, err := common.GetKnativeOperatorCR(p)
+	if err != nil {
+		return err
+	}
+	state, err := ksCR.GetKnativeCRState(clusterProfileCMDFlags.Component, clusterProfileCMDFlags.Namespace)
+	if err != nil {
+		return err
+	}
+	common.ReportKnativeCRState(cmd.OutOrStdout(), clusterProfileCMDFlags.Component, clusterProfileCMDFlags.Namespace, state)
+	return nil
+}
+
+func getOverlayYamlContentClusterProfile(clusterProfileCMDFlags ClusterProfileFlags) string {
+	if strings.EqualFold(clusterProfileCMDFlags.Component, common.EventingComponent) {
+		return eventingClusterProfileOverlay
+	}
+	return servingClusterProfileOverlay
+}
+
+func getYamlValuesContentClusterProfile(namespace string, ref *base.ClusterProfileReference) string {
+	contentArray := []string{}
+	header := "#@data/values\n---"
+	contentArray = append(contentArray, header)
+	contentArray = append(contentArray, fmt.Sprintf("namespace: %s", namespace))
+	contentArray = append(contentArray, fmt.Sprintf("clusterProfileRef: {name: %q, namespace: %q}", ref.Name, ref.Namespace))
+	return strings.Join(contentArray, "\n")
+}
@@ -0,0 +1,130 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configure
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"knative.dev/kn-plugin-operator/pkg/command/testingUtil"
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+func TestValidateClusterProfileFlags(t *testing.T) {
+	for _, tt := range []struct {
+		name                   string
+		clusterProfileCMDFlags ClusterProfileFlags
+		expectedResult         error
+	}{{
+		name: "Knative Serving",
+		clusterProfileCMDFlags: ClusterProfileFlags{
+			ClusterProfile: "fleet-system/spoke-1",
+			Component:      "serving",
+			Namespace:      "knative-serving",
+		},
+		expectedResult: nil,
+	}, {
+		name: "Knative Eventing with list",
+		clusterProfileCMDFlags: ClusterProfileFlags{
+			List:      true,
+			Component: "eventing",
+			Namespace: "knative-eventing",
+		},
+		expectedResult: nil,
+	}, {
+		name: "Knative Serving without ClusterProfile",
+		clusterProfileCMDFlags: ClusterProfileFlags{
+			Component: "serving",
+			Namespace: "knative-serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify the ClusterProfile."),
+	}, {
+		name: "Knative Serving with invalid ClusterProfile",
+		clusterProfileCMDFlags: ClusterProfileFlags{
+			ClusterProfile: "spoke-1",
+			Component:      "serving",
+			Namespace:      "knative-serving",
+		},
+		expectedResult: fmt.Errorf("The ClusterProfile spoke-1 should be in the format of namespace/name."),
+	}, {
+		name: "Knative Serving with ClusterProfile and list",
+		clusterProfileCMDFlags: ClusterProfileFlags{
+			ClusterProfile: "fleet-system/spoke-1",
+			List:           true,
+			Component:      "serving",
+			Namespace:      "knative-serving",
+		},
+		expectedResult: fmt.Errorf("You cannot specify --cluster-profile together with --list."),
+	}, {
+		name: "Knative Serving without component",
+		clusterProfileCMDFlags: ClusterProfileFlags{
+			ClusterProfile: "fleet-system/spoke-1",
+			Namespace:      "knative-serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify the component for Knative: serving or eventing."),
+	}, {
+		name: "Knative Serving without namespace",
+		clusterProfileCMDFlags: ClusterProfileFlags{
+			ClusterProfile: "fleet-system/spoke-1",
+			Component:      "serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify the namespace."),
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := validateClusterProfileFlags(tt.clusterProfileCMDFlags)
+			if tt.expectedResult == nil {
+				testingUtil.AssertEqual(t, result, nil)
+			} else {
+				testingUtil.AssertEqual(t, result.Error(), tt.expectedResult.Error())
+			}
+		})
+	}
+}
+
+func TestGetOverlayYamlContentClusterProfile(t *testing.T) {
+	for _, tt := range []struct {
+		name                   string
+		clusterProfileCMDFlags ClusterProfileFlags
+		expectedResultFile     string
+	}{{
+		name: "Knative Serving",
+		clusterProfileCMDFlags: ClusterProfileFlags{
+			Component: "serving",
+		},
+		expectedResultFile: "testdata/overlay/ks_cluster_profile.yaml",
+	}, {
+		name: "Knative Eventing",
+		clusterProfileCMDFlags: ClusterProfileFlags{
+			Component: "eventing",
+		},
+		expectedResultFile: "testdata/overlay/ke_cluster_profile.yaml",
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := getOverlayYamlContentClusterProfile(tt.clusterProfileCMDFlags)
+			expected, err := os.ReadFile(tt.expectedResultFile)
+			testingUtil.AssertEqual(t, err, nil)
+			testingUtil.AssertEqual(t, result, string(expected))
+		})
+	}
+}
+
+func TestGetYamlValuesContentClusterProfile(t *testing.T) {
+	result := getYamlValuesContentClusterProfile("knative-serving", &base.ClusterProfileReference{Namespace: "fleet-system", Name: "spoke-1"})
+	testingUtil.AssertEqual(t, result, `#@data/values
+---
+namespace: knative-serving
+clusterProfileRef: {name: "spoke-1", namespace: "fleet-system"}`)
+}
@@ -15,14 +15,19 @@
 package configure
 
 import (
+	"bytes"
 	_ "embed"
+	"encoding/json"
 	"fmt"
 	"strings"
 
+	"github.com/ghodss/yaml"
 	"github.com/spf13/cobra"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc" // from https://github.com/kubernetes/client-go/issues/345
+	"k8s.io/client-go/util/retry"
 	"knative.dev/kn-plugin-operator/pkg"
 	"knative.dev/kn-plugin-operator/pkg/command/common"
+	"knative.dev/operator/pkg/apis/operator/base"
 )
 
 //go:embed overlay/ks_cm_base.yaml
@@ -33,6 +38,13 @@ var eventingCMOverlay string
 
 var cmsCMDFlags common.CMsFlags
 
+const (
+	// mergeMode updates the keys in the file and keeps the other keys of the ConfigMap
+	mergeMode = "merge"
+	// replaceMode drops the keys of the ConfigMap, which are not in the file
+	replaceMode = "replace"
+)
+
 // newConfigmapsCommand represents the configure commands to update the ConfigMaps in Knative Serving or Eventing
 func newConfigmapsCommand(p *pkg.OperatorParams) *cobra.Command {
 	var configureCMsCmd = &cobra.Command{
@@ -40,13 +52,24 @@ func newConfigmapsCommand(p *pkg.OperatorParams) *cobra.Command {
 		Short: "Configure the configmap for Knative Serving and Eventing deployments",
 		Example: `
   # Configure the CM for Knative Serving and Eventing
-  kn operator configure configmaps --component eventing --cmName eventing-controller --key key --value value --namespace knative-eventing`,
+  kn operator configure configmaps --component eventing --cmName eventing-controller --key key --value value --namespace knative-eventing
+  # Configure multiple keys of the ConfigMap config-autoscaler for Knative Serving at once
+  kn operator configure configmaps --component serving --cmName config-autoscaler --set enable-scale-to-zero=false --set container-concurrency-target-default=50 --namespace knative-serving
+  # Import the ConfigMap config-autoscaler for Knative Serving from a ConfigMap manifest or a YAML file with key value pairs
+  kn operator configure configmaps --component serving --cmName config-autoscaler --from-file autoscaler.yaml --namespace knative-serving
+  # Import the ConfigMap config-autoscaler for Knative Serving and drop the keys, which are not in the file
+  kn operator configure configmaps --component serving --cmName config-autoscaler --from-file autoscaler.yaml --mode replace --namespace knative-serving
+  # Configure a value of the ConfigMap, which does not match the type of the key in the catalogue of the known keys
+  kn operator configure configmaps --component serving --cmName config-autoscaler --key stable-window --value 60 --force --namespace knative-serving`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := validateCMsFlags(cmsCMDFlags); err != nil {
 				return err
 			}
 
-			err := configureCMs(cmsCMDFlags, p)
+			warnings, err := configureCMs(cmsCMDFlags, p)
+			for _, warning := range warnings {
+				fmt.Fprintf(cmd.OutOrStdout(), "Warning: %s\n", warning)
+			}
 			if err != nil {
 				return err
 			}
@@ -60,6 +83,11 @@ func newConfigmapsCommand(p *pkg.OperatorParams) *cobra.Command {
 	configureCMsCmd.Flags().StringVar(&cmsCMDFlags.Key, "key", "", "The key of the data in the configmap")
 	configureCMsCmd.Flags().StringVar(&cmsCMDFlags.Value, "value", "", "The value of the data in the configmap")
 	configureCMsCmd.Flags().StringVar(&cmsCMDFlags.CMName, "cmName", "", "The flag to specify the configmap name")
+	configureCMsCmd.Flags().StringArrayVar(&cmsCMDFlags.Set, "set", []string{}, "The key value pair in the format of key=value. It can be specified multiple times.")
+	configureCMsCmd.Flags().StringArrayVar(&cmsCMDFlags.SetFile, "set-file", []string{}, "The key and the path of the file to read the value from, in the format of key=path. It can be specified multiple times.")
+	configureCMsCmd.Flags().StringVar(&cmsCMDFlags.FromFile, "from-file", "", "The path of the ConfigMap manifest or the YAML file with key value pairs to import as the data of the configmap")
+	configureCMsCmd.Flags().StringVar(&cmsCMDFlags.Mode, "mode", mergeMode, "The mode to import the file: merge or replace. The keys, which are not in the file, are kept with merge and dropped with replace")
+	configureCMsCmd.Flags().BoolVar(&cmsCMDFlags.Force, "force", false, "The flag to configure the invalid values of the configmap with warnings instead of errors. The unknown keys are always configured with warnings.")
 	configureCMsCmd.Flags().StringVarP(&cmsCMDFlags.Component, "component", "c", "", "The flag to specify the component name")
 	configureCMsCmd.Flags().StringVarP(&cmsCMDFlags.Namespace, "namespace", "n", "", "The namespace of the Knative Operator or the Knative component")
 
@@ -67,11 +95,24 @@ func newConfigmapsCommand(p *pkg.OperatorParams) *cobra.Command {
 }
 
 func validateCMsFlags(cmsCMDFlags common.CMsFlags) error {
-	if cmsCMDFlags.Key == "" {
-		return fmt.Errorf("You need to specify the key in the ConfigMap data.")
-	}
-	if cmsCMDFlags.Value == "" {
-		return fmt.Errorf("You need to specify the value in the ConfigMap data.")
+	batch := len(cmsCMDFlags.Set) > 0 || len(cmsCMDFlags.SetFile) > 0
+	if cmsCMDFlags.FromFile != "" {
+		if cmsCMDFlags.Key != "" || cmsCMDFlags.Value != "" || batch {
+			return fmt.Errorf("You cannot specify --from-file together with --key, --value, --set or --set-file.")
+		}
+		if cmsCMDFlags.Mode != "" && !strings.EqualFold(cmsCMDFlags.Mode, mergeMode) && !strings.EqualFold(cmsCMDFlags.Mode, replaceMode) {
+			return fmt.Errorf("You need to specify the mode for --from-file: %s or %s.", mergeMode, replaceMode)
+		}
+	} else {
+		if cmsCMDFlags.Key == "" && (!batch || cmsCMDFlags.Value != "") {
+			return fmt.Errorf("You need to specify the key in the ConfigMap data.")
+		}
+		if cmsCMDFlags.Key != "" && cmsCMDFlags.Value == "" {
+			return fmt.Errorf("You need to specify the value in the ConfigMap data.")
+		}
+		if err := common.ValidateSetFlags(cmsCMDFlags.Set, cmsCMDFlags.SetFile); err != nil {
+			return err
+		}
 	}
 	if cmsCMDFlags.CMName == "" {
 		return fmt.Errorf("You need to specify the name of the ConfigMap.")
@@ -85,22 +126,176 @@ func validateCMsFlags(cmsCMDFlags common.CMsFlags) error {
 	return nil
 }
 
-func configureCMs(cmsCMDFlags common.CMsFlags, p *pkg.OperatorParams) error {
+func configureCMs(cmsCMDFlags common.CMsFlags, p *pkg.OperatorParams) ([]string, error) {
 	component := common.ServingComponent
 	if strings.EqualFold(cmsCMDFlags.Component, common.EventingComponent) {
 		component = common.EventingComponent
 	}
+	ksCR, err := common.GetKnativeOperatorCR(p)
+	if err != nil {
+		return nil, err
+	}
+	if cmsCMDFlags.FromFile != "" {
+		data, err := getCMDataFromFile(cmsCMDFlags)
+		if err != nil {
+			return nil, err
+		}
+		warnings, err := checkCMData(ksCR, component, cmsCMDFlags, data)
+		if err != nil {
+			return warnings, err
+		}
+		return warnings, importCMs(ksCR, cmsCMDFlags, component, data)
+	}
+
+	pairs, err := common.GetKeyValuePairs(cmsCMDFlags.Key, cmsCMDFlags.Value, cmsCMDFlags.Set, cmsCMDFlags.SetFile)
+	if err != nil {
+		return nil, err
+	}
+	data := map[string]string{}
+	for _, pair := range pairs {
+		data[pair.Key] = pair.Value
+	}
+	warnings, err := checkCMData(ksCR, component, cmsCMDFlags, data)
+	if err != nil {
+		return warnings, err
+	}
+
 	yamlTemplateString, err := common.GenerateOperatorCRString(component, cmsCMDFlags.Namespace, p)
 	if err != nil {
-		return err
+		return warnings, err
+	}
+	overlays := []common.OverlayValues{}
+	for _, pair := range pairs {
+		flags := cmsCMDFlags
+		flags.Key = pair.Key
+		flags.Value = pair.Value
+		overlays = append(overlays, common.OverlayValues{Overlay: getOverlayYamlContentCM(flags), Values: getYamlValuesContentCMs(flags)})
 	}
+	return warnings, common.ApplyManifestsWithOverlays(yamlTemplateString, overlays, p)
+}
 
-	overlayContent := getOverlayYamlContentCM(cmsCMDFlags)
-	valuesYaml := getYamlValuesContentCMs(cmsCMDFlags)
-	if err := common.ApplyManifests(yamlTemplateString, overlayContent, valuesYaml, p); err != nil {
-		return err
+// checkCMData validates the data against the catalogue of the known keys for the installed version of Knative
+func checkCMData(ksCR *common.KnativeOperatorCR, component string, cmsCMDFlags common.CMsFlags, data map[string]string) ([]string, error) {
+	state, err := ksCR.GetKnativeCRState(component, cmsCMDFlags.Namespace)
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	version := ""
+	if state != nil {
+		version = state.Version
+	}
+	return validateCMData(component, cmsCMDFlags.CMName, version, data, cmsCMDFlags.Force)
+}
+
+// validateCMData returns the invalid values in the data as an error, or as warnings with force. The unknown keys are
+// always returned as warnings. The data of a ConfigMap, which is not in the catalogue, is not validated.
+func validateCMData(component, cmName, version string, data map[string]string, force bool) ([]string, error) {
+	catalogue, err := common.GetConfigMapCatalogue()
+	if err != nil {
+		return nil, err
+	}
+	entries := common.FindConfigMapEntries(catalogue, component, cmName)
+	if len(entries) == 0 {
+		return []string{fmt.Sprintf("The ConfigMap %s is not in the catalogue of Knative %s, so its data is not validated.",
+			cmName, component)}, nil
+	}
+	problems, warnings := common.CheckConfigMapData(entries[0], version, data)
+	if len(problems) == 0 || force {
+		return append(problems, warnings...), nil
+	}
+	return warnings, fmt.Errorf("%s\nPlease fix the data of the ConfigMap %s, or use --force to configure it anyway.",
+		strings.Join(problems, "\n"), cmName)
+}
+
+// getCMDataFromFile returns the data of the ConfigMap in the file, checking that the file is for the same ConfigMap
+func getCMDataFromFile(cmsCMDFlags common.CMsFlags) (map[string]string, error) {
+	name, data, err := readCMDataFromFile(cmsCMDFlags.FromFile)
+	if err != nil {
+		return nil, err
+	}
+	if name != "" && name != cmsCMDFlags.CMName && name != common.AddOrRemovePrefix(cmsCMDFlags.CMName, common.ConfigMapPrefix) {
+		return nil, fmt.Errorf("The name %s of the ConfigMap in the file does not match the name %s of the ConfigMap.", name, cmsCMDFlags.CMName)
+	}
+	return data, nil
+}
+
+// importCMs writes the data of the ConfigMap in the file into spec.config of the custom resource
+func importCMs(ksCR *common.KnativeOperatorCR, cmsCMDFlags common.CMsFlags, component string, data map[string]string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cmData, err := ksCR.GetConfigMaps(component, cmsCMDFlags.Namespace)
+		if err != nil {
+			return err
+		}
+		cmData = importCMData(cmData, cmsCMDFlags.CMName, data, cmsCMDFlags.Mode)
+		return ksCR.UpdateConfigMaps(component, cmsCMDFlags.Namespace, cmData)
+	})
+}
+
+// readCMDataFromFile returns the name and the data of the ConfigMap in the file. The file is either a ConfigMap manifest,
+// or a YAML file with key value pairs, which has no name.
+func readCMDataFromFile(path string) (string, map[string]string, error) {
+	content, err := common.ReadFile(path)
+	if err != nil {
+		return "", nil, err
+	}
+	jsonData, err := yaml.YAMLToJSON([]byte(content))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to parse the file %s: %w", path, err)
+	}
+	fields := map[string]interface{}{}
+	decoder := json.NewDecoder(bytes.NewReader(jsonData))
+	// Keep the numbers as they are written in the file
+	decoder.UseNumber()
+	if err = decoder.Decode(&fields); err != nil {
+		return "", nil, fmt.Errorf("failed to parse the file %s: %w", path, err)
+	}
+
+	name := ""
+	if kind, _ := fields["kind"].(string); kind == "ConfigMap" {
+		if metadata, ok := fields["metadata"].(map[string]interface{}); ok {
+			name, _ = metadata["name"].(string)
+		}
+		fields, _ = fields["data"].(map[string]interface{})
+	}
+	data := map[string]string{}
+	for key, value := range fields {
+		switch v := value.(type) {
+		case string:
+			data[key] = v
+		case json.Number, bool:
+			data[key] = fmt.Sprint(v)
+		default:
+			return "", nil, fmt.Errorf("The value of the key %s in the file %s should be a string.", key, path)
+		}
+	}
+	return name, data, nil
+}
+
+// importCMData writes the data into the ConfigMap named cmName in cmData. The ConfigMap can be saved with or without
+// the prefix config- in cmData, and the existing one is updated in the merge mode. Both are dropped in the replace mode.
+func importCMData(cmData base.ConfigMapData, cmName string, data map[string]string, mode string) base.ConfigMapData {
+	if cmData == nil {
+		cmData = base.ConfigMapData{}
+	}
+	altName := common.AddOrRemovePrefix(cmName, common.ConfigMapPrefix)
+	if strings.EqualFold(mode, replaceMode) {
+		delete(cmData, cmName)
+		delete(cmData, altName)
+	}
+
+	name := cmName
+	if _, ok := cmData[name]; !ok {
+		if _, ok := cmData[altName]; ok {
+			name = altName
+		}
+	}
+	if cmData[name] == nil {
+		cmData[name] = map[string]string{}
+	}
+	for key, value := range data {
+		cmData[name][key] = value
+	}
+	return cmData
 }
 
 func getOverlayYamlContentCM(cmsCMDFlags common.CMsFlags) string {
@@ -136,7 +331,8 @@ func getYamlValuesContentCMs(cmsCMDFlags common.CMsFlags) string {
 	contentArray = append(contentArray, header)
 	namespace := fmt.Sprintf("namespace: %s", cmsCMDFlags.Namespace)
 	contentArray = append(contentArray, namespace)
-	value := fmt.Sprintf("value: %s", cmsCMDFlags.Value)
-	contentArray = append(contentArray, value)
+	// Quote the value, so that it is always a string, even if it is a number, a boolean or has multiple lines
+	value, _ := json.Marshal(cmsCMDFlags.Value)
+	contentArray = append(contentArray, fmt.Sprintf("value: %s", value))
 	return strings.Join(contentArray, "\n")
 }
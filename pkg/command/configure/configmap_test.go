@@ -20,6 +20,7 @@ import (
 
 	"knative.dev/kn-plugin-operator/pkg/command/common"
 	"knative.dev/kn-plugin-operator/pkg/command/testingUtil"
+	"knative.dev/operator/pkg/apis/operator/base"
 )
 
 func TestValidateCMsFlags(t *testing.T) {
@@ -93,6 +94,55 @@ func TestValidateCMsFlags(t *testing.T) {
 			CMName:    "eventing-controller",
 		},
 		expectedResult: fmt.Errorf("You need to specify the value in the ConfigMap data."),
+	}, {
+		name: "Knative Serving with multiple key value pairs",
+		cmsCMDFlags: common.CMsFlags{
+			Set:       []string{"enable-scale-to-zero=false", "container-concurrency-target-default=50"},
+			Component: "serving",
+			Namespace: "test-serving",
+			CMName:    "config-autoscaler",
+		},
+		expectedResult: nil,
+	}, {
+		name: "Knative Serving with the key and multiple key value pairs",
+		cmsCMDFlags: common.CMsFlags{
+			Key:       "test-key",
+			Set:       []string{"enable-scale-to-zero=false"},
+			Component: "serving",
+			Namespace: "test-serving",
+			CMName:    "config-autoscaler",
+		},
+		expectedResult: fmt.Errorf("You need to specify the value in the ConfigMap data."),
+	}, {
+		name: "Knative Serving with the file",
+		cmsCMDFlags: common.CMsFlags{
+			FromFile:  "testdata/config-autoscaler.yaml",
+			Mode:      "replace",
+			Component: "serving",
+			Namespace: "test-serving",
+			CMName:    "config-autoscaler",
+		},
+		expectedResult: nil,
+	}, {
+		name: "Knative Serving with the file and the key",
+		cmsCMDFlags: common.CMsFlags{
+			FromFile:  "testdata/config-autoscaler.yaml",
+			Key:       "test-key",
+			Component: "serving",
+			Namespace: "test-serving",
+			CMName:    "config-autoscaler",
+		},
+		expectedResult: fmt.Errorf("You cannot specify --from-file together with --key, --value, --set or --set-file."),
+	}, {
+		name: "Knative Serving with the file and invalid mode",
+		cmsCMDFlags: common.CMsFlags{
+			FromFile:  "testdata/config-autoscaler.yaml",
+			Mode:      "overwrite",
+			Component: "serving",
+			Namespace: "test-serving",
+			CMName:    "config-autoscaler",
+		},
+		expectedResult: fmt.Errorf("You need to specify the mode for --from-file: merge or replace."),
 	}} {
 		t.Run(tt.name, func(t *testing.T) {
 			result := validateCMsFlags(tt.cmsCMDFlags)
@@ -191,7 +241,7 @@ func TestGetYamlValuesContentCMs(t *testing.T) {
 		expectedResult: `#@data/values
 ---
 namespace: test-eventing
-value: test-value`,
+value: "test-value"`,
 	}, {
 		name: "Knative Serving",
 		cmsCMDFlags: common.CMsFlags{
@@ -204,7 +254,7 @@ value: test-value`,
 		expectedResult: `#@data/values
 ---
 namespace: test-serving
-value: test-value`,
+value: "test-value"`,
 	}} {
 		t.Run(tt.name, func(t *testing.T) {
 			result := getYamlValuesContentCMs(tt.cmsCMDFlags)
@@ -212,3 +262,156 @@ value: test-value`,
 		})
 	}
 }
+
+func TestReadCMDataFromFile(t *testing.T) {
+	for _, tt := range []struct {
+		name         string
+		path         string
+		expectedName string
+		expectedData map[string]string
+	}{{
+		name:         "ConfigMap manifest",
+		path:         "testdata/config-autoscaler.yaml",
+		expectedName: "config-autoscaler",
+		expectedData: map[string]string{
+			"enable-scale-to-zero":                 "false",
+			"container-concurrency-target-default": "50",
+		},
+	}, {
+		name:         "Key value pairs",
+		path:         "testdata/autoscaler.yaml",
+		expectedName: "",
+		expectedData: map[string]string{
+			"enable-scale-to-zero":                 "false",
+			"container-concurrency-target-default": "50",
+			"scale-to-zero-grace-period":           "30s",
+		},
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			name, data, err := readCMDataFromFile(tt.path)
+			testingUtil.AssertEqual(t, err, nil)
+			testingUtil.AssertEqual(t, name, tt.expectedName)
+			testingUtil.AssertDeepEqual(t, data, tt.expectedData)
+		})
+	}
+}
+
+func TestImportCMData(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		cmData         base.ConfigMapData
+		cmName         string
+		mode           string
+		expectedResult base.ConfigMapData
+	}{{
+		name:   "Merge into no ConfigMap",
+		cmData: nil,
+		cmName: "config-autoscaler",
+		mode:   "merge",
+		expectedResult: base.ConfigMapData{
+			"config-autoscaler": {"enable-scale-to-zero": "false"},
+		},
+	}, {
+		name: "Merge into the ConfigMap without the prefix",
+		cmData: base.ConfigMapData{
+			"autoscaler": {"enable-scale-to-zero": "true", "max-scale": "10"},
+		},
+		cmName: "config-autoscaler",
+		mode:   "merge",
+		expectedResult: base.ConfigMapData{
+			"autoscaler": {"enable-scale-to-zero": "false", "max-scale": "10"},
+		},
+	}, {
+		name: "Replace the ConfigMaps with and without the prefix",
+		cmData: base.ConfigMapData{
+			"autoscaler":        {"max-scale": "10"},
+			"config-autoscaler": {"enable-scale-to-zero": "true", "min-scale": "1"},
+			"config-network":    {"ingress-class": "kourier.ingress.networking.knative.dev"},
+		},
+		cmName: "config-autoscaler",
+		mode:   "replace",
+		expectedResult: base.ConfigMapData{
+			"config-autoscaler": {"enable-scale-to-zero": "false"},
+			"config-network":    {"ingress-class": "kourier.ingress.networking.knative.dev"},
+		},
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := importCMData(tt.cmData, tt.cmName, map[string]string{"enable-scale-to-zero": "false"}, tt.mode)
+			testingUtil.AssertDeepEqual(t, result, tt.expectedResult)
+		})
+	}
+}
+
+func TestValidateCMData(t *testing.T) {
+	for _, tt := range []struct {
+		name             string
+		component        string
+		cmName           string
+		data             map[string]string
+		force            bool
+		expectedWarnings []string
+		expectedErr      error
+	}{{
+		name:             "Known keys",
+		component:        "serving",
+		cmName:           "config-autoscaler",
+		data:             map[string]string{"enable-scale-to-zero": "false", "stable-window": "2m"},
+		expectedWarnings: []string{},
+	}, {
+		name:      "Unknown key",
+		component: "serving",
+		cmName:    "autoscaler",
+		data:      map[string]string{"enable-scale-to-zer0": "false"},
+		expectedWarnings: []string{
+			"The key enable-scale-to-zer0 is unknown in the ConfigMap config-autoscaler. Did you mean enable-scale-to-zero?",
+		},
+	}, {
+		name:      "Feature flags in any case",
+		component: "serving",
+		cmName:    "config-features",
+		data: map[string]string{
+			"kubernetes.podspec-affinity":      "allowed",
+			"kubernetes.podspec-tolerations":   "Enabled",
+			"kubernetes.podspec-schedulername": "enabled",
+		},
+		expectedWarnings: []string{},
+	}, {
+		name:      "Invalid value",
+		component: "serving",
+		cmName:    "config-autoscaler",
+		data:      map[string]string{"stable-window": "60", "stable-windw": "60s"},
+		expectedWarnings: []string{
+			"The key stable-windw is unknown in the ConfigMap config-autoscaler. Did you mean stable-window?",
+		},
+		expectedErr: fmt.Errorf("The value 60 of the key stable-window should be of the type duration.\n" +
+			"Please fix the data of the ConfigMap config-autoscaler, or use --force to configure it anyway."),
+	}, {
+		name:      "Invalid value with force",
+		component: "serving",
+		cmName:    "config-autoscaler",
+		data:      map[string]string{"stable-window": "60"},
+		force:     true,
+		expectedWarnings: []string{
+			"The value 60 of the key stable-window should be of the type duration.",
+		},
+	}, {
+		name:      "ConfigMap not in the catalogue",
+		component: "eventing",
+		cmName:    "config-autoscaler",
+		data:      map[string]string{"test-key": "test-value"},
+		expectedWarnings: []string{
+			"The ConfigMap config-autoscaler is not in the catalogue of Knative eventing, so its data is not validated.",
+		},
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings, err := validateCMData(tt.component, tt.cmName, "", tt.data, tt.force)
+			if tt.expectedErr == nil {
+				testingUtil.AssertEqual(t, err, nil)
+				testingUtil.AssertDeepEqual(t, warnings, tt.expectedWarnings)
+			} else {
+				testingUtil.AssertEqual(t, err.Error(), tt.expectedErr.Error())
+				testingUtil.AssertDeepEqual(t, warnings, tt.expectedWarnings)
+			}
+		})
+	}
+}
@@ -43,6 +43,21 @@ func NewConfigureCommand(p *pkg.OperatorParams) *cobra.Command {
 	configureCmd.AddCommand(newAnnotationCommand(p))
 	configureCmd.AddCommand(newNodeSelectorCommand(p))
 	configureCmd.AddCommand(newSelectorCommand(p))
+	configureCmd.AddCommand(newAffinityCommand(p))
+	configureCmd.AddCommand(newTopologySpreadCommand(p))
+	configureCmd.AddCommand(newProbesCommand(p))
+	configureCmd.AddCommand(newPDBCommand(p))
+	configureCmd.AddCommand(newHostNetworkCommand(p))
+	configureCmd.AddCommand(newNamespaceLabelCommand(p))
+	configureCmd.AddCommand(newNamespaceAnnotationCommand(p))
+	configureCmd.AddCommand(newRegistryCommand(p))
+	configureCmd.AddCommand(newImagePullSecretCommand(p))
+	configureCmd.AddCommand(newCustomCertsCommand(p))
+	configureCmd.AddCommand(newIngressCommand(p))
+	configureCmd.AddCommand(newEventingCommand(p))
+	configureCmd.AddCommand(newVersionCommand(p))
+	configureCmd.AddCommand(newClusterProfileCommand(p))
+	configureCmd.AddCommand(newProfileCommand(p))
 
 	return configureCmd
 }
@@ -0,0 +1,154 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configure
+
+import (
+	_ "embed"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc" // from https://github.com/kubernetes/client-go/issues/345
+
+	"knative.dev/kn-plugin-operator/pkg"
+	"knative.dev/kn-plugin-operator/pkg/command/common"
+)
+
+//go:embed overlay/ks_custom_certs.yaml
+var servingCustomCertsOverlay string
+
+type CustomCertsFlags struct {
+	File      string
+	Type      string
+	Name      string
+	Namespace string
+}
+
+var customCertsCMDFlags CustomCertsFlags
+
+// newCustomCertsCommand represents the configure commands to configure the custom CA certificates for the Knative Serving controller
+func newCustomCertsCommand(p *pkg.OperatorParams) *cobra.Command {
+	var configureCustomCertsCmd = &cobra.Command{
+		Use:   "custom-certs",
+		Short: "Configure the custom CA certificates for the Knative Serving controller",
+		Example: `
+  # Configure the CA certificates in ca.pem for the Knative Serving controller with the Secret corp-ca
+  kn operator configure custom-certs --from-file ca.pem --type Secret --name corp-ca --namespace knative-serving`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateCustomCertsFlags(customCertsCMDFlags); err != nil {
+				return err
+			}
+
+			err := configureCustomCerts(customCertsCMDFlags, p)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "The custom CA certificates have been configured with the %s %s in the namespace '%s'.\n",
+				customCertsCMDFlags.Type, customCertsCMDFlags.Name, customCertsCMDFlags.Namespace)
+			return nil
+		},
+	}
+
+	configureCustomCertsCmd.Flags().StringVar(&customCertsCMDFlags.File, "from-file", "", "The local PEM file containing the CA certificates")
+	configureCustomCertsCmd.Flags().StringVar(&customCertsCMDFlags.Type, "type", common.SecretType, "The type of the resource to save the CA certificates: Secret or ConfigMap")
+	configureCustomCertsCmd.Flags().StringVar(&customCertsCMDFlags.Name, "name", "", "The name of the Secret or the ConfigMap to save the CA certificates. An existing one has to be created by the plugin")
+	configureCustomCertsCmd.Flags().StringVarP(&customCertsCMDFlags.Namespace, "namespace", "n", "", "The namespace of Knative Serving")
+
+	return configureCustomCertsCmd
+}
+
+func validateCustomCertsFlags(customCertsCMDFlags CustomCertsFlags) error {
+	if customCertsCMDFlags.File == "" {
+		return fmt.Errorf("You need to specify the PEM file of the CA certificates.")
+	}
+	if customCertsCMDFlags.Type != common.SecretType && customCertsCMDFlags.Type != common.ConfigMapType {
+		return fmt.Errorf("You need to specify the type to one of the following values: Secret or ConfigMap.")
+	}
+	if customCertsCMDFlags.Name == "" {
+		return fmt.Errorf("You need to specify the name of the Secret or the ConfigMap.")
+	}
+	if customCertsCMDFlags.Namespace == "" {
+		return fmt.Errorf("You need to specify the namespace.")
+	}
+	return nil
+}
+
+// readCertificates reads the PEM file and makes sure that it contains at least one certificate
+func readCertificates(file string) ([]byte, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	rest := data
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return nil, fmt.Errorf("The file %s does not contain any PEM encoded certificate.", file)
+		}
+		if block.Type == "CERTIFICATE" {
+			return data, nil
+		}
+	}
+}
+
+func configureCustomCerts(customCertsCMDFlags CustomCertsFlags, p *pkg.OperatorParams) error {
+	data, err := readCertificates(customCertsCMDFlags.File)
+	if err != nil {
+		return err
+	}
+
+	client, err := p.NewKubeClient()
+	if err != nil {
+		return fmt.Errorf("cannot get source cluster kube config, please use --kubeconfig or export environment variable KUBECONFIG to set\n")
+	}
+	kubeResource := common.KubeResource{
+		KubeClient: client,
+	}
+	// The Secret or the ConfigMap has to be in the namespace of Knative Serving, where the controller runs
+	if err = kubeResource.CreateOrUpdateManagedResource(customCertsCMDFlags.Type, customCertsCMDFlags.Name, customCertsCMDFlags.Namespace,
+		filepath.Base(customCertsCMDFlags.File), data); err != nil {
+		return err
+	}
+
+	yamlTemplateString, err := common.GenerateOperatorCRString(common.ServingComponent, customCertsCMDFlags.Namespace, p)
+	if err != nil {
+		return err
+	}
+
+	valuesYaml := getYamlValuesContentCustomCerts(customCertsCMDFlags)
+	if err := common.ApplyManifests(yamlTemplateString, servingCustomCertsOverlay, valuesYaml, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+func getYamlValuesContentCustomCerts(customCertsCMDFlags CustomCertsFlags) string {
+	contentArray := []string{}
+	header := "#@data/values\n---"
+	contentArray = append(contentArray, header)
+	namespace := fmt.Sprintf("namespace: %s", customCertsCMDFlags.Namespace)
+	contentArray = append(contentArray, namespace)
+	certsType := fmt.Sprintf("type: %s", customCertsCMDFlags.Type)
+	contentArray = append(contentArray, certsType)
+	name := fmt.Sprintf("name: %s", customCertsCMDFlags.Name)
+	contentArray = append(contentArray, name)
+	return strings.Join(contentArray, "\n")
+}
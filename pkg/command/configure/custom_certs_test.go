@@ -0,0 +1,120 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configure
+
+import (
+	"fmt"
+	"testing"
+
+	"knative.dev/kn-plugin-operator/pkg/command/testingUtil"
+)
+
+func TestValidateCustomCertsFlags(t *testing.T) {
+	for _, tt := range []struct {
+		name                string
+		customCertsCMDFlags CustomCertsFlags
+		expectedResult      error
+	}{{
+		name: "Custom certs flags with all the parameters",
+		customCertsCMDFlags: CustomCertsFlags{
+			File:      "testdata/ca.pem",
+			Type:      "Secret",
+			Name:      "corp-ca",
+			Namespace: "test-serving",
+		},
+		expectedResult: nil,
+	}, {
+		name: "Custom certs flags without file",
+		customCertsCMDFlags: CustomCertsFlags{
+			Type:      "Secret",
+			Name:      "corp-ca",
+			Namespace: "test-serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify the PEM file of the CA certificates."),
+	}, {
+		name: "Custom certs flags with invalid type",
+		customCertsCMDFlags: CustomCertsFlags{
+			File:      "testdata/ca.pem",
+			Type:      "secret",
+			Name:      "corp-ca",
+			Namespace: "test-serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify the type to one of the following values: Secret or ConfigMap."),
+	}, {
+		name: "Custom certs flags without name",
+		customCertsCMDFlags: CustomCertsFlags{
+			File:      "testdata/ca.pem",
+			Type:      "ConfigMap",
+			Namespace: "test-serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify the name of the Secret or the ConfigMap."),
+	}, {
+		name: "Custom certs flags without namespace",
+		customCertsCMDFlags: CustomCertsFlags{
+			File: "testdata/ca.pem",
+			Type: "ConfigMap",
+			Name: "corp-ca",
+		},
+		expectedResult: fmt.Errorf("You need to specify the namespace."),
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := validateCustomCertsFlags(tt.customCertsCMDFlags)
+			if tt.expectedResult == nil {
+				testingUtil.AssertEqual(t, result, nil)
+			} else {
+				testingUtil.AssertEqual(t, result.Error(), tt.expectedResult.Error())
+			}
+		})
+	}
+}
+
+func TestReadCertificates(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		file        string
+		expectedErr bool
+	}{{
+		name: "PEM file with the certificate",
+		file: "testdata/ca.pem",
+	}, {
+		name:        "File without any certificate",
+		file:        "testdata/affinity.yaml",
+		expectedErr: true,
+	}, {
+		name:        "Missing file",
+		file:        "testdata/missing.pem",
+		expectedErr: true,
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := readCertificates(tt.file)
+			testingUtil.AssertEqual(t, err != nil, tt.expectedErr)
+		})
+	}
+}
+
+func TestGetYamlValuesContentCustomCerts(t *testing.T) {
+	customCertsCMDFlags := CustomCertsFlags{
+		File:      "testdata/ca.pem",
+		Type:      "Secret",
+		Name:      "corp-ca",
+		Namespace: "test-serving",
+	}
+	expectedResult := `#@data/values
+---
+namespace: test-serving
+type: Secret
+name: corp-ca`
+	testingUtil.AssertEqual(t, getYamlValuesContentCustomCerts(customCertsCMDFlags), expectedResult)
+}
@@ -16,14 +16,17 @@ package configure
 
 import (
 	_ "embed"
+	"encoding/json"
 	"fmt"
 	"strings"
 
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc" // from https://github.com/kubernetes/client-go/issues/345
 
 	"knative.dev/kn-plugin-operator/pkg"
 	"knative.dev/kn-plugin-operator/pkg/command/common"
+	"knative.dev/operator/pkg/apis/operator/base"
 )
 
 //go:embed overlay/ks_envvar.yaml
@@ -35,10 +38,18 @@ var eventingEnvVarOverlay string
 type EnvVarFlags struct {
 	EnvName       string
 	EnvValue      string
+	FromSecret    string
+	FromConfigMap string
+	FromField     string
 	Component     string
 	Namespace     string
 	DeployName    string
 	ContainerName string
+
+	AllDeployments bool
+	Set            []string
+	SetFile        []string
+	List           bool
 }
 
 var envVarFlags EnvVarFlags
@@ -48,47 +59,101 @@ func newEnvVarCommand(p *pkg.OperatorParams) *cobra.Command {
 	var configureImagesCmd = &cobra.Command{
 		Use:   "envvars",
 		Short: "Configure the env vars for Knative",
+		Long: `Configure the env vars for Knative.
+
+The value of the environment variable is either a literal value specified by --value, or a reference to a key of a
+Secret with --from-secret, a key of a ConfigMap with --from-configmap, or a field of the pod with --from-field. Use the
+references for sensitive values like credentials, so that they are not written in plain text in the custom resource.
+
+With --list, the configured env vars are printed, optionally only for the deployment and the container specified by
+--deployName and --container. The env vars referencing a Secret are printed as <secret name:key>, never with a value.`,
 		Example: `
   # Configure the env vars for Knative
-  kn operator configure envvars --component eventing --deployName eventing-controller --container eventing-controller --name key --value value --namespace knative-eventing`,
+  kn operator configure envvars --component eventing --deployName eventing-controller --container eventing-controller --name key --value value --namespace knative-eventing
+  # Configure the env var from the key token of the Secret api-credentials
+  kn operator configure envvars --component serving --deployName controller --container controller --name API_TOKEN --from-secret api-credentials:token --namespace knative-serving
+  # Configure the env var from the namespace of the pod
+  kn operator configure envvars --component serving --deployName controller --container controller --name POD_NAMESPACE --from-field metadata.namespace --namespace knative-serving
+  # Configure the env var for all the containers of all the deployments of Knative Serving
+  kn operator configure envvars --component serving --all-deployments --name HTTP_PROXY --value http://proxy:3128 --namespace knative-serving
+  # Configure multiple env vars with literal values at once
+  kn operator configure envvars --component serving --deployName controller --container controller --set HTTP_PROXY=http://proxy:3128 --set NO_PROXY=.cluster.local --namespace knative-serving
+  # List the env vars configured for Knative Serving
+  kn operator configure envvars --component serving --list --namespace knative-serving`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := validateEnvVarsFlags(envVarFlags); err != nil {
 				return err
 			}
 
+			if envVarFlags.List {
+				return listEnvVars(cmd, envVarFlags, p)
+			}
+
 			err := configureEnvVars(envVarFlags, p)
 			if err != nil {
 				return err
 			}
 
-			fmt.Fprintf(cmd.OutOrStdout(), "The specified images has been configured.\n")
+			fmt.Fprintf(cmd.OutOrStdout(), "The specified env vars have been configured in the namespace '%s'.\n", envVarFlags.Namespace)
 			return nil
 		},
 	}
 
 	configureImagesCmd.Flags().StringVar(&envVarFlags.EnvName, "name", "", "The name for the environment variable")
 	configureImagesCmd.Flags().StringVar(&envVarFlags.EnvValue, "value", "", "The value for the environment variable")
+	configureImagesCmd.Flags().StringVar(&envVarFlags.FromSecret, "from-secret", "", "The key of the Secret to get the value from, in the format of name:key")
+	configureImagesCmd.Flags().StringVar(&envVarFlags.FromConfigMap, "from-configmap", "", "The key of the ConfigMap to get the value from, in the format of name:key")
+	configureImagesCmd.Flags().StringVar(&envVarFlags.FromField, "from-field", "", "The field of the pod to get the value from, e.g. metadata.namespace")
 	configureImagesCmd.Flags().StringVar(&envVarFlags.DeployName, "deployName", "", "The flag to specify the deployment name")
 	configureImagesCmd.Flags().StringVarP(&envVarFlags.Component, "component", "c", "", "The flag to specify the component name")
 	configureImagesCmd.Flags().StringVarP(&envVarFlags.Namespace, "namespace", "n", "", "The namespace of the Knative Operator or the Knative component")
 	configureImagesCmd.Flags().StringVar(&envVarFlags.ContainerName, "container", "", "The name of the container")
+	configureImagesCmd.Flags().StringArrayVar(&envVarFlags.Set, "set", []string{}, "The name and the literal value of the environment variable in the format of name=value. It can be specified multiple times.")
+	configureImagesCmd.Flags().StringArrayVar(&envVarFlags.SetFile, "set-file", []string{}, "The name of the environment variable and the path of the file to read the value from, in the format of name=path. It can be specified multiple times.")
+	configureImagesCmd.Flags().BoolVar(&envVarFlags.AllDeployments, "all-deployments", false, allDeploymentsUsage+". If the container is not specified, all the containers are configured.")
+	configureImagesCmd.Flags().BoolVar(&envVarFlags.List, "list", false, "The flag to list the configured env vars, with the values from Secrets masked")
 
 	return configureImagesCmd
 }
 
 func validateEnvVarsFlags(envVarFlags EnvVarFlags) error {
-	if envVarFlags.EnvName == "" {
+	if envVarFlags.List {
+		return validateListEnvVarsFlags(envVarFlags)
+	}
+	batch := len(envVarFlags.Set) > 0 || len(envVarFlags.SetFile) > 0
+	count := 0
+	for _, source := range []string{envVarFlags.EnvValue, envVarFlags.FromSecret, envVarFlags.FromConfigMap, envVarFlags.FromField} {
+		if source != "" {
+			count++
+		}
+	}
+	if envVarFlags.EnvName == "" && (!batch || count > 0) {
 		return fmt.Errorf("You need to specify the name for the environment variable.")
 	}
-	if envVarFlags.EnvValue == "" {
-		return fmt.Errorf("You need to specify the value for the environment variable.")
+	if envVarFlags.EnvName != "" {
+		if count == 0 {
+			return fmt.Errorf("You need to specify the value for the environment variable.")
+		}
+		if count > 1 {
+			return fmt.Errorf("You can only specify one of --value, --from-secret, --from-configmap and --from-field.")
+		}
+		if _, err := getEnvVar(envVarFlags); err != nil {
+			return err
+		}
+	}
+	if err := common.ValidateSetFlags(envVarFlags.Set, envVarFlags.SetFile); err != nil {
+		return err
+	}
+
+	if err := validateAllDeploymentsFlags(envVarFlags.AllDeployments, envVarFlags.DeployName); err != nil {
+		return err
 	}
 
-	if envVarFlags.DeployName == "" {
+	if envVarFlags.DeployName == "" && !envVarFlags.AllDeployments {
 		return fmt.Errorf("You need to specify the name for the deployment resource.")
 	}
 
-	if envVarFlags.ContainerName == "" {
+	if envVarFlags.ContainerName == "" && !envVarFlags.AllDeployments {
 		return fmt.Errorf("You need to specify the name for the container.")
 	}
 
@@ -101,6 +166,84 @@ func validateEnvVarsFlags(envVarFlags EnvVarFlags) error {
 	return nil
 }
 
+func validateListEnvVarsFlags(envVarFlags EnvVarFlags) error {
+	if envVarFlags.EnvName != "" || envVarFlags.EnvValue != "" || envVarFlags.FromSecret != "" || envVarFlags.FromConfigMap != "" ||
+		envVarFlags.FromField != "" || len(envVarFlags.Set) > 0 || len(envVarFlags.SetFile) > 0 || envVarFlags.AllDeployments {
+		return fmt.Errorf("You can only specify --deployName and --container together with --list.")
+	}
+	if envVarFlags.Namespace == "" {
+		return fmt.Errorf("You need to specify the namespace.")
+	}
+	if envVarFlags.Component != "" && !strings.EqualFold(envVarFlags.Component, common.ServingComponent) && !strings.EqualFold(envVarFlags.Component, common.EventingComponent) {
+		return fmt.Errorf("You need to specify the component for Knative: serving or eventing.")
+	}
+	return nil
+}
+
+func listEnvVars(cmd *cobra.Command, envVarFlags EnvVarFlags, p *pkg.OperatorParams) error {
+	component := common.ServingComponent
+	if strings.EqualFold(envVarFlags.Component, common.EventingComponent) {
+		component = common.EventingComponent
+	}
+	ksCR, err := common.GetKnativeOperatorCR(p)
+	if err != nil {
+		return err
+	}
+	commonSpec, err := ksCR.GetCommonSpec(component, envVarFlags.Namespace)
+	if err != nil {
+		return err
+	}
+
+	lines := formatEnvVars(commonSpec.GetWorkloadOverrides(), envVarFlags.DeployName, envVarFlags.ContainerName)
+	if len(lines) == 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "No env var is configured for Knative %s in the namespace '%s'.\n", component, envVarFlags.Namespace)
+		return nil
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "The env vars configured for Knative %s in the namespace '%s':\n%s\n", component,
+		envVarFlags.Namespace, strings.Join(lines, common.LineWrapper))
+	return nil
+}
+
+// formatEnvVars formats the env vars of the workloads into lines grouped by the deployment and the container. Only the
+// deployment and the container with the names are formatted, if the names are not empty.
+func formatEnvVars(workloadOverrides []base.WorkloadOverride, deployName, containerName string) []string {
+	lines := []string{}
+	for _, workload := range workloadOverrides {
+		if deployName != "" && workload.Name != deployName {
+			continue
+		}
+		for _, env := range workload.Env {
+			if (containerName != "" && env.Container != containerName) || len(env.EnvVars) == 0 {
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("  %s/%s:", workload.Name, env.Container))
+			for _, envVar := range env.EnvVars {
+				lines = append(lines, fmt.Sprintf("    %s=%s", envVar.Name, formatEnvVarValue(envVar)))
+			}
+		}
+	}
+	return lines
+}
+
+// formatEnvVarValue returns the literal value of the env var, or the source of the value referenced by the env var.
+// The value of a Secret is never returned.
+func formatEnvVarValue(envVar corev1.EnvVar) string {
+	if envVar.ValueFrom == nil {
+		return envVar.Value
+	}
+	switch {
+	case envVar.ValueFrom.SecretKeyRef != nil:
+		return fmt.Sprintf("<secret %s:%s>", envVar.ValueFrom.SecretKeyRef.Name, envVar.ValueFrom.SecretKeyRef.Key)
+	case envVar.ValueFrom.ConfigMapKeyRef != nil:
+		return fmt.Sprintf("<configmap %s:%s>", envVar.ValueFrom.ConfigMapKeyRef.Name, envVar.ValueFrom.ConfigMapKeyRef.Key)
+	case envVar.ValueFrom.FieldRef != nil:
+		return fmt.Sprintf("<field %s>", envVar.ValueFrom.FieldRef.FieldPath)
+	case envVar.ValueFrom.ResourceFieldRef != nil:
+		return fmt.Sprintf("<resource %s>", envVar.ValueFrom.ResourceFieldRef.Resource)
+	}
+	return ""
+}
+
 func configureEnvVars(envVarFlags EnvVarFlags, p *pkg.OperatorParams) error {
 	component := common.ServingComponent
 	if strings.EqualFold(envVarFlags.Component, common.EventingComponent) {
@@ -111,12 +254,50 @@ func configureEnvVars(envVarFlags EnvVarFlags, p *pkg.OperatorParams) error {
 		return err
 	}
 
-	overlayContent := getOverlayYamlContentEnvvar(envVarFlags)
-	valuesYaml := getYamlValuesContentEnvvars(envVarFlags)
-	if err := common.ApplyManifests(yamlTemplateString, overlayContent, valuesYaml, p); err != nil {
+	envVars, err := getEnvVarsFlags(envVarFlags)
+	if err != nil {
 		return err
 	}
-	return nil
+	targets, err := getTargetWorkloads(envVarFlags.AllDeployments, component, envVarFlags.Namespace,
+		envVarFlags.DeployName, envVarFlags.ContainerName, p)
+	if err != nil {
+		return err
+	}
+	overlays := []common.OverlayValues{}
+	for _, target := range targets {
+		for _, flags := range envVars {
+			flags.DeployName = target.DeployName
+			flags.ContainerName = target.ContainerName
+			valuesYaml, err := getYamlValuesContentEnvvars(flags)
+			if err != nil {
+				return err
+			}
+			overlays = append(overlays, common.OverlayValues{Overlay: getOverlayYamlContentEnvvar(flags), Values: valuesYaml})
+		}
+	}
+	return common.ApplyManifestsWithOverlays(yamlTemplateString, overlays, p)
+}
+
+// getEnvVarsFlags returns the flags for the environment variable specified by --name, if any, followed by the flags
+// for the environment variables with the literal values specified by --set and --set-file
+func getEnvVarsFlags(envVarFlags EnvVarFlags) ([]EnvVarFlags, error) {
+	envVars := []EnvVarFlags{}
+	if envVarFlags.EnvName != "" {
+		envVars = append(envVars, envVarFlags)
+	}
+	pairs, err := common.GetKeyValuePairs("", "", envVarFlags.Set, envVarFlags.SetFile)
+	if err != nil {
+		return nil, err
+	}
+	for _, pair := range pairs {
+		envVars = append(envVars, EnvVarFlags{
+			EnvName:   pair.Key,
+			EnvValue:  pair.Value,
+			Component: envVarFlags.Component,
+			Namespace: envVarFlags.Namespace,
+		})
+	}
+	return envVars, nil
 }
 
 func getOverlayYamlContentEnvvar(envVarFlags EnvVarFlags) string {
@@ -127,7 +308,47 @@ func getOverlayYamlContentEnvvar(envVarFlags EnvVarFlags) string {
 	return baseOverlayContent
 }
 
-func getYamlValuesContentEnvvars(envVarFlags EnvVarFlags) string {
+// getEnvVar returns the environment variable with the literal value, or the value from the Secret, the ConfigMap or
+// the field of the pod
+func getEnvVar(envVarFlags EnvVarFlags) (corev1.EnvVar, error) {
+	envVar := corev1.EnvVar{Name: envVarFlags.EnvName}
+	if envVarFlags.FromSecret != "" {
+		name, key, err := parseKeyRef(envVarFlags.FromSecret, "--from-secret")
+		if err != nil {
+			return envVar, err
+		}
+		envVar.ValueFrom = &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: name},
+			Key:                  key,
+		}}
+	} else if envVarFlags.FromConfigMap != "" {
+		name, key, err := parseKeyRef(envVarFlags.FromConfigMap, "--from-configmap")
+		if err != nil {
+			return envVar, err
+		}
+		envVar.ValueFrom = &corev1.EnvVarSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: name},
+			Key:                  key,
+		}}
+	} else if envVarFlags.FromField != "" {
+		envVar.ValueFrom = &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{
+			FieldPath: envVarFlags.FromField,
+		}}
+	} else {
+		envVar.Value = envVarFlags.EnvValue
+	}
+	return envVar, nil
+}
+
+func parseKeyRef(ref, flag string) (string, string, error) {
+	parts := strings.Split(ref, ":")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("The value of %s should be in the format of name:key.", flag)
+	}
+	return parts[0], parts[1], nil
+}
+
+func getYamlValuesContentEnvvars(envVarFlags EnvVarFlags) (string, error) {
 	contentArray := []string{}
 	header := "#@data/values\n---"
 	contentArray = append(contentArray, header)
@@ -141,10 +362,14 @@ func getYamlValuesContentEnvvars(envVarFlags EnvVarFlags) string {
 	value := fmt.Sprintf("containerName: %s", envVarFlags.ContainerName)
 	contentArray = append(contentArray, value)
 
-	envName := fmt.Sprintf("envVarName: %s", envVarFlags.EnvName)
-	contentArray = append(contentArray, envName)
-
-	envValue := fmt.Sprintf("envVarValue: %s", envVarFlags.EnvValue)
-	contentArray = append(contentArray, envValue)
-	return strings.Join(contentArray, "\n")
+	envVar, err := getEnvVar(envVarFlags)
+	if err != nil {
+		return "", err
+	}
+	envVarContent, err := json.Marshal(envVar)
+	if err != nil {
+		return "", err
+	}
+	contentArray = append(contentArray, fmt.Sprintf("envVar: %s", envVarContent))
+	return strings.Join(contentArray, "\n"), nil
 }
@@ -18,7 +18,10 @@ import (
 	"fmt"
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/kn-plugin-operator/pkg/command/common"
 	"knative.dev/kn-plugin-operator/pkg/command/testingUtil"
+	"knative.dev/operator/pkg/apis/operator/base"
 )
 
 func TestValidateEnvVarsFlags(t *testing.T) {
@@ -109,6 +112,108 @@ func TestValidateEnvVarsFlags(t *testing.T) {
 			ContainerName: "container",
 		},
 		expectedResult: fmt.Errorf("You need to specify the namespace."),
+	}, {
+		name: "Knative Serving with the value from the Secret",
+		envVarFlags: EnvVarFlags{
+			EnvName:       "API_TOKEN",
+			FromSecret:    "api-credentials:token",
+			Component:     "serving",
+			Namespace:     "test-serving",
+			DeployName:    "controller",
+			ContainerName: "container",
+		},
+		expectedResult: nil,
+	}, {
+		name: "Knative Serving with both the value and the value from the ConfigMap",
+		envVarFlags: EnvVarFlags{
+			EnvName:       "test-key",
+			EnvValue:      "test-value",
+			FromConfigMap: "config:key",
+			Component:     "serving",
+			Namespace:     "test-serving",
+			DeployName:    "controller",
+			ContainerName: "container",
+		},
+		expectedResult: fmt.Errorf("You can only specify one of --value, --from-secret, --from-configmap and --from-field."),
+	}, {
+		name: "Knative Serving with the invalid reference to the Secret",
+		envVarFlags: EnvVarFlags{
+			EnvName:       "API_TOKEN",
+			FromSecret:    "api-credentials",
+			Component:     "serving",
+			Namespace:     "test-serving",
+			DeployName:    "controller",
+			ContainerName: "container",
+		},
+		expectedResult: fmt.Errorf("The value of --from-secret should be in the format of name:key."),
+	}, {
+		name: "Knative Serving with all the deployments and no container",
+		envVarFlags: EnvVarFlags{
+			EnvName:        "HTTP_PROXY",
+			EnvValue:       "http://proxy:3128",
+			Component:      "serving",
+			Namespace:      "test-serving",
+			AllDeployments: true,
+		},
+		expectedResult: nil,
+	}, {
+		name: "Knative Serving with all the deployments and the deployment name",
+		envVarFlags: EnvVarFlags{
+			EnvName:        "HTTP_PROXY",
+			EnvValue:       "http://proxy:3128",
+			Component:      "serving",
+			Namespace:      "test-serving",
+			DeployName:     "controller",
+			AllDeployments: true,
+		},
+		expectedResult: fmt.Errorf("You cannot specify the name of the deployment together with --all-deployments."),
+	}, {
+		name: "Knative Serving with multiple env vars",
+		envVarFlags: EnvVarFlags{
+			Set:           []string{"HTTP_PROXY=http://proxy:3128", "NO_PROXY=.cluster.local"},
+			Component:     "serving",
+			Namespace:     "test-serving",
+			DeployName:    "controller",
+			ContainerName: "container",
+		},
+		expectedResult: nil,
+	}, {
+		name: "Knative Serving with the value from the Secret and no name",
+		envVarFlags: EnvVarFlags{
+			FromSecret:    "api-credentials:token",
+			Set:           []string{"HTTP_PROXY=http://proxy:3128"},
+			Component:     "serving",
+			Namespace:     "test-serving",
+			DeployName:    "controller",
+			ContainerName: "container",
+		},
+		expectedResult: fmt.Errorf("You need to specify the name for the environment variable."),
+	}, {
+		name: "Env var flags to list",
+		envVarFlags: EnvVarFlags{
+			Component:  "serving",
+			Namespace:  "test-serving",
+			DeployName: "controller",
+			List:       true,
+		},
+		expectedResult: nil,
+	}, {
+		name: "Env var flags to list with the value",
+		envVarFlags: EnvVarFlags{
+			EnvName:   "HTTP_PROXY",
+			EnvValue:  "http://proxy:3128",
+			Component: "serving",
+			Namespace: "test-serving",
+			List:      true,
+		},
+		expectedResult: fmt.Errorf("You can only specify --deployName and --container together with --list."),
+	}, {
+		name: "Env var flags to list without namespace",
+		envVarFlags: EnvVarFlags{
+			Component: "serving",
+			List:      true,
+		},
+		expectedResult: fmt.Errorf("You need to specify the namespace."),
 	}} {
 		t.Run(tt.name, func(t *testing.T) {
 			result := validateEnvVarsFlags(tt.envVarFlags)
@@ -121,6 +226,79 @@ func TestValidateEnvVarsFlags(t *testing.T) {
 	}
 }
 
+func TestFormatEnvVars(t *testing.T) {
+	workloadOverrides := []base.WorkloadOverride{{
+		Name: "controller",
+		Env: []base.EnvRequirementsOverride{{
+			Container: "controller",
+			EnvVars: []corev1.EnvVar{{
+				Name:  "HTTP_PROXY",
+				Value: "http://proxy:3128",
+			}, {
+				Name: "API_TOKEN",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "api-credentials"},
+						Key:                  "token",
+					},
+				},
+			}, {
+				Name: "LOG_LEVEL",
+				ValueFrom: &corev1.EnvVarSource{
+					ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "logging"},
+						Key:                  "level",
+					},
+				},
+			}},
+		}},
+	}, {
+		Name: "webhook",
+		Env: []base.EnvRequirementsOverride{{
+			Container: "webhook",
+			EnvVars: []corev1.EnvVar{{
+				Name: "POD_NAMESPACE",
+				ValueFrom: &corev1.EnvVarSource{
+					FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.namespace"},
+				},
+			}},
+		}},
+	}}
+
+	for _, tt := range []struct {
+		name           string
+		deployName     string
+		containerName  string
+		expectedResult []string
+	}{{
+		name: "All the env vars",
+		expectedResult: []string{
+			"  controller/controller:",
+			"    HTTP_PROXY=http://proxy:3128",
+			"    API_TOKEN=<secret api-credentials:token>",
+			"    LOG_LEVEL=<configmap logging:level>",
+			"  webhook/webhook:",
+			"    POD_NAMESPACE=<field metadata.namespace>",
+		},
+	}, {
+		name:       "Env vars of the deployment",
+		deployName: "webhook",
+		expectedResult: []string{
+			"  webhook/webhook:",
+			"    POD_NAMESPACE=<field metadata.namespace>",
+		},
+	}, {
+		name:           "Env vars of an unknown container",
+		deployName:     "controller",
+		containerName:  "queue-proxy",
+		expectedResult: []string{},
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			testingUtil.AssertDeepEqual(t, formatEnvVars(workloadOverrides, tt.deployName, tt.containerName), tt.expectedResult)
+		})
+	}
+}
+
 func TestGetOverlayYamlContentEnvvar(t *testing.T) {
 	for _, tt := range []struct {
 		name           string
@@ -149,7 +327,7 @@ metadata:
 #@overlay/match missing_ok=True
 spec:
   #@overlay/match missing_ok=True
-  deployments:
+  workloads:
   #@overlay/match by="name",missing_ok=True
   - name: #@ data.values.deployName
     #@overlay/match missing_ok=True
@@ -159,9 +337,8 @@ spec:
       #@overlay/match missing_ok=True
       envVars:
       #@overlay/match by="name",missing_ok=True
-      - name: #@ data.values.envVarName
-        #@overlay/match missing_ok=True
-        value: #@ data.values.envVarValue
+      #@overlay/replace or_add=True
+      - #@ data.values.envVar
 `,
 	}, {
 		name: "Knative Serving",
@@ -186,7 +363,7 @@ metadata:
 #@overlay/match missing_ok=True
 spec:
   #@overlay/match missing_ok=True
-  deployments:
+  workloads:
   #@overlay/match by="name",missing_ok=True
   - name: #@ data.values.deployName
     #@overlay/match missing_ok=True
@@ -196,9 +373,8 @@ spec:
       #@overlay/match missing_ok=True
       envVars:
       #@overlay/match by="name",missing_ok=True
-      - name: #@ data.values.envVarName
-        #@overlay/match missing_ok=True
-        value: #@ data.values.envVarValue
+      #@overlay/replace or_add=True
+      - #@ data.values.envVar
 `,
 	}} {
 		t.Run(tt.name, func(t *testing.T) {
@@ -228,8 +404,7 @@ func TestGetYamlValuesContentEnvvars(t *testing.T) {
 namespace: test-eventing
 deployName: eventing-controller
 containerName: container
-envVarName: test-key
-envVarValue: test-value`,
+envVar: {"name":"test-key","value":"test-value"}`,
 	}, {
 		name: "Knative Serving",
 		envVarFlags: EnvVarFlags{
@@ -245,12 +420,167 @@ envVarValue: test-value`,
 namespace: test-serving
 deployName: controller
 containerName: container
-envVarName: test-key
-envVarValue: test-value`,
+envVar: {"name":"test-key","value":"test-value"}`,
+	}, {
+		name: "Knative Serving with the value from the Secret",
+		envVarFlags: EnvVarFlags{
+			EnvName:       "API_TOKEN",
+			FromSecret:    "api-credentials:token",
+			Component:     "serving",
+			Namespace:     "test-serving",
+			DeployName:    "controller",
+			ContainerName: "container",
+		},
+		expectedResult: `#@data/values
+---
+namespace: test-serving
+deployName: controller
+containerName: container
+envVar: {"name":"API_TOKEN","valueFrom":{"secretKeyRef":{"name":"api-credentials","key":"token"}}}`,
+	}, {
+		name: "Knative Serving with the value from the ConfigMap",
+		envVarFlags: EnvVarFlags{
+			EnvName:       "HTTP_PROXY",
+			FromConfigMap: "proxy:http",
+			Component:     "serving",
+			Namespace:     "test-serving",
+			DeployName:    "controller",
+			ContainerName: "container",
+		},
+		expectedResult: `#@data/values
+---
+namespace: test-serving
+deployName: controller
+containerName: container
+envVar: {"name":"HTTP_PROXY","valueFrom":{"configMapKeyRef":{"name":"proxy","key":"http"}}}`,
+	}, {
+		name: "Knative Serving with the value from the field",
+		envVarFlags: EnvVarFlags{
+			EnvName:       "POD_NAMESPACE",
+			FromField:     "metadata.namespace",
+			Component:     "serving",
+			Namespace:     "test-serving",
+			DeployName:    "controller",
+			ContainerName: "container",
+		},
+		expectedResult: `#@data/values
+---
+namespace: test-serving
+deployName: controller
+containerName: container
+envVar: {"name":"POD_NAMESPACE","valueFrom":{"fieldRef":{"fieldPath":"metadata.namespace"}}}`,
 	}} {
 		t.Run(tt.name, func(t *testing.T) {
-			result := getYamlValuesContentEnvvars(tt.envVarFlags)
+			result, err := getYamlValuesContentEnvvars(tt.envVarFlags)
+			testingUtil.AssertEqual(t, err, nil)
 			testingUtil.AssertEqual(t, result, tt.expectedResult)
 		})
 	}
 }
+
+func TestGetEnvVarsFlags(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		envVarFlags    EnvVarFlags
+		expectedResult []EnvVarFlags
+	}{{
+		name: "Knative Serving with one env var",
+		envVarFlags: EnvVarFlags{
+			EnvName:    "API_TOKEN",
+			FromSecret: "api-credentials:token",
+			Component:  "serving",
+			Namespace:  "test-serving",
+		},
+		expectedResult: []EnvVarFlags{{
+			EnvName:    "API_TOKEN",
+			FromSecret: "api-credentials:token",
+			Component:  "serving",
+			Namespace:  "test-serving",
+		}},
+	}, {
+		name: "Knative Serving with one env var and multiple literal env vars",
+		envVarFlags: EnvVarFlags{
+			EnvName:    "API_TOKEN",
+			FromSecret: "api-credentials:token",
+			Set:        []string{"HTTP_PROXY=http://proxy:3128", "NO_PROXY=.cluster.local"},
+			Component:  "serving",
+			Namespace:  "test-serving",
+		},
+		expectedResult: []EnvVarFlags{{
+			EnvName:    "API_TOKEN",
+			FromSecret: "api-credentials:token",
+			Set:        []string{"HTTP_PROXY=http://proxy:3128", "NO_PROXY=.cluster.local"},
+			Component:  "serving",
+			Namespace:  "test-serving",
+		}, {
+			EnvName:   "HTTP_PROXY",
+			EnvValue:  "http://proxy:3128",
+			Component: "serving",
+			Namespace: "test-serving",
+		}, {
+			EnvName:   "NO_PROXY",
+			EnvValue:  ".cluster.local",
+			Component: "serving",
+			Namespace: "test-serving",
+		}},
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := getEnvVarsFlags(tt.envVarFlags)
+			testingUtil.AssertEqual(t, err, nil)
+			testingUtil.AssertDeepEqual(t, result, tt.expectedResult)
+		})
+	}
+}
+
+func TestRenderEnvVarsOverlays(t *testing.T) {
+	base := `apiVersion: operator.knative.dev/v1beta1
+kind: KnativeServing
+metadata:
+  name: knative-serving
+  namespace: test-serving
+spec:
+  workloads:
+  - name: activator
+    env:
+    - container: activator
+      envVars:
+      - name: EXISTING
+        value: existing
+`
+	overlays := []common.OverlayValues{}
+	for _, envVarFlags := range []EnvVarFlags{
+		{EnvName: "NEW", EnvValue: "new"},
+		{EnvName: "EXISTING", EnvValue: "changed"},
+		{EnvName: "OTHER", EnvValue: "other"},
+	} {
+		envVarFlags.Component = "serving"
+		envVarFlags.Namespace = "test-serving"
+		envVarFlags.DeployName = "activator"
+		envVarFlags.ContainerName = "activator"
+		valuesYaml, err := getYamlValuesContentEnvvars(envVarFlags)
+		testingUtil.AssertEqual(t, err, nil)
+		overlays = append(overlays, common.OverlayValues{Overlay: getOverlayYamlContentEnvvar(envVarFlags), Values: valuesYaml})
+	}
+
+	result, err := common.RenderOverlays(base, overlays)
+	testingUtil.AssertEqual(t, err, nil)
+	expectedResult := `apiVersion: operator.knative.dev/v1beta1
+kind: KnativeServing
+metadata:
+  name: knative-serving
+  namespace: test-serving
+spec:
+  workloads:
+  - name: activator
+    env:
+    - container: activator
+      envVars:
+      - name: EXISTING
+        value: changed
+      - name: NEW
+        value: new
+      - name: OTHER
+        value: other
+`
+	testingUtil.AssertEqual(t, result, expectedResult)
+}
@@ -0,0 +1,183 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configure
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc" // from https://github.com/kubernetes/client-go/issues/345
+
+	"knative.dev/kn-plugin-operator/pkg"
+	"knative.dev/kn-plugin-operator/pkg/command/common"
+	"knative.dev/operator/pkg/apis/operator/v1beta1"
+)
+
+//go:embed overlay/ke_eventing.yaml
+var eventingSpecOverlay string
+
+type EventingFlags struct {
+	DefaultBrokerClass       string
+	SinkBindingSelectionMode string
+	Namespace                string
+	List                     bool
+}
+
+var eventingCMDFlags EventingFlags
+
+var (
+	brokerClasses         = []string{"MTChannelBasedBroker", "Kafka"}
+	sinkBindingSelections = []string{"inclusion", "exclusion"}
+)
+
+// newEventingCommand represents the configure commands for the fields specific to Knative Eventing
+func newEventingCommand(p *pkg.OperatorParams) *cobra.Command {
+	var configureEventingCmd = &cobra.Command{
+		Use:   "eventing",
+		Short: "Configure the default broker class and the SinkBinding selection mode for Knative Eventing",
+		Example: `
+  # Configure the default broker class for Knative Eventing
+  kn operator configure eventing --default-broker-class Kafka --namespace knative-eventing
+  # Configure the SinkBinding selection mode for Knative Eventing
+  kn operator configure eventing --sinkbinding-selection-mode inclusion --namespace knative-eventing
+  # List the default broker class and the SinkBinding selection mode of Knative Eventing
+  kn operator configure eventing --list --namespace knative-eventing`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateEventingFlags(eventingCMDFlags); err != nil {
+				return err
+			}
+
+			if eventingCMDFlags.List {
+				return listEventing(cmd, eventingCMDFlags, p)
+			}
+
+			err := configureEventing(eventingCMDFlags, p)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Knative Eventing has been configured in the namespace '%s'.\n", eventingCMDFlags.Namespace)
+			return nil
+		},
+	}
+
+	configureEventingCmd.Flags().StringVar(&eventingCMDFlags.DefaultBrokerClass, "default-broker-class", "", "The default broker class: MTChannelBasedBroker or Kafka")
+	configureEventingCmd.Flags().StringVar(&eventingCMDFlags.SinkBindingSelectionMode, "sinkbinding-selection-mode", "", "The selection mode of the SinkBinding webhook: inclusion or exclusion")
+	configureEventingCmd.Flags().StringVarP(&eventingCMDFlags.Namespace, "namespace", "n", "", "The namespace of Knative Eventing")
+	configureEventingCmd.Flags().BoolVar(&eventingCMDFlags.List, "list", false, "The flag to list the default broker class and the SinkBinding selection mode")
+
+	return configureEventingCmd
+}
+
+func validateEventingFlags(eventingCMDFlags EventingFlags) error {
+	if eventingCMDFlags.List {
+		if eventingCMDFlags.DefaultBrokerClass != "" || eventingCMDFlags.SinkBindingSelectionMode != "" {
+			return fmt.Errorf("You cannot specify --default-broker-class or --sinkbinding-selection-mode together with --list.")
+		}
+		if eventingCMDFlags.Namespace == "" {
+			return fmt.Errorf("You need to specify the namespace.")
+		}
+		return nil
+	}
+	if eventingCMDFlags.DefaultBrokerClass == "" && eventingCMDFlags.SinkBindingSelectionMode == "" {
+		return fmt.Errorf("You need to specify at least one of the following options: --default-broker-class or --sinkbinding-selection-mode.")
+	}
+	if eventingCMDFlags.DefaultBrokerClass != "" && !common.Contains(brokerClasses, eventingCMDFlags.DefaultBrokerClass) {
+		return fmt.Errorf("You need to specify the default broker class to one of the following values: %s.", strings.Join(brokerClasses, ", "))
+	}
+	if eventingCMDFlags.SinkBindingSelectionMode != "" && !common.Contains(sinkBindingSelections, eventingCMDFlags.SinkBindingSelectionMode) {
+		return fmt.Errorf("You need to specify the SinkBinding selection mode to one of the following values: %s.", strings.Join(sinkBindingSelections, ", "))
+	}
+	if eventingCMDFlags.Namespace == "" {
+		return fmt.Errorf("You need to specify the namespace.")
+	}
+	return nil
+}
+
+func configureEventing(eventingCMDFlags EventingFlags, p *pkg.OperatorParams) error {
+	yamlTemplateString, err := common.GenerateOperatorCRString(common.EventingComponent, eventingCMDFlags.Namespace, p)
+	if err != nil {
+		return err
+	}
+
+	overlayContent := getOverlayYamlContentEventing(eventingCMDFlags)
+	valuesYaml := getYamlValuesContentEventing(eventingCMDFlags)
+	if err := common.ApplyManifests(yamlTemplateString, overlayContent, valuesYaml, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+func listEventing(cmd *cobra.Command, eventingCMDFlags EventingFlags, p *pkg.OperatorParams) error {
+	ksCR, err := common.GetKnativeOperatorCR(p)
+	if err != nil {
+		return err
+	}
+	ke, err := ksCR.GetKnativeEventingInCluster(eventingCMDFlags.Namespace)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Knative Eventing in the namespace '%s':\n%s\n", eventingCMDFlags.Namespace, formatEventingSpec(&ke.Spec))
+	return nil
+}
+
+// formatEventingSpec formats the default broker class and the SinkBinding selection mode into lines. The fields not
+// set in the spec are left to the defaults of the operator.
+func formatEventingSpec(spec *v1beta1.KnativeEventingSpec) string {
+	lines := []string{}
+	for _, field := range []struct {
+		name  string
+		value string
+	}{{"defaultBrokerClass", spec.DefaultBrokerClass}, {"sinkBindingSelectionMode", spec.SinkBindingSelectionMode}} {
+		value := field.value
+		if value == "" {
+			value = "<not set>"
+		}
+		lines = append(lines, fmt.Sprintf("  %s: %s", field.name, value))
+	}
+	return strings.Join(lines, common.LineWrapper)
+}
+
+func getOverlayYamlContentEventing(eventingCMDFlags EventingFlags) string {
+	resourceArray := []string{eventingSpecOverlay}
+	if eventingCMDFlags.DefaultBrokerClass != "" {
+		tag := fmt.Sprintf("%s%s", common.Spaces(2), common.YttMatchingTag)
+		resourceArray = append(resourceArray, tag)
+		resourceArray = append(resourceArray, fmt.Sprintf("%sdefaultBrokerClass: #@ data.values.defaultBrokerClass", common.Spaces(2)))
+	}
+	if eventingCMDFlags.SinkBindingSelectionMode != "" {
+		tag := fmt.Sprintf("%s%s", common.Spaces(2), common.YttMatchingTag)
+		resourceArray = append(resourceArray, tag)
+		resourceArray = append(resourceArray, fmt.Sprintf("%ssinkBindingSelectionMode: #@ data.values.sinkBindingSelectionMode", common.Spaces(2)))
+	}
+	return strings.Join(resourceArray, "\n")
+}
+
+func getYamlValuesContentEventing(eventingCMDFlags EventingFlags) string {
+	contentArray := []string{}
+	header := "#@data/values\n---"
+	contentArray = append(contentArray, header)
+
+	contentArray = append(contentArray, fmt.Sprintf("namespace: %s", eventingCMDFlags.Namespace))
+	if eventingCMDFlags.DefaultBrokerClass != "" {
+		contentArray = append(contentArray, fmt.Sprintf("defaultBrokerClass: %s", eventingCMDFlags.DefaultBrokerClass))
+	}
+	if eventingCMDFlags.SinkBindingSelectionMode != "" {
+		contentArray = append(contentArray, fmt.Sprintf("sinkBindingSelectionMode: %s", eventingCMDFlags.SinkBindingSelectionMode))
+	}
+	return strings.Join(contentArray, "\n")
+}
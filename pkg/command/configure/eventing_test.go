@@ -0,0 +1,182 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configure
+
+import (
+	"fmt"
+	"testing"
+
+	"knative.dev/kn-plugin-operator/pkg/command/testingUtil"
+	"knative.dev/operator/pkg/apis/operator/v1beta1"
+)
+
+func TestValidateEventingFlags(t *testing.T) {
+	for _, tt := range []struct {
+		name             string
+		eventingCMDFlags EventingFlags
+		expectedResult   error
+	}{{
+		name: "Eventing flags with all the parameters",
+		eventingCMDFlags: EventingFlags{
+			DefaultBrokerClass:       "Kafka",
+			SinkBindingSelectionMode: "inclusion",
+			Namespace:                "test-eventing",
+		},
+		expectedResult: nil,
+	}, {
+		name: "Eventing flags without any option",
+		eventingCMDFlags: EventingFlags{
+			Namespace: "test-eventing",
+		},
+		expectedResult: fmt.Errorf("You need to specify at least one of the following options: --default-broker-class or --sinkbinding-selection-mode."),
+	}, {
+		name: "Eventing flags with invalid broker class",
+		eventingCMDFlags: EventingFlags{
+			DefaultBrokerClass: "InMemoryBroker",
+			Namespace:          "test-eventing",
+		},
+		expectedResult: fmt.Errorf("You need to specify the default broker class to one of the following values: MTChannelBasedBroker, Kafka."),
+	}, {
+		name: "Eventing flags with invalid SinkBinding selection mode",
+		eventingCMDFlags: EventingFlags{
+			SinkBindingSelectionMode: "Inclusion",
+			Namespace:                "test-eventing",
+		},
+		expectedResult: fmt.Errorf("You need to specify the SinkBinding selection mode to one of the following values: inclusion, exclusion."),
+	}, {
+		name: "Eventing flags without namespace",
+		eventingCMDFlags: EventingFlags{
+			DefaultBrokerClass: "MTChannelBasedBroker",
+		},
+		expectedResult: fmt.Errorf("You need to specify the namespace."),
+	}, {
+		name: "Eventing flags to list",
+		eventingCMDFlags: EventingFlags{
+			Namespace: "test-eventing",
+			List:      true,
+		},
+		expectedResult: nil,
+	}, {
+		name: "Eventing flags to list with the broker class",
+		eventingCMDFlags: EventingFlags{
+			DefaultBrokerClass: "Kafka",
+			Namespace:          "test-eventing",
+			List:               true,
+		},
+		expectedResult: fmt.Errorf("You cannot specify --default-broker-class or --sinkbinding-selection-mode together with --list."),
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := validateEventingFlags(tt.eventingCMDFlags)
+			if tt.expectedResult == nil {
+				testingUtil.AssertEqual(t, result, nil)
+			} else {
+				testingUtil.AssertEqual(t, result.Error(), tt.expectedResult.Error())
+			}
+		})
+	}
+}
+
+func TestFormatEventingSpec(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		spec           *v1beta1.KnativeEventingSpec
+		expectedResult string
+	}{{
+		name: "Eventing spec with both fields",
+		spec: &v1beta1.KnativeEventingSpec{
+			DefaultBrokerClass:       "Kafka",
+			SinkBindingSelectionMode: "inclusion",
+		},
+		expectedResult: "  defaultBrokerClass: Kafka\n  sinkBindingSelectionMode: inclusion",
+	}, {
+		name:           "Eventing spec without the fields",
+		spec:           &v1beta1.KnativeEventingSpec{},
+		expectedResult: "  defaultBrokerClass: <not set>\n  sinkBindingSelectionMode: <not set>",
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			testingUtil.AssertEqual(t, formatEventingSpec(tt.spec), tt.expectedResult)
+		})
+	}
+}
+
+func TestGetOverlayYamlContentEventing(t *testing.T) {
+	for _, tt := range []struct {
+		name             string
+		eventingCMDFlags EventingFlags
+		expectedResult   string
+	}{{
+		name: "Knative Eventing with the default broker class and the SinkBinding selection mode",
+		eventingCMDFlags: EventingFlags{
+			DefaultBrokerClass:       "Kafka",
+			SinkBindingSelectionMode: "inclusion",
+			Namespace:                "test-eventing",
+		},
+		expectedResult: `#@ load("@ytt:overlay", "overlay")
+#@ load("@ytt:data", "data")
+
+#@overlay/match by=overlay.subset({"kind": "KnativeEventing"}),expects=1
+---
+apiVersion: operator.knative.dev/v1beta1
+kind: KnativeEventing
+metadata:
+  #@overlay/match missing_ok=True
+  namespace: #@ data.values.namespace
+#@overlay/match missing_ok=True
+spec:
+
+  #@overlay/match missing_ok=True
+  defaultBrokerClass: #@ data.values.defaultBrokerClass
+  #@overlay/match missing_ok=True
+  sinkBindingSelectionMode: #@ data.values.sinkBindingSelectionMode`,
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := getOverlayYamlContentEventing(tt.eventingCMDFlags)
+			testingUtil.AssertEqual(t, result, tt.expectedResult)
+		})
+	}
+}
+
+func TestGetYamlValuesContentEventing(t *testing.T) {
+	for _, tt := range []struct {
+		name             string
+		eventingCMDFlags EventingFlags
+		expectedResult   string
+	}{{
+		name: "Knative Eventing with the default broker class",
+		eventingCMDFlags: EventingFlags{
+			DefaultBrokerClass: "Kafka",
+			Namespace:          "test-eventing",
+		},
+		expectedResult: `#@data/values
+---
+namespace: test-eventing
+defaultBrokerClass: Kafka`,
+	}, {
+		name: "Knative Eventing with the SinkBinding selection mode",
+		eventingCMDFlags: EventingFlags{
+			SinkBindingSelectionMode: "exclusion",
+			Namespace:                "test-eventing",
+		},
+		expectedResult: `#@data/values
+---
+namespace: test-eventing
+sinkBindingSelectionMode: exclusion`,
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := getYamlValuesContentEventing(tt.eventingCMDFlags)
+			testingUtil.AssertEqual(t, result, tt.expectedResult)
+		})
+	}
+}
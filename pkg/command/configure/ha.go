@@ -133,7 +133,7 @@ func getHAConfiguration(haCMDFlags HAFlags) string {
 	} else {
 		tag := fmt.Sprintf("%s%s", common.Spaces(2), common.YttMatchingTag)
 		resourceArray = append(resourceArray, tag)
-		deploymentField := fmt.Sprintf("%s%s", common.Spaces(2), "deployments:")
+		deploymentField := fmt.Sprintf("%s%s", common.Spaces(2), "workloads:")
 		resourceArray = append(resourceArray, deploymentField)
 		tag = fmt.Sprintf("%s%s", common.Spaces(2), common.FieldByName("name"))
 		resourceArray = append(resourceArray, tag)
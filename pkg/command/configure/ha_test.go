@@ -48,7 +48,7 @@ metadata:
 spec:
 
   #@overlay/match missing_ok=True
-  deployments:
+  workloads:
   #@overlay/match by="name",missing_ok=True
   - name: #@ data.values.name
     #@overlay/match missing_ok=True
@@ -75,7 +75,7 @@ metadata:
 spec:
 
   #@overlay/match missing_ok=True
-  deployments:
+  workloads:
   #@overlay/match by="name",missing_ok=True
   - name: #@ data.values.name
     #@overlay/match missing_ok=True
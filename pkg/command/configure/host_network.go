@@ -0,0 +1,136 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configure
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc" // from https://github.com/kubernetes/client-go/issues/345
+
+	"knative.dev/kn-plugin-operator/pkg"
+	"knative.dev/kn-plugin-operator/pkg/command/common"
+)
+
+//go:embed overlay/ks_host_network.yaml
+var servingHostNetworkOverlay string
+
+//go:embed overlay/ke_host_network.yaml
+var eventingHostNetworkOverlay string
+
+type HostNetworkFlags struct {
+	Enabled    bool
+	Component  string
+	Namespace  string
+	DeployName string
+}
+
+var hostNetworkCMDFlags HostNetworkFlags
+
+// newHostNetworkCommand represents the configure commands to configure the host network for Knative deployments
+func newHostNetworkCommand(p *pkg.OperatorParams) *cobra.Command {
+	var configureHostNetworkCmd = &cobra.Command{
+		Use:   "host-network",
+		Short: "Configure the host network for Knative Serving and Eventing deployments",
+		Example: `
+  # Run the pods of the deployment activator in the host network
+  kn operator configure host-network --component serving --deployName activator --enabled --namespace knative-serving
+  # Run the pods of the deployment activator in the pod network
+  kn operator configure host-network --component serving --deployName activator --enabled=false --namespace knative-serving`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateHostNetworkFlags(hostNetworkCMDFlags); err != nil {
+				return err
+			}
+
+			err := configureHostNetwork(hostNetworkCMDFlags, p)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "The host network has been configured for the deployment %s in the namespace '%s'.\n",
+				hostNetworkCMDFlags.DeployName, hostNetworkCMDFlags.Namespace)
+			return nil
+		},
+	}
+
+	configureHostNetworkCmd.Flags().BoolVar(&hostNetworkCMDFlags.Enabled, "enabled", false, "The flag to run the pods in the host network")
+	configureHostNetworkCmd.Flags().StringVar(&hostNetworkCMDFlags.DeployName, "deployName", "", "The flag to specify the deployment name")
+	configureHostNetworkCmd.Flags().StringVarP(&hostNetworkCMDFlags.Component, "component", "c", "", "The flag to specify the component name")
+	configureHostNetworkCmd.Flags().StringVarP(&hostNetworkCMDFlags.Namespace, "namespace", "n", "", "The namespace of the Knative Operator or the Knative component")
+
+	return configureHostNetworkCmd
+}
+
+func validateHostNetworkFlags(hostNetworkCMDFlags HostNetworkFlags) error {
+	if hostNetworkCMDFlags.DeployName == "" {
+		return fmt.Errorf("You need to specify the name of the deployment.")
+	}
+	if hostNetworkCMDFlags.Namespace == "" {
+		return fmt.Errorf("You need to specify the namespace.")
+	}
+	if hostNetworkCMDFlags.Component == "" {
+		return fmt.Errorf("You need to specify the component name.")
+	}
+	if !strings.EqualFold(hostNetworkCMDFlags.Component, common.ServingComponent) && !strings.EqualFold(hostNetworkCMDFlags.Component, common.EventingComponent) {
+		return fmt.Errorf("You need to specify the component for Knative: serving or eventing.")
+	}
+	return nil
+}
+
+func configureHostNetwork(hostNetworkCMDFlags HostNetworkFlags, p *pkg.OperatorParams) error {
+	component := common.ServingComponent
+	if strings.EqualFold(hostNetworkCMDFlags.Component, common.EventingComponent) {
+		component = common.EventingComponent
+	}
+	yamlTemplateString, err := common.GenerateOperatorCRString(component, hostNetworkCMDFlags.Namespace, p)
+	if err != nil {
+		return err
+	}
+
+	overlayContent := getOverlayYamlContentHostNetwork(hostNetworkCMDFlags)
+	valuesYaml := getYamlValuesContentHostNetwork(hostNetworkCMDFlags)
+
+	if err = common.ApplyManifests(yamlTemplateString, overlayContent, valuesYaml, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+func getOverlayYamlContentHostNetwork(hostNetworkCMDFlags HostNetworkFlags) string {
+	baseOverlayContent := servingHostNetworkOverlay
+	if strings.EqualFold(hostNetworkCMDFlags.Component, common.EventingComponent) {
+		baseOverlayContent = eventingHostNetworkOverlay
+	}
+	return baseOverlayContent
+}
+
+func getYamlValuesContentHostNetwork(hostNetworkCMDFlags HostNetworkFlags) string {
+	contentArray := []string{}
+	header := "#@data/values\n---"
+	contentArray = append(contentArray, header)
+
+	namespace := fmt.Sprintf("namespace: %s", hostNetworkCMDFlags.Namespace)
+	contentArray = append(contentArray, namespace)
+
+	deployName := fmt.Sprintf("deployName: %s", hostNetworkCMDFlags.DeployName)
+	contentArray = append(contentArray, deployName)
+
+	hostNetwork := fmt.Sprintf("hostNetwork: %t", hostNetworkCMDFlags.Enabled)
+	contentArray = append(contentArray, hostNetwork)
+
+	return strings.Join(contentArray, "\n")
+}
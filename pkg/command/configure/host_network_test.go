@@ -0,0 +1,111 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configure
+
+import (
+	"fmt"
+	"testing"
+
+	"knative.dev/kn-plugin-operator/pkg/command/testingUtil"
+)
+
+func TestValidateHostNetworkFlags(t *testing.T) {
+	for _, tt := range []struct {
+		name                string
+		hostNetworkCMDFlags HostNetworkFlags
+		expectedResult      error
+	}{{
+		name: "Host network flags with all the parameters",
+		hostNetworkCMDFlags: HostNetworkFlags{
+			Enabled:    true,
+			Component:  "serving",
+			Namespace:  "test-serving",
+			DeployName: "activator",
+		},
+		expectedResult: nil,
+	}, {
+		name: "Host network flags without deployment name",
+		hostNetworkCMDFlags: HostNetworkFlags{
+			Enabled:   true,
+			Component: "serving",
+			Namespace: "test-serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify the name of the deployment."),
+	}, {
+		name: "Host network flags without namespace",
+		hostNetworkCMDFlags: HostNetworkFlags{
+			Enabled:    true,
+			Component:  "serving",
+			DeployName: "activator",
+		},
+		expectedResult: fmt.Errorf("You need to specify the namespace."),
+	}, {
+		name: "Host network flags with invalid component",
+		hostNetworkCMDFlags: HostNetworkFlags{
+			Enabled:    true,
+			Component:  "test",
+			Namespace:  "test-serving",
+			DeployName: "activator",
+		},
+		expectedResult: fmt.Errorf("You need to specify the component for Knative: serving or eventing."),
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := validateHostNetworkFlags(tt.hostNetworkCMDFlags)
+			if tt.expectedResult == nil {
+				testingUtil.AssertEqual(t, result, nil)
+			} else {
+				testingUtil.AssertEqual(t, result.Error(), tt.expectedResult.Error())
+			}
+		})
+	}
+}
+
+func TestGetYamlValuesContentHostNetwork(t *testing.T) {
+	for _, tt := range []struct {
+		name                string
+		hostNetworkCMDFlags HostNetworkFlags
+		expectedResult      string
+	}{{
+		name: "Knative Serving with the host network enabled",
+		hostNetworkCMDFlags: HostNetworkFlags{
+			Enabled:    true,
+			Component:  "serving",
+			Namespace:  "test-serving",
+			DeployName: "activator",
+		},
+		expectedResult: `#@data/values
+---
+namespace: test-serving
+deployName: activator
+hostNetwork: true`,
+	}, {
+		name: "Knative Eventing with the host network disabled",
+		hostNetworkCMDFlags: HostNetworkFlags{
+			Component:  "eventing",
+			Namespace:  "test-eventing",
+			DeployName: "eventing-controller",
+		},
+		expectedResult: `#@data/values
+---
+namespace: test-eventing
+deployName: eventing-controller
+hostNetwork: false`,
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := getYamlValuesContentHostNetwork(tt.hostNetworkCMDFlags)
+			testingUtil.AssertEqual(t, result, tt.expectedResult)
+		})
+	}
+}
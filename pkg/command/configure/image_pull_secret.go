@@ -0,0 +1,198 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configure
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc" // from https://github.com/kubernetes/client-go/issues/345
+	"k8s.io/client-go/util/retry"
+
+	"knative.dev/kn-plugin-operator/pkg"
+	"knative.dev/kn-plugin-operator/pkg/command/common"
+)
+
+//go:embed overlay/ks_image_pull_secret.yaml
+var servingImagePullSecretOverlay string
+
+//go:embed overlay/ke_image_pull_secret.yaml
+var eventingImagePullSecretOverlay string
+
+type ImagePullSecretFlags struct {
+	Add          string
+	Remove       string
+	DockerConfig string
+	Component    string
+	Namespace    string
+}
+
+var imagePullSecretCMDFlags ImagePullSecretFlags
+
+// newImagePullSecretCommand represents the configure commands to configure the image pull secrets for Knative images
+func newImagePullSecretCommand(p *pkg.OperatorParams) *cobra.Command {
+	var configureImagePullSecretCmd = &cobra.Command{
+		Use:   "image-pull-secrets",
+		Short: "Configure the image pull secrets for Knative",
+		Example: `
+  # Add the image pull secret regcred, created from the local docker config file, for Knative Serving
+  kn operator configure image-pull-secrets --component serving --add regcred --docker-config ~/.docker/config.json --namespace knative-serving
+  # Remove the image pull secret regcred for Knative Serving
+  kn operator configure image-pull-secrets --component serving --remove regcred --namespace knative-serving`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateImagePullSecretFlags(imagePullSecretCMDFlags); err != nil {
+				return err
+			}
+
+			err := configureImagePullSecret(imagePullSecretCMDFlags, p)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "The image pull secrets have been configured in the namespace '%s'.\n",
+				imagePullSecretCMDFlags.Namespace)
+			return nil
+		},
+	}
+
+	configureImagePullSecretCmd.Flags().StringVar(&imagePullSecretCMDFlags.Add, "add", "", "The name of the image pull secret to add")
+	configureImagePullSecretCmd.Flags().StringVar(&imagePullSecretCMDFlags.Remove, "remove", "", "The name of the image pull secret to remove")
+	configureImagePullSecretCmd.Flags().StringVar(&imagePullSecretCMDFlags.DockerConfig, "docker-config", "", "The local docker config file to create the docker-registry Secret to add")
+	configureImagePullSecretCmd.Flags().StringVarP(&imagePullSecretCMDFlags.Component, "component", "c", "", "The flag to specify the component name")
+	configureImagePullSecretCmd.Flags().StringVarP(&imagePullSecretCMDFlags.Namespace, "namespace", "n", "", "The namespace of the Knative Operator or the Knative component")
+
+	return configureImagePullSecretCmd
+}
+
+func validateImagePullSecretFlags(imagePullSecretCMDFlags ImagePullSecretFlags) error {
+	if imagePullSecretCMDFlags.Add == "" && imagePullSecretCMDFlags.Remove == "" {
+		return fmt.Errorf("You need to specify the image pull secret with either --add or --remove.")
+	}
+	if imagePullSecretCMDFlags.Add != "" && imagePullSecretCMDFlags.Remove != "" {
+		return fmt.Errorf("You are only allowed to specify either --add or --remove.")
+	}
+	if imagePullSecretCMDFlags.DockerConfig != "" && imagePullSecretCMDFlags.Add == "" {
+		return fmt.Errorf("You need to specify --add for the secret created from the docker config file.")
+	}
+	if imagePullSecretCMDFlags.Namespace == "" {
+		return fmt.Errorf("You need to specify the namespace.")
+	}
+	if imagePullSecretCMDFlags.Component == "" {
+		return fmt.Errorf("You need to specify the component name.")
+	}
+	if !strings.EqualFold(imagePullSecretCMDFlags.Component, common.ServingComponent) && !strings.EqualFold(imagePullSecretCMDFlags.Component, common.EventingComponent) {
+		return fmt.Errorf("You need to specify the component for Knative: serving or eventing.")
+	}
+	return nil
+}
+
+func configureImagePullSecret(imagePullSecretCMDFlags ImagePullSecretFlags, p *pkg.OperatorParams) error {
+	if imagePullSecretCMDFlags.Remove != "" {
+		return removeImagePullSecret(imagePullSecretCMDFlags, p)
+	}
+
+	if imagePullSecretCMDFlags.DockerConfig != "" {
+		if err := createDockerRegistrySecret(imagePullSecretCMDFlags, p); err != nil {
+			return err
+		}
+	}
+
+	component := common.ServingComponent
+	if strings.EqualFold(imagePullSecretCMDFlags.Component, common.EventingComponent) {
+		component = common.EventingComponent
+	}
+	yamlTemplateString, err := common.GenerateOperatorCRString(component, imagePullSecretCMDFlags.Namespace, p)
+	if err != nil {
+		return err
+	}
+
+	overlayContent := getOverlayYamlContentImagePullSecret(imagePullSecretCMDFlags)
+	valuesYaml := getYamlValuesContentImagePullSecret(imagePullSecretCMDFlags)
+	if err := common.ApplyManifests(yamlTemplateString, overlayContent, valuesYaml, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+// createDockerRegistrySecret creates the docker-registry Secret from the local docker config file in the namespace
+// of the Knative component, where the Knative images are pulled.
+func createDockerRegistrySecret(imagePullSecretCMDFlags ImagePullSecretFlags, p *pkg.OperatorParams) error {
+	dockerConfig, err := os.ReadFile(imagePullSecretCMDFlags.DockerConfig)
+	if err != nil {
+		return err
+	}
+	if !json.Valid(dockerConfig) {
+		return fmt.Errorf("The docker config file %s is not a valid JSON file.", imagePullSecretCMDFlags.DockerConfig)
+	}
+
+	client, err := p.NewKubeClient()
+	if err != nil {
+		return fmt.Errorf("cannot get source cluster kube config, please use --kubeconfig or export environment variable KUBECONFIG to set\n")
+	}
+	kubeResource := common.KubeResource{
+		KubeClient: client,
+	}
+	return kubeResource.CreateOrUpdateDockerRegistrySecret(imagePullSecretCMDFlags.Add, imagePullSecretCMDFlags.Namespace, dockerConfig)
+}
+
+func removeImagePullSecret(imagePullSecretCMDFlags ImagePullSecretFlags, p *pkg.OperatorParams) error {
+	ksCR, err := common.GetKnativeOperatorCR(p)
+	if err != nil {
+		return err
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		registry, err := ksCR.GetRegistry(imagePullSecretCMDFlags.Component, imagePullSecretCMDFlags.Namespace)
+		if err != nil {
+			return err
+		}
+		registry.ImagePullSecrets = removeImagePullSecretFields(registry.ImagePullSecrets, imagePullSecretCMDFlags.Remove)
+		return ksCR.UpdateRegistry(imagePullSecretCMDFlags.Component, imagePullSecretCMDFlags.Namespace, registry)
+	})
+}
+
+func removeImagePullSecretFields(secrets []corev1.LocalObjectReference, name string) []corev1.LocalObjectReference {
+	secretsBack := make([]corev1.LocalObjectReference, 0, len(secrets))
+	for _, secret := range secrets {
+		if secret.Name != name {
+			secretsBack = append(secretsBack, secret)
+		}
+	}
+	return secretsBack
+}
+
+func getOverlayYamlContentImagePullSecret(imagePullSecretCMDFlags ImagePullSecretFlags) string {
+	baseOverlayContent := servingImagePullSecretOverlay
+	if strings.EqualFold(imagePullSecretCMDFlags.Component, common.EventingComponent) {
+		baseOverlayContent = eventingImagePullSecretOverlay
+	}
+	return baseOverlayContent
+}
+
+func getYamlValuesContentImagePullSecret(imagePullSecretCMDFlags ImagePullSecretFlags) string {
+	contentArray := []string{}
+	header := "#@data/values\n---"
+	contentArray = append(contentArray, header)
+	namespace := fmt.Sprintf("namespace: %s", imagePullSecretCMDFlags.Namespace)
+	contentArray = append(contentArray, namespace)
+	secretName := fmt.Sprintf("secretName: %s", imagePullSecretCMDFlags.Add)
+	contentArray = append(contentArray, secretName)
+	return strings.Join(contentArray, "\n")
+}
@@ -0,0 +1,127 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configure
+
+import (
+	"fmt"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/kn-plugin-operator/pkg/command/testingUtil"
+)
+
+func TestValidateImagePullSecretFlags(t *testing.T) {
+	for _, tt := range []struct {
+		name                    string
+		imagePullSecretCMDFlags ImagePullSecretFlags
+		expectedResult          error
+	}{{
+		name: "Image pull secret flags to add the secret from the docker config",
+		imagePullSecretCMDFlags: ImagePullSecretFlags{
+			Add:          "regcred",
+			DockerConfig: "config.json",
+			Component:    "serving",
+			Namespace:    "test-serving",
+		},
+		expectedResult: nil,
+	}, {
+		name: "Image pull secret flags to remove the secret",
+		imagePullSecretCMDFlags: ImagePullSecretFlags{
+			Remove:    "regcred",
+			Component: "eventing",
+			Namespace: "test-eventing",
+		},
+		expectedResult: nil,
+	}, {
+		name: "Image pull secret flags without add or remove",
+		imagePullSecretCMDFlags: ImagePullSecretFlags{
+			Component: "serving",
+			Namespace: "test-serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify the image pull secret with either --add or --remove."),
+	}, {
+		name: "Image pull secret flags with both add and remove",
+		imagePullSecretCMDFlags: ImagePullSecretFlags{
+			Add:       "regcred",
+			Remove:    "oldcred",
+			Component: "serving",
+			Namespace: "test-serving",
+		},
+		expectedResult: fmt.Errorf("You are only allowed to specify either --add or --remove."),
+	}, {
+		name: "Image pull secret flags with the docker config to remove",
+		imagePullSecretCMDFlags: ImagePullSecretFlags{
+			Remove:       "regcred",
+			DockerConfig: "config.json",
+			Component:    "serving",
+			Namespace:    "test-serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify --add for the secret created from the docker config file."),
+	}, {
+		name: "Image pull secret flags with invalid component",
+		imagePullSecretCMDFlags: ImagePullSecretFlags{
+			Add:       "regcred",
+			Component: "test",
+			Namespace: "test-serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify the component for Knative: serving or eventing."),
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := validateImagePullSecretFlags(tt.imagePullSecretCMDFlags)
+			if tt.expectedResult == nil {
+				testingUtil.AssertEqual(t, result, nil)
+			} else {
+				testingUtil.AssertEqual(t, result.Error(), tt.expectedResult.Error())
+			}
+		})
+	}
+}
+
+func TestRemoveImagePullSecretFields(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		input          []corev1.LocalObjectReference
+		secretName     string
+		expectedResult []corev1.LocalObjectReference
+	}{{
+		name:           "Remove the existing secret",
+		input:          []corev1.LocalObjectReference{{Name: "regcred"}, {Name: "oldcred"}},
+		secretName:     "regcred",
+		expectedResult: []corev1.LocalObjectReference{{Name: "oldcred"}},
+	}, {
+		name:           "Remove the unknown secret",
+		input:          []corev1.LocalObjectReference{{Name: "regcred"}},
+		secretName:     "unknown",
+		expectedResult: []corev1.LocalObjectReference{{Name: "regcred"}},
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := removeImagePullSecretFields(tt.input, tt.secretName)
+			testingUtil.AssertDeepEqual(t, result, tt.expectedResult)
+		})
+	}
+}
+
+func TestGetYamlValuesContentImagePullSecret(t *testing.T) {
+	imagePullSecretCMDFlags := ImagePullSecretFlags{
+		Add:       "regcred",
+		Component: "eventing",
+		Namespace: "test-eventing",
+	}
+	expectedResult := `#@data/values
+---
+namespace: test-eventing
+secretName: regcred`
+	testingUtil.AssertEqual(t, getYamlValuesContentImagePullSecret(imagePullSecretCMDFlags), expectedResult)
+}
@@ -0,0 +1,38 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configure
+
+import (
+	"github.com/spf13/cobra"
+	"knative.dev/kn-plugin-operator/pkg"
+)
+
+// newIngressCommand represents the configure commands for the ingresses of Knative Serving
+func newIngressCommand(p *pkg.OperatorParams) *cobra.Command {
+	var configureIngressCmd = &cobra.Command{
+		Use:   "ingress",
+		Short: "Configure the ingress for Knative Serving",
+		Example: `
+  # Configure the kourier gateway service as NodePort for Knative Serving
+  kn operator configure ingress kourier --service-type NodePort --http-port 31080 --https-port 31443 --namespace knative-serving
+  # Configure the selector of knative-ingress-gateway for Knative Serving
+  kn operator configure ingress istio --gateway ingress --selector istio=custom --namespace knative-serving`,
+	}
+
+	configureIngressCmd.AddCommand(newKourierCommand(p))
+	configureIngressCmd.AddCommand(newIstioCommand(p))
+
+	return configureIngressCmd
+}
@@ -0,0 +1,400 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configure
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc" // from https://github.com/kubernetes/client-go/issues/345
+
+	"knative.dev/kn-plugin-operator/pkg"
+	"knative.dev/kn-plugin-operator/pkg/command/common"
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+//go:embed overlay/ks_istio.yaml
+var servingIstioOverlay string
+
+type IstioFlags struct {
+	Gateway   string
+	Selectors []string
+	Servers   []string
+	List      bool
+	Namespace string
+}
+
+var istioCMDFlags IstioFlags
+
+var (
+	istioProtocols = []string{"HTTP", "HTTPS", "GRPC", "HTTP2", "MONGO", "TCP", "TLS"}
+	istioTLSModes  = []string{"PASSTHROUGH", "SIMPLE", "MUTUAL", "AUTO_PASSTHROUGH", "ISTIO_MUTUAL"}
+)
+
+// newIstioCommand represents the configure commands for the gateways of the ingress istio for Knative Serving
+func newIstioCommand(p *pkg.OperatorParams) *cobra.Command {
+	var configureIstioCmd = &cobra.Command{
+		Use:   "istio",
+		Short: "Configure the gateways of the ingress istio for Knative Serving",
+		Long: `Configure the selector and the servers of knative-ingress-gateway or knative-local-gateway.
+
+The option --server accepts the comma separated fields of a server: port, protocol, port-name, target-port, name,
+bind, hosts (separated by ;), tls-mode, tls-credential, tls-server-certificate, tls-private-key,
+tls-ca-certificates, tls-subject-alt-names (separated by ;) and tls-https-redirect. The server replaces the existing
+server listening on the same port. The port name defaults to <protocol>-<port>, and the TLS mode defaults to SIMPLE,
+if any TLS certificate is specified.`,
+		Example: `
+  # Configure the selector of knative-ingress-gateway for Knative Serving
+  kn operator configure ingress istio --gateway ingress --selector istio=custom --namespace knative-serving
+  # Configure the HTTPS server of knative-ingress-gateway for Knative Serving
+  kn operator configure ingress istio --gateway ingress --server port=443,protocol=HTTPS,hosts=*.example.com,tls-credential=example-cert --namespace knative-serving
+  # List the override of knative-local-gateway for Knative Serving
+  kn operator configure ingress istio --gateway local --list --namespace knative-serving`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateIstioFlags(istioCMDFlags); err != nil {
+				return err
+			}
+
+			gatewayName, _ := common.GetIstioGatewayName(istioCMDFlags.Gateway)
+			if istioCMDFlags.List {
+				return listIstioGateway(cmd, gatewayName, istioCMDFlags, p)
+			}
+
+			err := configureIstio(gatewayName, istioCMDFlags, p)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "The gateway %s has been configured in the namespace '%s'.\n", gatewayName, istioCMDFlags.Namespace)
+			return nil
+		},
+	}
+
+	configureIstioCmd.Flags().StringVar(&istioCMDFlags.Gateway, "gateway", "", "The gateway to configure: ingress or local")
+	configureIstioCmd.Flags().StringSliceVar(&istioCMDFlags.Selectors, "selector", []string{}, "The selector of the gateway in the format of key=value")
+	configureIstioCmd.Flags().StringArrayVar(&istioCMDFlags.Servers, "server", []string{}, "The server of the gateway in the format of field=value separated by commas")
+	configureIstioCmd.Flags().BoolVar(&istioCMDFlags.List, "list", false, "The flag to list the override of the gateway")
+	configureIstioCmd.Flags().StringVarP(&istioCMDFlags.Namespace, "namespace", "n", "", "The namespace of Knative Serving")
+
+	return configureIstioCmd
+}
+
+func validateIstioFlags(istioCMDFlags IstioFlags) error {
+	if _, err := common.GetIstioGatewayName(istioCMDFlags.Gateway); err != nil {
+		return err
+	}
+	if istioCMDFlags.Namespace == "" {
+		return fmt.Errorf("You need to specify the namespace.")
+	}
+	if istioCMDFlags.List {
+		if len(istioCMDFlags.Selectors) > 0 || len(istioCMDFlags.Servers) > 0 {
+			return fmt.Errorf("You cannot specify --selector or --server together with --list.")
+		}
+		return nil
+	}
+	if len(istioCMDFlags.Selectors) == 0 && len(istioCMDFlags.Servers) == 0 {
+		return fmt.Errorf("You need to specify at least one selector or one server.")
+	}
+	if _, err := parseIstioSelector(istioCMDFlags.Selectors); err != nil {
+		return err
+	}
+	for _, server := range istioCMDFlags.Servers {
+		if _, err := parseIstioServer(server); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseIstioSelector(selectors []string) (map[string]string, error) {
+	result := map[string]string{}
+	for _, selector := range selectors {
+		pair := strings.SplitN(selector, "=", 2)
+		if len(pair) != 2 || pair[0] == "" || pair[1] == "" {
+			return nil, fmt.Errorf("The selector %s is not in the format of key=value.", selector)
+		}
+		result[pair[0]] = pair[1]
+	}
+	return result, nil
+}
+
+// parseIstioServer parses the server specified in the format of field=value separated by commas and validates it
+func parseIstioServer(input string) (base.IstioServer, error) {
+	server := base.IstioServer{Port: &base.IstioPort{}}
+	tls := &base.IstioServerTLSSettings{}
+	for _, item := range strings.Split(input, ",") {
+		pair := strings.SplitN(item, "=", 2)
+		if len(pair) != 2 || pair[1] == "" {
+			return server, fmt.Errorf("The field %s of the server %s is not in the format of field=value.", item, input)
+		}
+		key, value := pair[0], pair[1]
+		switch key {
+		case "port", "target-port":
+			number, err := strconv.ParseUint(value, 10, 32)
+			if err != nil || number == 0 || number > 65535 {
+				return server, fmt.Errorf("The %s %s of the server %s is not a valid port number.", key, value, input)
+			}
+			if key == "port" {
+				server.Port.Number = uint32(number)
+			} else {
+				server.Port.TargetPort = uint32(number)
+			}
+		case "protocol":
+			server.Port.Protocol = strings.ToUpper(value)
+		case "port-name":
+			server.Port.Name = value
+		case "name":
+			server.Name = value
+		case "bind":
+			server.Bind = value
+		case "hosts":
+			server.Hosts = strings.Split(value, ";")
+		case "tls-mode":
+			tls.Mode = strings.ToUpper(value)
+		case "tls-credential":
+			tls.CredentialName = value
+		case "tls-server-certificate":
+			tls.ServerCertificate = value
+		case "tls-private-key":
+			tls.PrivateKey = value
+		case "tls-ca-certificates":
+			tls.CaCertificates = value
+		case "tls-subject-alt-names":
+			tls.SubjectAltNames = strings.Split(value, ";")
+		case "tls-https-redirect":
+			redirect, err := strconv.ParseBool(value)
+			if err != nil {
+				return server, fmt.Errorf("The tls-https-redirect %s of the server %s is not a boolean.", value, input)
+			}
+			tls.HttpsRedirect = redirect
+		default:
+			return server, fmt.Errorf("The field %s of the server %s is not supported.", key, input)
+		}
+	}
+
+	if server.Port.Number == 0 {
+		return server, fmt.Errorf("You need to specify the port of the server %s.", input)
+	}
+	if !common.Contains(istioProtocols, server.Port.Protocol) {
+		return server, fmt.Errorf("You need to specify the protocol of the server %s to one of the following values: %s.",
+			input, strings.Join(istioProtocols, ", "))
+	}
+	if len(server.Hosts) == 0 {
+		return server, fmt.Errorf("You need to specify the hosts of the server %s.", input)
+	}
+	if server.Port.Name == "" {
+		server.Port.Name = fmt.Sprintf("%s-%d", strings.ToLower(server.Port.Protocol), server.Port.Number)
+	}
+
+	if !reflect.DeepEqual(*tls, base.IstioServerTLSSettings{}) {
+		if err := validateIstioServerTLS(server.Port.Protocol, tls); err != nil {
+			return server, fmt.Errorf("The TLS settings of the server %s are invalid: %v", input, err)
+		}
+		server.Tls = tls
+	} else if server.Port.Protocol == "HTTPS" || server.Port.Protocol == "TLS" {
+		return server, fmt.Errorf("You need to specify the TLS settings of the server %s for the protocol %s.", input, server.Port.Protocol)
+	}
+	return server, nil
+}
+
+// validateIstioServerTLS validates the TLS settings of the server with the protocol, following the rules of the
+// istio gateway. The TLS mode defaults to SIMPLE, if any certificate is specified.
+func validateIstioServerTLS(protocol string, tls *base.IstioServerTLSSettings) error {
+	hasCertificates := tls.CredentialName != "" || tls.ServerCertificate != "" || tls.PrivateKey != "" || tls.CaCertificates != ""
+	if tls.HttpsRedirect {
+		if protocol != "HTTP" {
+			return fmt.Errorf("tls-https-redirect is only available for the protocol HTTP")
+		}
+		if tls.Mode != "" || hasCertificates || len(tls.SubjectAltNames) > 0 {
+			return fmt.Errorf("tls-https-redirect cannot be combined with other TLS settings")
+		}
+		return nil
+	}
+	if protocol != "HTTPS" && protocol != "TLS" {
+		return fmt.Errorf("the TLS settings are only available for the protocols HTTPS and TLS")
+	}
+
+	if tls.Mode == "" && hasCertificates {
+		tls.Mode = "SIMPLE"
+	}
+	if !common.Contains(istioTLSModes, tls.Mode) {
+		return fmt.Errorf("tls-mode needs to be one of the following values: %s", strings.Join(istioTLSModes, ", "))
+	}
+
+	switch tls.Mode {
+	case "SIMPLE", "MUTUAL":
+		if tls.CredentialName != "" {
+			if tls.ServerCertificate != "" || tls.PrivateKey != "" || tls.CaCertificates != "" {
+				return fmt.Errorf("tls-credential cannot be combined with the certificate files")
+			}
+			return nil
+		}
+		if tls.ServerCertificate == "" || tls.PrivateKey == "" {
+			return fmt.Errorf("the mode %s requires tls-credential or both tls-server-certificate and tls-private-key", tls.Mode)
+		}
+		if tls.Mode == "MUTUAL" && tls.CaCertificates == "" {
+			return fmt.Errorf("the mode MUTUAL requires tls-ca-certificates together with the certificate files")
+		}
+	default:
+		if hasCertificates {
+			return fmt.Errorf("the mode %s does not accept any certificate", tls.Mode)
+		}
+	}
+	return nil
+}
+
+// mergeIstioGatewayOverride merges the selector and the servers into the existing override of the gateway. The
+// server replaces the existing server listening on the same port.
+func mergeIstioGatewayOverride(existing *base.IstioGatewayOverride, selector map[string]string,
+	servers []base.IstioServer) *base.IstioGatewayOverride {
+	override := &base.IstioGatewayOverride{}
+	if existing != nil {
+		existing.DeepCopyInto(override)
+	}
+
+	if len(selector) > 0 {
+		if override.Selector == nil {
+			override.Selector = map[string]string{}
+		}
+		for key, value := range selector {
+			override.Selector[key] = value
+		}
+	}
+
+	for _, server := range servers {
+		found := false
+		for i := range override.Servers {
+			if override.Servers[i].Port != nil && override.Servers[i].Port.Number == server.Port.Number {
+				override.Servers[i] = server
+				found = true
+				break
+			}
+		}
+		if !found {
+			override.Servers = append(override.Servers, server)
+		}
+	}
+	sort.SliceStable(override.Servers, func(i, j int) bool {
+		return getIstioServerPort(override.Servers[i]) < getIstioServerPort(override.Servers[j])
+	})
+	return override
+}
+
+func getIstioServerPort(server base.IstioServer) uint32 {
+	if server.Port == nil {
+		return 0
+	}
+	return server.Port.Number
+}
+
+func configureIstio(gatewayName string, istioCMDFlags IstioFlags, p *pkg.OperatorParams) error {
+	selector, err := parseIstioSelector(istioCMDFlags.Selectors)
+	if err != nil {
+		return err
+	}
+	servers := []base.IstioServer{}
+	for _, input := range istioCMDFlags.Servers {
+		server, err := parseIstioServer(input)
+		if err != nil {
+			return err
+		}
+		servers = append(servers, server)
+	}
+
+	ksCR, err := common.GetKnativeOperatorCR(p)
+	if err != nil {
+		return err
+	}
+	existing, err := ksCR.GetIstioGatewayOverride(istioCMDFlags.Namespace, gatewayName)
+	if err != nil {
+		return err
+	}
+	override := mergeIstioGatewayOverride(existing, selector, servers)
+
+	yamlTemplateString, err := common.GenerateOperatorCRString(common.ServingComponent, istioCMDFlags.Namespace, p)
+	if err != nil {
+		return err
+	}
+
+	overlayContent := getOverlayYamlContentIstio(gatewayName)
+	valuesYaml, err := getYamlValuesContentIstio(istioCMDFlags.Namespace, override)
+	if err != nil {
+		return err
+	}
+	if err := common.ApplyManifests(yamlTemplateString, overlayContent, valuesYaml, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+func listIstioGateway(cmd *cobra.Command, gatewayName string, istioCMDFlags IstioFlags, p *pkg.OperatorParams) error {
+	ksCR, err := common.GetKnativeOperatorCR(p)
+	if err != nil {
+		return err
+	}
+	override, err := ksCR.GetIstioGatewayOverride(istioCMDFlags.Namespace, gatewayName)
+	if err != nil {
+		return err
+	}
+	if override == nil {
+		fmt.Fprintf(cmd.OutOrStdout(), "The gateway %s is not overridden in the namespace '%s'.\n", gatewayName, istioCMDFlags.Namespace)
+		return nil
+	}
+
+	content, err := yaml.Marshal(override)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "%s:\n%s", gatewayName, content)
+	return nil
+}
+
+func getOverlayYamlContentIstio(gatewayName string) string {
+	resourceArray := []string{servingIstioOverlay}
+	tag := fmt.Sprintf("%s%s", common.Spaces(2), common.YttMatchingTag)
+	resourceArray = append(resourceArray, tag)
+	resourceArray = append(resourceArray, fmt.Sprintf("%singress:", common.Spaces(2)))
+	tag = fmt.Sprintf("%s%s", common.Spaces(4), common.YttMatchingTag)
+	resourceArray = append(resourceArray, tag)
+	resourceArray = append(resourceArray, fmt.Sprintf("%sistio:", common.Spaces(4)))
+	tag = fmt.Sprintf("%s%s", common.Spaces(6), common.YttMatchingTag)
+	resourceArray = append(resourceArray, tag)
+	tag = fmt.Sprintf("%s%s", common.Spaces(6), common.YttReplaceTag)
+	resourceArray = append(resourceArray, tag)
+	resourceArray = append(resourceArray, fmt.Sprintf("%s%s: #@ data.values.gateway", common.Spaces(6), gatewayName))
+	return strings.Join(resourceArray, "\n")
+}
+
+func getYamlValuesContentIstio(namespace string, override *base.IstioGatewayOverride) (string, error) {
+	contentArray := []string{}
+	header := "#@data/values\n---"
+	contentArray = append(contentArray, header)
+
+	contentArray = append(contentArray, fmt.Sprintf("namespace: %s", namespace))
+
+	gateway, err := json.Marshal(override)
+	if err != nil {
+		return "", err
+	}
+	contentArray = append(contentArray, fmt.Sprintf("gateway: %s", gateway))
+	return strings.Join(contentArray, "\n"), nil
+}
@@ -0,0 +1,282 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configure
+
+import (
+	"fmt"
+	"testing"
+
+	"knative.dev/kn-plugin-operator/pkg/command/testingUtil"
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+func TestValidateIstioFlags(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		istioCMDFlags  IstioFlags
+		expectedResult error
+	}{{
+		name: "Istio flags with selector and server",
+		istioCMDFlags: IstioFlags{
+			Gateway:   "ingress",
+			Selectors: []string{"istio=custom"},
+			Servers:   []string{"port=443,protocol=HTTPS,hosts=*.example.com,tls-credential=example-cert"},
+			Namespace: "test-serving",
+		},
+		expectedResult: nil,
+	}, {
+		name: "Istio flags to list the gateway",
+		istioCMDFlags: IstioFlags{
+			Gateway:   "local",
+			List:      true,
+			Namespace: "test-serving",
+		},
+		expectedResult: nil,
+	}, {
+		name: "Istio flags with invalid gateway",
+		istioCMDFlags: IstioFlags{
+			Gateway:   "egress",
+			Selectors: []string{"istio=custom"},
+			Namespace: "test-serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify the gateway to one of the following values: ingress or local."),
+	}, {
+		name: "Istio flags without namespace",
+		istioCMDFlags: IstioFlags{
+			Gateway:   "ingress",
+			Selectors: []string{"istio=custom"},
+		},
+		expectedResult: fmt.Errorf("You need to specify the namespace."),
+	}, {
+		name: "Istio flags with list and selector",
+		istioCMDFlags: IstioFlags{
+			Gateway:   "ingress",
+			Selectors: []string{"istio=custom"},
+			List:      true,
+			Namespace: "test-serving",
+		},
+		expectedResult: fmt.Errorf("You cannot specify --selector or --server together with --list."),
+	}, {
+		name: "Istio flags without selector or server",
+		istioCMDFlags: IstioFlags{
+			Gateway:   "ingress",
+			Namespace: "test-serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify at least one selector or one server."),
+	}, {
+		name: "Istio flags with invalid selector",
+		istioCMDFlags: IstioFlags{
+			Gateway:   "ingress",
+			Selectors: []string{"istio"},
+			Namespace: "test-serving",
+		},
+		expectedResult: fmt.Errorf("The selector istio is not in the format of key=value."),
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := validateIstioFlags(tt.istioCMDFlags)
+			if tt.expectedResult == nil {
+				testingUtil.AssertEqual(t, result, nil)
+			} else {
+				testingUtil.AssertEqual(t, result.Error(), tt.expectedResult.Error())
+			}
+		})
+	}
+}
+
+func TestParseIstioServer(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		input          string
+		expectedResult base.IstioServer
+		expectedError  error
+	}{{
+		name:  "HTTPS server with the credential",
+		input: "port=443,protocol=https,hosts=*.example.com;*.example.org,tls-credential=example-cert",
+		expectedResult: base.IstioServer{
+			Port:  &base.IstioPort{Number: 443, Protocol: "HTTPS", Name: "https-443"},
+			Hosts: []string{"*.example.com", "*.example.org"},
+			Tls:   &base.IstioServerTLSSettings{Mode: "SIMPLE", CredentialName: "example-cert"},
+		},
+	}, {
+		name:  "HTTP server with the https redirect",
+		input: "port=80,protocol=HTTP,port-name=http,target-port=8080,hosts=*,tls-https-redirect=true",
+		expectedResult: base.IstioServer{
+			Port:  &base.IstioPort{Number: 80, Protocol: "HTTP", Name: "http", TargetPort: 8080},
+			Hosts: []string{"*"},
+			Tls:   &base.IstioServerTLSSettings{HttpsRedirect: true},
+		},
+	}, {
+		name:  "HTTPS server with the mutual TLS",
+		input: "port=443,protocol=HTTPS,hosts=*,tls-mode=mutual,tls-server-certificate=/etc/cert.pem,tls-private-key=/etc/key.pem,tls-ca-certificates=/etc/ca.pem",
+		expectedResult: base.IstioServer{
+			Port:  &base.IstioPort{Number: 443, Protocol: "HTTPS", Name: "https-443"},
+			Hosts: []string{"*"},
+			Tls: &base.IstioServerTLSSettings{Mode: "MUTUAL", ServerCertificate: "/etc/cert.pem",
+				PrivateKey: "/etc/key.pem", CaCertificates: "/etc/ca.pem"},
+		},
+	}, {
+		name:          "Server with unknown field",
+		input:         "port=80,protocol=HTTP,hosts=*,unknown=value",
+		expectedError: fmt.Errorf("The field unknown of the server port=80,protocol=HTTP,hosts=*,unknown=value is not supported."),
+	}, {
+		name:          "Server without port",
+		input:         "protocol=HTTP,hosts=*",
+		expectedError: fmt.Errorf("You need to specify the port of the server protocol=HTTP,hosts=*."),
+	}, {
+		name:          "Server with invalid port",
+		input:         "port=70000,protocol=HTTP,hosts=*",
+		expectedError: fmt.Errorf("The port 70000 of the server port=70000,protocol=HTTP,hosts=* is not a valid port number."),
+	}, {
+		name:          "Server with invalid protocol",
+		input:         "port=80,protocol=UDP,hosts=*",
+		expectedError: fmt.Errorf("You need to specify the protocol of the server port=80,protocol=UDP,hosts=* to one of the following values: HTTP, HTTPS, GRPC, HTTP2, MONGO, TCP, TLS."),
+	}, {
+		name:          "Server without hosts",
+		input:         "port=80,protocol=HTTP",
+		expectedError: fmt.Errorf("You need to specify the hosts of the server port=80,protocol=HTTP."),
+	}, {
+		name:          "HTTPS server without TLS settings",
+		input:         "port=443,protocol=HTTPS,hosts=*",
+		expectedError: fmt.Errorf("You need to specify the TLS settings of the server port=443,protocol=HTTPS,hosts=* for the protocol HTTPS."),
+	}, {
+		name:          "HTTP server with the credential",
+		input:         "port=80,protocol=HTTP,hosts=*,tls-credential=example-cert",
+		expectedError: fmt.Errorf("The TLS settings of the server port=80,protocol=HTTP,hosts=*,tls-credential=example-cert are invalid: the TLS settings are only available for the protocols HTTPS and TLS"),
+	}, {
+		name:          "HTTPS server with the https redirect",
+		input:         "port=443,protocol=HTTPS,hosts=*,tls-https-redirect=true",
+		expectedError: fmt.Errorf("The TLS settings of the server port=443,protocol=HTTPS,hosts=*,tls-https-redirect=true are invalid: tls-https-redirect is only available for the protocol HTTP"),
+	}, {
+		name:          "HTTPS server with invalid TLS mode",
+		input:         "port=443,protocol=HTTPS,hosts=*,tls-mode=STRICT",
+		expectedError: fmt.Errorf("The TLS settings of the server port=443,protocol=HTTPS,hosts=*,tls-mode=STRICT are invalid: tls-mode needs to be one of the following values: PASSTHROUGH, SIMPLE, MUTUAL, AUTO_PASSTHROUGH, ISTIO_MUTUAL"),
+	}, {
+		name:          "HTTPS server with the credential and the certificate files",
+		input:         "port=443,protocol=HTTPS,hosts=*,tls-credential=example-cert,tls-server-certificate=/etc/cert.pem",
+		expectedError: fmt.Errorf("The TLS settings of the server port=443,protocol=HTTPS,hosts=*,tls-credential=example-cert,tls-server-certificate=/etc/cert.pem are invalid: tls-credential cannot be combined with the certificate files"),
+	}, {
+		name:          "HTTPS server with the certificate file but without the private key",
+		input:         "port=443,protocol=HTTPS,hosts=*,tls-server-certificate=/etc/cert.pem",
+		expectedError: fmt.Errorf("The TLS settings of the server port=443,protocol=HTTPS,hosts=*,tls-server-certificate=/etc/cert.pem are invalid: the mode SIMPLE requires tls-credential or both tls-server-certificate and tls-private-key"),
+	}, {
+		name:          "HTTPS server with the mutual TLS but without the CA certificates",
+		input:         "port=443,protocol=HTTPS,hosts=*,tls-mode=MUTUAL,tls-server-certificate=/etc/cert.pem,tls-private-key=/etc/key.pem",
+		expectedError: fmt.Errorf("The TLS settings of the server port=443,protocol=HTTPS,hosts=*,tls-mode=MUTUAL,tls-server-certificate=/etc/cert.pem,tls-private-key=/etc/key.pem are invalid: the mode MUTUAL requires tls-ca-certificates together with the certificate files"),
+	}, {
+		name:          "TLS server with the passthrough mode and the credential",
+		input:         "port=443,protocol=TLS,hosts=*,tls-mode=PASSTHROUGH,tls-credential=example-cert",
+		expectedError: fmt.Errorf("The TLS settings of the server port=443,protocol=TLS,hosts=*,tls-mode=PASSTHROUGH,tls-credential=example-cert are invalid: the mode PASSTHROUGH does not accept any certificate"),
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseIstioServer(tt.input)
+			if tt.expectedError == nil {
+				testingUtil.AssertEqual(t, err, nil)
+				testingUtil.AssertDeepEqual(t, result, tt.expectedResult)
+			} else {
+				testingUtil.AssertEqual(t, err.Error(), tt.expectedError.Error())
+			}
+		})
+	}
+}
+
+func TestMergeIstioGatewayOverride(t *testing.T) {
+	httpsServer := base.IstioServer{
+		Port:  &base.IstioPort{Number: 443, Protocol: "HTTPS", Name: "https-443"},
+		Hosts: []string{"*.example.com"},
+		Tls:   &base.IstioServerTLSSettings{Mode: "SIMPLE", CredentialName: "example-cert"},
+	}
+	httpServer := base.IstioServer{
+		Port:  &base.IstioPort{Number: 80, Protocol: "HTTP", Name: "http-80"},
+		Hosts: []string{"*"},
+	}
+	for _, tt := range []struct {
+		name           string
+		existing       *base.IstioGatewayOverride
+		selector       map[string]string
+		servers        []base.IstioServer
+		expectedResult *base.IstioGatewayOverride
+	}{{
+		name:     "Merge into an empty override",
+		selector: map[string]string{"istio": "custom"},
+		servers:  []base.IstioServer{httpsServer},
+		expectedResult: &base.IstioGatewayOverride{
+			Selector: map[string]string{"istio": "custom"},
+			Servers:  []base.IstioServer{httpsServer},
+		},
+	}, {
+		name: "Merge into an existing override",
+		existing: &base.IstioGatewayOverride{
+			Selector: map[string]string{"istio": "ingressgateway", "app": "gateway"},
+			Servers: []base.IstioServer{{
+				Port:  &base.IstioPort{Number: 443, Protocol: "HTTPS", Name: "https"},
+				Hosts: []string{"*"},
+				Tls:   &base.IstioServerTLSSettings{Mode: "SIMPLE", CredentialName: "old-cert"},
+			}},
+		},
+		selector: map[string]string{"istio": "custom"},
+		servers:  []base.IstioServer{httpServer, httpsServer},
+		expectedResult: &base.IstioGatewayOverride{
+			Selector: map[string]string{"istio": "custom", "app": "gateway"},
+			Servers:  []base.IstioServer{httpServer, httpsServer},
+		},
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := mergeIstioGatewayOverride(tt.existing, tt.selector, tt.servers)
+			testingUtil.AssertDeepEqual(t, result, tt.expectedResult)
+		})
+	}
+}
+
+func TestGetOverlayYamlContentIstio(t *testing.T) {
+	expectedResult := `#@ load("@ytt:overlay", "overlay")
+#@ load("@ytt:data", "data")
+
+#@overlay/match by=overlay.subset({"kind": "KnativeServing"}),expects=1
+---
+apiVersion: operator.knative.dev/v1beta1
+kind: KnativeServing
+metadata:
+  #@overlay/match missing_ok=True
+  namespace: #@ data.values.namespace
+#@overlay/match missing_ok=True
+spec:
+
+  #@overlay/match missing_ok=True
+  ingress:
+    #@overlay/match missing_ok=True
+    istio:
+      #@overlay/match missing_ok=True
+      #@overlay/replace or_add=True
+      knative-local-gateway: #@ data.values.gateway`
+	testingUtil.AssertEqual(t, getOverlayYamlContentIstio("knative-local-gateway"), expectedResult)
+}
+
+func TestGetYamlValuesContentIstio(t *testing.T) {
+	override := &base.IstioGatewayOverride{
+		Selector: map[string]string{"istio": "custom"},
+		Servers: []base.IstioServer{{
+			Port:  &base.IstioPort{Number: 443, Protocol: "HTTPS", Name: "https-443"},
+			Hosts: []string{"*.example.com"},
+			Tls:   &base.IstioServerTLSSettings{Mode: "SIMPLE", CredentialName: "example-cert"},
+		}},
+	}
+	expectedResult := `#@data/values
+---
+namespace: test-serving
+gateway: {"selector":{"istio":"custom"},"servers":[{"port":{"number":443,"protocol":"HTTPS","name":"https-443"},"hosts":["*.example.com"],"tls":{"mode":"SIMPLE","credentialName":"example-cert"}}]}`
+	result, err := getYamlValuesContentIstio("test-serving", override)
+	testingUtil.AssertEqual(t, err, nil)
+	testingUtil.AssertEqual(t, result, expectedResult)
+}
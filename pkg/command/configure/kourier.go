@@ -0,0 +1,243 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configure
+
+import (
+	_ "embed"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc" // from https://github.com/kubernetes/client-go/issues/345
+
+	"knative.dev/kn-plugin-operator/pkg"
+	"knative.dev/kn-plugin-operator/pkg/command/common"
+)
+
+const (
+	// EnvoyBootstrapKey is the key of the envoy bootstrap configuration in the bootstrap ConfigMap
+	EnvoyBootstrapKey = "envoy-bootstrap.yaml"
+	// DefaultKourierBootstrapName is the default name of the bootstrap ConfigMap created by the plugin
+	DefaultKourierBootstrapName = "kourier-custom-bootstrap"
+)
+
+//go:embed overlay/ks_kourier.yaml
+var servingKourierOverlay string
+
+type KourierFlags struct {
+	ServiceType    string
+	HTTPPort       int32
+	HTTPSPort      int32
+	LoadBalancerIP string
+	BootstrapFile  string
+	BootstrapName  string
+	Namespace      string
+}
+
+var kourierCMDFlags KourierFlags
+
+// newKourierCommand represents the configure commands for the ingress kourier of Knative Serving
+func newKourierCommand(p *pkg.OperatorParams) *cobra.Command {
+	var configureKourierCmd = &cobra.Command{
+		Use:   "kourier",
+		Short: "Configure the ingress kourier for Knative Serving",
+		Example: `
+  # Configure the kourier gateway service as NodePort for Knative Serving
+  kn operator configure ingress kourier --service-type NodePort --http-port 31080 --https-port 31443 --namespace knative-serving
+  # Configure the kourier gateway service as LoadBalancer with a fixed IP for Knative Serving
+  kn operator configure ingress kourier --service-type LoadBalancer --load-balancer-ip 10.0.0.10 --namespace knative-serving
+  # Configure the envoy bootstrap of the kourier gateway with the local file envoy.yaml
+  kn operator configure ingress kourier --bootstrap-from-file envoy.yaml --namespace knative-serving`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateKourierFlags(kourierCMDFlags); err != nil {
+				return err
+			}
+
+			err := configureKourier(kourierCMDFlags, p)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "The ingress kourier has been configured in the namespace '%s'.\n", kourierCMDFlags.Namespace)
+			return nil
+		},
+	}
+
+	configureKourierCmd.Flags().StringVar(&kourierCMDFlags.ServiceType, "service-type", "", "The service type of the kourier gateway: ClusterIP, NodePort or LoadBalancer")
+	configureKourierCmd.Flags().Int32Var(&kourierCMDFlags.HTTPPort, "http-port", 0, "The node port of the kourier gateway for the http traffic, available for the service type NodePort")
+	configureKourierCmd.Flags().Int32Var(&kourierCMDFlags.HTTPSPort, "https-port", 0, "The node port of the kourier gateway for the https traffic, available for the service type NodePort")
+	configureKourierCmd.Flags().StringVar(&kourierCMDFlags.LoadBalancerIP, "load-balancer-ip", "", "The load balancer IP of the kourier gateway, available for the service type LoadBalancer")
+	configureKourierCmd.Flags().StringVar(&kourierCMDFlags.BootstrapFile, "bootstrap-from-file", "", "The local file containing the envoy bootstrap configuration of the kourier gateway")
+	configureKourierCmd.Flags().StringVar(&kourierCMDFlags.BootstrapName, "bootstrap-configmap", DefaultKourierBootstrapName, "The name of the ConfigMap to save the envoy bootstrap configuration")
+	configureKourierCmd.Flags().StringVarP(&kourierCMDFlags.Namespace, "namespace", "n", "", "The namespace of Knative Serving")
+
+	return configureKourierCmd
+}
+
+func validateKourierFlags(kourierCMDFlags KourierFlags) error {
+	if kourierCMDFlags.ServiceType == "" && kourierCMDFlags.HTTPPort == 0 && kourierCMDFlags.HTTPSPort == 0 &&
+		kourierCMDFlags.LoadBalancerIP == "" && kourierCMDFlags.BootstrapFile == "" {
+		return fmt.Errorf("You need to specify at least one of the following options: --service-type, --http-port, --https-port, --load-balancer-ip or --bootstrap-from-file.")
+	}
+	if kourierCMDFlags.ServiceType != "" {
+		switch corev1.ServiceType(kourierCMDFlags.ServiceType) {
+		case corev1.ServiceTypeClusterIP, corev1.ServiceTypeNodePort, corev1.ServiceTypeLoadBalancer:
+		default:
+			return fmt.Errorf("You need to specify the service type to one of the following values: ClusterIP, NodePort or LoadBalancer.")
+		}
+	}
+	if kourierCMDFlags.HTTPPort != 0 || kourierCMDFlags.HTTPSPort != 0 {
+		if kourierCMDFlags.ServiceType != string(corev1.ServiceTypeNodePort) {
+			return fmt.Errorf("The http and https ports are only available for the service type NodePort.")
+		}
+		for _, port := range []int32{kourierCMDFlags.HTTPPort, kourierCMDFlags.HTTPSPort} {
+			if port < 0 || port > 65535 {
+				return fmt.Errorf("The port %d is not a valid port number.", port)
+			}
+		}
+	}
+	if kourierCMDFlags.LoadBalancerIP != "" {
+		if kourierCMDFlags.ServiceType != string(corev1.ServiceTypeLoadBalancer) {
+			return fmt.Errorf("The load balancer IP is only available for the service type LoadBalancer.")
+		}
+		if net.ParseIP(kourierCMDFlags.LoadBalancerIP) == nil {
+			return fmt.Errorf("The load balancer IP %s is not a valid IP address.", kourierCMDFlags.LoadBalancerIP)
+		}
+	}
+	if kourierCMDFlags.BootstrapFile != "" && kourierCMDFlags.BootstrapName == "" {
+		return fmt.Errorf("You need to specify the name of the bootstrap ConfigMap.")
+	}
+	if kourierCMDFlags.Namespace == "" {
+		return fmt.Errorf("You need to specify the namespace.")
+	}
+	return nil
+}
+
+// readBootstrap reads the envoy bootstrap configuration and makes sure that it is a valid YAML or JSON document
+func readBootstrap(file string) ([]byte, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	bootstrap := map[string]interface{}{}
+	if err = yaml.Unmarshal(data, &bootstrap); err != nil {
+		return nil, fmt.Errorf("The file %s does not contain a valid envoy bootstrap configuration: %v", file, err)
+	}
+	if len(bootstrap) == 0 {
+		return nil, fmt.Errorf("The file %s does not contain a valid envoy bootstrap configuration.", file)
+	}
+	return data, nil
+}
+
+func configureKourier(kourierCMDFlags KourierFlags, p *pkg.OperatorParams) error {
+	if kourierCMDFlags.BootstrapFile != "" {
+		data, err := readBootstrap(kourierCMDFlags.BootstrapFile)
+		if err != nil {
+			return err
+		}
+
+		client, err := p.NewKubeClient()
+		if err != nil {
+			return fmt.Errorf("cannot get source cluster kube config, please use --kubeconfig or export environment variable KUBECONFIG to set\n")
+		}
+		kubeResource := common.KubeResource{
+			KubeClient: client,
+		}
+		// The bootstrap ConfigMap has to be in the namespace of Knative Serving, where the kourier gateway runs
+		if err = kubeResource.CreateOrUpdateManagedResource(common.ConfigMapType, kourierCMDFlags.BootstrapName, kourierCMDFlags.Namespace,
+			EnvoyBootstrapKey, data); err != nil {
+			return err
+		}
+	}
+
+	yamlTemplateString, err := common.GenerateOperatorCRString(common.ServingComponent, kourierCMDFlags.Namespace, p)
+	if err != nil {
+		return err
+	}
+
+	overlayContent := getOverlayYamlContentKourier(kourierCMDFlags)
+	valuesYaml := getYamlValuesContentKourier(kourierCMDFlags)
+	if err := common.ApplyManifests(yamlTemplateString, overlayContent, valuesYaml, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+type kourierField struct {
+	name  string
+	key   string
+	value string
+}
+
+// getKourierFields returns the kourier fields specified in the flags
+func getKourierFields(kourierCMDFlags KourierFlags) []kourierField {
+	fields := []kourierField{}
+	if kourierCMDFlags.ServiceType != "" {
+		fields = append(fields, kourierField{name: "service-type", key: "serviceType", value: kourierCMDFlags.ServiceType})
+	}
+	if kourierCMDFlags.LoadBalancerIP != "" {
+		fields = append(fields, kourierField{name: "service-load-balancer-ip", key: "loadBalancerIP",
+			value: fmt.Sprintf("\"%s\"", kourierCMDFlags.LoadBalancerIP)})
+	}
+	if kourierCMDFlags.HTTPPort != 0 {
+		fields = append(fields, kourierField{name: "http-port", key: "httpPort", value: fmt.Sprintf("%d", kourierCMDFlags.HTTPPort)})
+	}
+	if kourierCMDFlags.HTTPSPort != 0 {
+		fields = append(fields, kourierField{name: "https-port", key: "httpsPort", value: fmt.Sprintf("%d", kourierCMDFlags.HTTPSPort)})
+	}
+	if kourierCMDFlags.BootstrapFile != "" {
+		fields = append(fields, kourierField{name: "bootstrap-configmap", key: "bootstrapConfigMap", value: kourierCMDFlags.BootstrapName})
+	}
+	return fields
+}
+
+func getOverlayYamlContentKourier(kourierCMDFlags KourierFlags) string {
+	resourceArray := []string{servingKourierOverlay}
+	tag := fmt.Sprintf("%s%s", common.Spaces(2), common.YttMatchingTag)
+	resourceArray = append(resourceArray, tag)
+	resourceArray = append(resourceArray, fmt.Sprintf("%singress:", common.Spaces(2)))
+	tag = fmt.Sprintf("%s%s", common.Spaces(4), common.YttMatchingTag)
+	resourceArray = append(resourceArray, tag)
+	resourceArray = append(resourceArray, fmt.Sprintf("%skourier:", common.Spaces(4)))
+
+	for _, field := range getKourierFields(kourierCMDFlags) {
+		tag = fmt.Sprintf("%s%s", common.Spaces(6), common.YttMatchingTag)
+		resourceArray = append(resourceArray, tag)
+		kourierField := fmt.Sprintf("%s%s: #@ data.values.%s", common.Spaces(6), field.name, field.key)
+		resourceArray = append(resourceArray, kourierField)
+	}
+
+	return strings.Join(resourceArray, "\n")
+}
+
+func getYamlValuesContentKourier(kourierCMDFlags KourierFlags) string {
+	contentArray := []string{}
+	header := "#@data/values\n---"
+	contentArray = append(contentArray, header)
+
+	namespace := fmt.Sprintf("namespace: %s", kourierCMDFlags.Namespace)
+	contentArray = append(contentArray, namespace)
+
+	for _, field := range getKourierFields(kourierCMDFlags) {
+		contentArray = append(contentArray, fmt.Sprintf("%s: %s", field.key, field.value))
+	}
+
+	return strings.Join(contentArray, "\n")
+}
@@ -0,0 +1,230 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configure
+
+import (
+	"fmt"
+	"testing"
+
+	"knative.dev/kn-plugin-operator/pkg/command/testingUtil"
+)
+
+func TestValidateKourierFlags(t *testing.T) {
+	for _, tt := range []struct {
+		name            string
+		kourierCMDFlags KourierFlags
+		expectedResult  error
+	}{{
+		name: "Kourier flags with the NodePort service",
+		kourierCMDFlags: KourierFlags{
+			ServiceType: "NodePort",
+			HTTPPort:    31080,
+			HTTPSPort:   31443,
+			Namespace:   "test-serving",
+		},
+		expectedResult: nil,
+	}, {
+		name: "Kourier flags with the LoadBalancer service",
+		kourierCMDFlags: KourierFlags{
+			ServiceType:    "LoadBalancer",
+			LoadBalancerIP: "10.0.0.10",
+			Namespace:      "test-serving",
+		},
+		expectedResult: nil,
+	}, {
+		name: "Kourier flags with the bootstrap file",
+		kourierCMDFlags: KourierFlags{
+			BootstrapFile: "testdata/envoy.yaml",
+			BootstrapName: "kourier-custom-bootstrap",
+			Namespace:     "test-serving",
+		},
+		expectedResult: nil,
+	}, {
+		name: "Kourier flags without any option",
+		kourierCMDFlags: KourierFlags{
+			Namespace: "test-serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify at least one of the following options: --service-type, --http-port, --https-port, --load-balancer-ip or --bootstrap-from-file."),
+	}, {
+		name: "Kourier flags with invalid service type",
+		kourierCMDFlags: KourierFlags{
+			ServiceType: "ExternalName",
+			Namespace:   "test-serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify the service type to one of the following values: ClusterIP, NodePort or LoadBalancer."),
+	}, {
+		name: "Kourier flags with ports for the LoadBalancer service",
+		kourierCMDFlags: KourierFlags{
+			ServiceType: "LoadBalancer",
+			HTTPPort:    31080,
+			Namespace:   "test-serving",
+		},
+		expectedResult: fmt.Errorf("The http and https ports are only available for the service type NodePort."),
+	}, {
+		name: "Kourier flags with invalid port",
+		kourierCMDFlags: KourierFlags{
+			ServiceType: "NodePort",
+			HTTPSPort:   70000,
+			Namespace:   "test-serving",
+		},
+		expectedResult: fmt.Errorf("The port 70000 is not a valid port number."),
+	}, {
+		name: "Kourier flags with load balancer IP for the NodePort service",
+		kourierCMDFlags: KourierFlags{
+			ServiceType:    "NodePort",
+			LoadBalancerIP: "10.0.0.10",
+			Namespace:      "test-serving",
+		},
+		expectedResult: fmt.Errorf("The load balancer IP is only available for the service type LoadBalancer."),
+	}, {
+		name: "Kourier flags with invalid load balancer IP",
+		kourierCMDFlags: KourierFlags{
+			ServiceType:    "LoadBalancer",
+			LoadBalancerIP: "10.0.0",
+			Namespace:      "test-serving",
+		},
+		expectedResult: fmt.Errorf("The load balancer IP 10.0.0 is not a valid IP address."),
+	}, {
+		name: "Kourier flags without the bootstrap ConfigMap name",
+		kourierCMDFlags: KourierFlags{
+			BootstrapFile: "testdata/envoy.yaml",
+			Namespace:     "test-serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify the name of the bootstrap ConfigMap."),
+	}, {
+		name: "Kourier flags without namespace",
+		kourierCMDFlags: KourierFlags{
+			ServiceType: "ClusterIP",
+		},
+		expectedResult: fmt.Errorf("You need to specify the namespace."),
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := validateKourierFlags(tt.kourierCMDFlags)
+			if tt.expectedResult == nil {
+				testingUtil.AssertEqual(t, result, nil)
+			} else {
+				testingUtil.AssertEqual(t, result.Error(), tt.expectedResult.Error())
+			}
+		})
+	}
+}
+
+func TestReadBootstrap(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		file        string
+		expectedErr bool
+	}{{
+		name: "Valid envoy bootstrap file",
+		file: "testdata/envoy.yaml",
+	}, {
+		name:        "File without a YAML document",
+		file:        "testdata/ca.pem",
+		expectedErr: true,
+	}, {
+		name:        "Missing file",
+		file:        "testdata/missing.yaml",
+		expectedErr: true,
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := readBootstrap(tt.file)
+			testingUtil.AssertEqual(t, err != nil, tt.expectedErr)
+		})
+	}
+}
+
+func TestGetOverlayYamlContentKourier(t *testing.T) {
+	for _, tt := range []struct {
+		name            string
+		kourierCMDFlags KourierFlags
+		expectedResult  string
+	}{{
+		name: "Kourier with the NodePort service and the bootstrap ConfigMap",
+		kourierCMDFlags: KourierFlags{
+			ServiceType:   "NodePort",
+			HTTPPort:      31080,
+			HTTPSPort:     31443,
+			BootstrapFile: "testdata/envoy.yaml",
+			BootstrapName: "kourier-custom-bootstrap",
+			Namespace:     "test-serving",
+		},
+		expectedResult: `#@ load("@ytt:overlay", "overlay")
+#@ load("@ytt:data", "data")
+
+#@overlay/match by=overlay.subset({"kind": "KnativeServing"}),expects=1
+---
+apiVersion: operator.knative.dev/v1beta1
+kind: KnativeServing
+metadata:
+  #@overlay/match missing_ok=True
+  namespace: #@ data.values.namespace
+#@overlay/match missing_ok=True
+spec:
+
+  #@overlay/match missing_ok=True
+  ingress:
+    #@overlay/match missing_ok=True
+    kourier:
+      #@overlay/match missing_ok=True
+      service-type: #@ data.values.serviceType
+      #@overlay/match missing_ok=True
+      http-port: #@ data.values.httpPort
+      #@overlay/match missing_ok=True
+      https-port: #@ data.values.httpsPort
+      #@overlay/match missing_ok=True
+      bootstrap-configmap: #@ data.values.bootstrapConfigMap`,
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := getOverlayYamlContentKourier(tt.kourierCMDFlags)
+			testingUtil.AssertEqual(t, result, tt.expectedResult)
+		})
+	}
+}
+
+func TestGetYamlValuesContentKourier(t *testing.T) {
+	for _, tt := range []struct {
+		name            string
+		kourierCMDFlags KourierFlags
+		expectedResult  string
+	}{{
+		name: "Kourier with the LoadBalancer service",
+		kourierCMDFlags: KourierFlags{
+			ServiceType:    "LoadBalancer",
+			LoadBalancerIP: "10.0.0.10",
+			Namespace:      "test-serving",
+		},
+		expectedResult: `#@data/values
+---
+namespace: test-serving
+serviceType: LoadBalancer
+loadBalancerIP: "10.0.0.10"`,
+	}, {
+		name: "Kourier with the bootstrap ConfigMap",
+		kourierCMDFlags: KourierFlags{
+			BootstrapFile: "testdata/envoy.yaml",
+			BootstrapName: "kourier-custom-bootstrap",
+			Namespace:     "test-serving",
+		},
+		expectedResult: `#@data/values
+---
+namespace: test-serving
+bootstrapConfigMap: kourier-custom-bootstrap`,
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := getYamlValuesContentKourier(tt.kourierCMDFlags)
+			testingUtil.AssertEqual(t, result, tt.expectedResult)
+		})
+	}
+}
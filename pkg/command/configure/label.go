@@ -16,6 +16,7 @@ package configure
 
 import (
 	_ "embed"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -41,7 +42,11 @@ func newDeploymentLabelCommand(p *pkg.OperatorParams) *cobra.Command {
 		Short: "Configure the labels for Knative Serving and Eventing deployments",
 		Example: `
   # Configure the labels for Knative Serving and Eventing deployments
-  kn operator configure labels --component eventing --deployName eventing-controller --key key --value value --namespace knative-eventing`,
+  kn operator configure labels --component eventing --deployName eventing-controller --key key --value value --namespace knative-eventing
+  # Configure the labels for all the deployments of Knative Serving
+  kn operator configure labels --component serving --all-deployments --key key --value value --namespace knative-serving
+  # Configure multiple labels for the deployment of Knative Serving at once
+  kn operator configure labels --component serving --deployName controller --set key1=value1 --set key2=value2 --namespace knative-serving`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := validateLabelsAnnotationsFlags(deploymentLabelCMDFlags); err != nil {
 				return err
@@ -53,7 +58,7 @@ func newDeploymentLabelCommand(p *pkg.OperatorParams) *cobra.Command {
 			}
 
 			fmt.Fprintf(cmd.OutOrStdout(), "The specified labels has been configured for the deployment %s in the deployment '%s'.\n",
-				deploymentLabelCMDFlags.DeployName, deploymentLabelCMDFlags.Namespace)
+				getDeploymentDescription(deploymentLabelCMDFlags), deploymentLabelCMDFlags.Namespace)
 			return nil
 		},
 	}
@@ -62,6 +67,9 @@ func newDeploymentLabelCommand(p *pkg.OperatorParams) *cobra.Command {
 	configureLabelsCmd.Flags().StringVar(&deploymentLabelCMDFlags.Value, "value", "", "The value of the data in the configmap")
 	configureLabelsCmd.Flags().StringVar(&deploymentLabelCMDFlags.DeployName, "deployName", "", "The flag to specify the deployment name")
 	configureLabelsCmd.Flags().StringVar(&deploymentLabelCMDFlags.ServiceName, "serviceName", "", "The flag to specify the service name")
+	configureLabelsCmd.Flags().BoolVar(&deploymentLabelCMDFlags.AllDeployments, "all-deployments", false, allDeploymentsUsage)
+	configureLabelsCmd.Flags().StringArrayVar(&deploymentLabelCMDFlags.Set, "set", []string{}, "The key value pair in the format of key=value. It can be specified multiple times.")
+	configureLabelsCmd.Flags().StringArrayVar(&deploymentLabelCMDFlags.SetFile, "set-file", []string{}, "The key and the path of the file to read the value from, in the format of key=path. It can be specified multiple times.")
 	configureLabelsCmd.Flags().StringVarP(&deploymentLabelCMDFlags.Component, "component", "c", "", "The flag to specify the component name")
 	configureLabelsCmd.Flags().StringVarP(&deploymentLabelCMDFlags.Namespace, "namespace", "n", "", "The namespace of the Knative Operator or the Knative component")
 
@@ -69,12 +77,16 @@ func newDeploymentLabelCommand(p *pkg.OperatorParams) *cobra.Command {
 }
 
 func validateKeyValuePairs(deploymentLabelCMDFlags common.KeyValueFlags) error {
-	if deploymentLabelCMDFlags.Key == "" {
+	batch := len(deploymentLabelCMDFlags.Set) > 0 || len(deploymentLabelCMDFlags.SetFile) > 0
+	if deploymentLabelCMDFlags.Key == "" && (!batch || deploymentLabelCMDFlags.Value != "") {
 		return fmt.Errorf("You need to specify the key.")
 	}
-	if deploymentLabelCMDFlags.Value == "" {
+	if deploymentLabelCMDFlags.Key != "" && deploymentLabelCMDFlags.Value == "" {
 		return fmt.Errorf("You need to specify the value.")
 	}
+	if err := common.ValidateSetFlags(deploymentLabelCMDFlags.Set, deploymentLabelCMDFlags.SetFile); err != nil {
+		return err
+	}
 	if deploymentLabelCMDFlags.Namespace == "" {
 		return fmt.Errorf("You need to specify the namespace.")
 	}
@@ -91,12 +103,51 @@ func validateLabelsAnnotationsFlags(deploymentLabelCMDFlags common.KeyValueFlags
 	if err := validateKeyValuePairs(deploymentLabelCMDFlags); err != nil {
 		return err
 	}
-	if deploymentLabelCMDFlags.DeployName == "" && deploymentLabelCMDFlags.ServiceName == "" {
+	if deploymentLabelCMDFlags.AllDeployments && deploymentLabelCMDFlags.ServiceName != "" {
+		return fmt.Errorf("You cannot specify the name of the service together with --all-deployments.")
+	}
+	if err := validateAllDeploymentsFlags(deploymentLabelCMDFlags.AllDeployments, deploymentLabelCMDFlags.DeployName); err != nil {
+		return err
+	}
+	if deploymentLabelCMDFlags.DeployName == "" && deploymentLabelCMDFlags.ServiceName == "" && !deploymentLabelCMDFlags.AllDeployments {
 		return fmt.Errorf("You need to specify the name of the deployment or the service.")
 	}
 	return nil
 }
 
+// getDeploymentDescription returns the name of the deployment, or all the deployments, to report the configuration
+func getDeploymentDescription(keyValuesCMDFlags common.KeyValueFlags) string {
+	if keyValuesCMDFlags.AllDeployments {
+		return "all the deployments"
+	}
+	return keyValuesCMDFlags.DeployName
+}
+
+// getKeyValueOverlays generates the overlay and the values for each key value pair of each target deployment
+func getKeyValueOverlays(keyValuesCMDFlags common.KeyValueFlags, component string, getOverlay func(common.KeyValueFlags) string,
+	p *pkg.OperatorParams) ([]common.OverlayValues, error) {
+	pairs, err := common.GetKeyValuePairs(keyValuesCMDFlags.Key, keyValuesCMDFlags.Value, keyValuesCMDFlags.Set, keyValuesCMDFlags.SetFile)
+	if err != nil {
+		return nil, err
+	}
+	deployNames, err := getTargetDeployments(keyValuesCMDFlags.AllDeployments, component, keyValuesCMDFlags.Namespace,
+		keyValuesCMDFlags.DeployName, p)
+	if err != nil {
+		return nil, err
+	}
+	overlays := []common.OverlayValues{}
+	for _, deployName := range deployNames {
+		for _, pair := range pairs {
+			flags := keyValuesCMDFlags
+			flags.DeployName = deployName
+			flags.Key = pair.Key
+			flags.Value = pair.Value
+			overlays = append(overlays, common.OverlayValues{Overlay: getOverlay(flags), Values: getYamlValuesContent(flags)})
+		}
+	}
+	return overlays, nil
+}
+
 func configureLabels(deploymentLabelCMDFlags common.KeyValueFlags, p *pkg.OperatorParams) error {
 	component := common.ServingComponent
 	if strings.EqualFold(deploymentLabelCMDFlags.Component, common.EventingComponent) {
@@ -107,12 +158,11 @@ func configureLabels(deploymentLabelCMDFlags common.KeyValueFlags, p *pkg.Operat
 		return err
 	}
 
-	overlayContent := getOverlayYamlContentLabel(deploymentLabelCMDFlags)
-	valuesYaml := getYamlValuesContent(deploymentLabelCMDFlags)
-	if err := common.ApplyManifests(yamlTemplateString, overlayContent, valuesYaml, p); err != nil {
+	overlays, err := getKeyValueOverlays(deploymentLabelCMDFlags, component, getOverlayYamlContentLabel, p)
+	if err != nil {
 		return err
 	}
-	return nil
+	return common.ApplyManifestsWithOverlays(yamlTemplateString, overlays, p)
 }
 
 func getOverlayYamlContentLabel(deploymentLabelCMDFlags common.KeyValueFlags) string {
@@ -132,7 +182,7 @@ func getLabelConfiguration(deploymentLabelCMDFlags common.KeyValueFlags) string
 	resourceArray = append(resourceArray, tag)
 
 	if deploymentLabelCMDFlags.DeployName != "" {
-		field := fmt.Sprintf("%s%s:", common.Spaces(2), "deployments")
+		field := fmt.Sprintf("%s%s:", common.Spaces(2), "workloads")
 		resourceArray = append(resourceArray, field)
 	} else {
 		field := fmt.Sprintf("%s%s:", common.Spaces(2), "services")
@@ -180,7 +230,8 @@ func getYamlValuesContent(deploymentLabelCMDFlags common.KeyValueFlags) string {
 		contentArray = append(contentArray, serviceName)
 	}
 
-	value := fmt.Sprintf("value: %s", deploymentLabelCMDFlags.Value)
-	contentArray = append(contentArray, value)
+	// Quote the value, so that it is always a string, even if it is a number, a boolean or has multiple lines
+	value, _ := json.Marshal(deploymentLabelCMDFlags.Value)
+	contentArray = append(contentArray, fmt.Sprintf("value: %s", value))
 	return strings.Join(contentArray, "\n")
 }
@@ -113,6 +113,66 @@ func TestValidateLabelsAnnotationsFlags(t *testing.T) {
 			DeployName: "eventing-controller",
 		},
 		expectedResult: fmt.Errorf("You need to specify the value."),
+	}, {
+		name: "Knative Serving with all the deployments",
+		deploymentLabelCMDFlags: common.KeyValueFlags{
+			Key:            "test-key",
+			Value:          "test-value",
+			Component:      "serving",
+			Namespace:      "test-serving",
+			AllDeployments: true,
+		},
+		expectedResult: nil,
+	}, {
+		name: "Knative Serving with all the deployments and the deployment name",
+		deploymentLabelCMDFlags: common.KeyValueFlags{
+			Key:            "test-key",
+			Value:          "test-value",
+			Component:      "serving",
+			Namespace:      "test-serving",
+			DeployName:     "controller",
+			AllDeployments: true,
+		},
+		expectedResult: fmt.Errorf("You cannot specify the name of the deployment together with --all-deployments."),
+	}, {
+		name: "Knative Serving with all the deployments and the service name",
+		deploymentLabelCMDFlags: common.KeyValueFlags{
+			Key:            "test-key",
+			Value:          "test-value",
+			Component:      "serving",
+			Namespace:      "test-serving",
+			ServiceName:    "controller",
+			AllDeployments: true,
+		},
+		expectedResult: fmt.Errorf("You cannot specify the name of the service together with --all-deployments."),
+	}, {
+		name: "Knative Serving with multiple key value pairs",
+		deploymentLabelCMDFlags: common.KeyValueFlags{
+			Set:        []string{"key1=value1", "key2=value2"},
+			Component:  "serving",
+			Namespace:  "test-serving",
+			DeployName: "controller",
+		},
+		expectedResult: nil,
+	}, {
+		name: "Knative Serving with the value and multiple key value pairs",
+		deploymentLabelCMDFlags: common.KeyValueFlags{
+			Value:      "test-value",
+			Set:        []string{"key1=value1"},
+			Component:  "serving",
+			Namespace:  "test-serving",
+			DeployName: "controller",
+		},
+		expectedResult: fmt.Errorf("You need to specify the key."),
+	}, {
+		name: "Knative Serving with the invalid key value pair",
+		deploymentLabelCMDFlags: common.KeyValueFlags{
+			Set:        []string{"key1"},
+			Component:  "serving",
+			Namespace:  "test-serving",
+			DeployName: "controller",
+		},
+		expectedResult: fmt.Errorf("The value key1 of --set should be in the format of key=value."),
 	}} {
 		t.Run(tt.name, func(t *testing.T) {
 			result := validateLabelsAnnotationsFlags(tt.deploymentLabelCMDFlags)
@@ -153,7 +213,7 @@ metadata:
 spec:
 
   #@overlay/match missing_ok=True
-  deployments:
+  workloads:
   #@overlay/match by="name",missing_ok=True
   - name: #@ data.values.deployName
     #@overlay/match missing_ok=True
@@ -186,7 +246,7 @@ func TestGetYamlValuesContentLabels(t *testing.T) {
 ---
 namespace: test-eventing
 deployName: network
-value: test-value`,
+value: "test-value"`,
 	}, {
 		name: "Knative Eventing with service name",
 		deploymentLabelCMDFlags: common.KeyValueFlags{
@@ -200,7 +260,7 @@ value: test-value`,
 ---
 namespace: test-eventing
 serviceName: network
-value: test-value`,
+value: "test-value"`,
 	}, {
 		name: "Knative Serving",
 		deploymentLabelCMDFlags: common.KeyValueFlags{
@@ -214,7 +274,7 @@ value: test-value`,
 ---
 namespace: test-serving
 deployName: network
-value: test-value`,
+value: "test-value"`,
 	}} {
 		t.Run(tt.name, func(t *testing.T) {
 			result := getYamlValuesContent(tt.deploymentLabelCMDFlags)
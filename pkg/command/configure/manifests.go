@@ -16,14 +16,17 @@ package configure
 
 import (
 	_ "embed"
+	"encoding/json"
 	"fmt"
 	"strings"
 
 	"github.com/spf13/cobra"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc" // from https://github.com/kubernetes/client-go/issues/345
 
 	"knative.dev/kn-plugin-operator/pkg"
 	"knative.dev/kn-plugin-operator/pkg/command/common"
+	"knative.dev/operator/pkg/apis/operator/base"
 )
 
 //go:embed overlay/ks_custom_manifests.yaml
@@ -33,12 +36,17 @@ var servingManifestsOverlay string
 var eventingManifestsOverlay string
 
 type manifestsFlags struct {
-	File              string
+	Files             []string
+	Name              string
 	OperatorNamespace string
 	Namespace         string
 	Component         string
 	Overwrite         bool
 	Accessible        bool
+	URLs              []string
+	Replace           bool
+	List              bool
+	Force             bool
 }
 
 var manifestsCMDFlags manifestsFlags
@@ -48,37 +56,97 @@ func newManifestsCommand(p *pkg.OperatorParams) *cobra.Command {
 	var configureManifestsCmd = &cobra.Command{
 		Use:   "manifests",
 		Short: "Configure the custom manifests for Knative",
+		Long: `Configure the custom manifests for Knative.
+
+The manifests are added to spec.additionalManifests by default. With --replace, they are configured in spec.manifests
+instead, replacing the manifests shipped with the Knative Operator.
+
+The local files and directories are stored as named custom manifests in the ConfigMaps config-manifest,
+config-manifest-1 and so on in the namespace of the Knative Operator, which are mounted into the operator. The custom
+manifests are split across the ConfigMaps, when they approach the size limit of a ConfigMap.
+
+The local files are parsed into Kubernetes objects before they are stored. The kinds unknown to Kubernetes and Knative,
+and not defined by a CustomResourceDefinition in the same files, are rejected unless --force is specified. The
+cluster-scoped objects and the objects in other namespaces than the one of the Knative component are reported as
+warnings. The objects added by the custom manifests are listed, leaving out the objects already in the manifests at
+the URLs in spec.manifests, if they can be downloaded. The manifests shipped inside the image of the Knative Operator
+cannot be read, so all the objects are listed, if spec.manifests is not configured.`,
 		Example: `
   # Configure the custom manifests for Knative
-  kn operator configure manifests --component eventing --namespace knative-eventing --operatorNamespace default --file filePath`,
+  kn operator configure manifests --component eventing --namespace knative-eventing --operatorNamespace default --file filePath
+  # Configure the custom manifests in the files and the directory under the name monitoring for Knative Serving
+  kn operator configure manifests --component serving --namespace knative-serving --operatorNamespace knative-operator --name monitoring --file dashboards.yaml --file alerts/
+  # Replace the manifests of Knative Serving with the manifests at the URLs
+  kn operator configure manifests --component serving --namespace knative-serving --replace --url https://example.com/serving-crds.yaml --url https://example.com/serving-core.yaml
+  # List the manifests configured for Knative Serving
+  kn operator configure manifests --component serving --namespace knative-serving --list`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := validateManifestsFlags(manifestsCMDFlags); err != nil {
 				return err
 			}
 
-			err := configureManifests(manifestsCMDFlags, p)
+			if manifestsCMDFlags.List {
+				return listManifests(cmd, manifestsCMDFlags, p)
+			}
+
+			objects, warnings, err := configureManifests(manifestsCMDFlags, p)
+			for _, warning := range warnings {
+				fmt.Fprintf(cmd.OutOrStdout(), "Warning: %s\n", warning)
+			}
 			if err != nil {
 				return err
 			}
 
+			if len(objects) > 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), "The custom manifests add the following objects:\n%s", common.FormatManifestObjects(objects))
+			}
 			fmt.Fprintf(cmd.OutOrStdout(), "The specified custom manifests has been configured.\n")
 			return nil
 		},
 	}
 
 	configureManifestsCmd.Flags().BoolVar(&manifestsCMDFlags.Overwrite, "overwrite", false, "The flag to specify the mode of the custom manifests")
-	configureManifestsCmd.Flags().StringVar(&manifestsCMDFlags.File, "file", "", "The path to the local file with the custom manifests")
-	configureManifestsCmd.Flags().StringVar(&manifestsCMDFlags.OperatorNamespace, "operatorNamespace", "default", "The flag to specify the configmap name")
+	configureManifestsCmd.Flags().StringArrayVar(&manifestsCMDFlags.Files, "file", []string{}, "The path to the local file or directory with the custom manifests. It can be specified multiple times.")
+	configureManifestsCmd.Flags().StringVar(&manifestsCMDFlags.Name, "name", "", "The name of the custom manifests in the local files. It is custom-manifests by default.")
+	configureManifestsCmd.Flags().StringVar(&manifestsCMDFlags.OperatorNamespace, "operatorNamespace", "default", "The namespace of the Knative Operator to store the custom manifests in")
 	configureManifestsCmd.Flags().StringVarP(&manifestsCMDFlags.Component, "component", "c", "", "The flag to specify the component name")
 	configureManifestsCmd.Flags().StringVarP(&manifestsCMDFlags.Namespace, "namespace", "n", "", "The namespace of the Knative Operator or the Knative component")
 	configureManifestsCmd.Flags().BoolVar(&manifestsCMDFlags.Accessible, "accessible", false, "The flag to indicate wehther the link is accessible by Knative in the Kubernetes cluster")
+	configureManifestsCmd.Flags().StringArrayVar(&manifestsCMDFlags.URLs, "url", []string{}, "The URL of the manifests accessible by Knative in the Kubernetes cluster. It can be specified multiple times.")
+	configureManifestsCmd.Flags().BoolVar(&manifestsCMDFlags.Replace, "replace", false, "The flag to configure the manifests in spec.manifests instead of spec.additionalManifests")
+	configureManifestsCmd.Flags().BoolVar(&manifestsCMDFlags.List, "list", false, "The flag to list the configured manifests")
+	configureManifestsCmd.Flags().BoolVar(&manifestsCMDFlags.Force, "force", false, "The flag to store the custom manifests with unknown kinds, reporting them as warnings instead of errors")
 
 	return configureManifestsCmd
 }
 
 func validateManifestsFlags(manifestsCMDFlags manifestsFlags) error {
-	if manifestsCMDFlags.File == "" {
-		return fmt.Errorf("You need to specify the local path of the file containing the custom manifests.")
+	if manifestsCMDFlags.List {
+		if len(manifestsCMDFlags.Files) > 0 || len(manifestsCMDFlags.URLs) > 0 {
+			return fmt.Errorf("You cannot specify --file or --url together with --list.")
+		}
+	} else if len(manifestsCMDFlags.Files) == 0 && len(manifestsCMDFlags.URLs) == 0 {
+		return fmt.Errorf("You need to specify the local path of the file containing the custom manifests, or the URLs of the manifests.")
+	} else if len(manifestsCMDFlags.Files) > 0 && len(manifestsCMDFlags.URLs) > 0 {
+		return fmt.Errorf("You cannot specify --file and --url at the same time.")
+	}
+	for _, file := range manifestsCMDFlags.Files {
+		if strings.TrimSpace(file) == "" {
+			return fmt.Errorf("The path of the custom manifests cannot be empty.")
+		}
+	}
+	for _, url := range manifestsCMDFlags.URLs {
+		if strings.TrimSpace(url) == "" {
+			return fmt.Errorf("The URL of the manifests cannot be empty.")
+		}
+	}
+	if manifestsCMDFlags.Name != "" {
+		if len(manifestsCMDFlags.Files) == 0 || manifestsCMDFlags.Accessible {
+			return fmt.Errorf("You can only specify --name for the custom manifests in the local files.")
+		}
+		if err := common.ValidateCustomManifestsName(manifestsCMDFlags.Name); err != nil {
+			return err
+		}
 	}
 	if manifestsCMDFlags.Namespace == "" {
 		return fmt.Errorf("You need to specify the namespace for the Knative component.")
@@ -89,57 +157,203 @@ func validateManifestsFlags(manifestsCMDFlags manifestsFlags) error {
 	return nil
 }
 
-func UpdateOperatorForCustomManifests(manifestsCMDFlags manifestsFlags, p *pkg.OperatorParams) error {
+// checkCustomManifests parses the custom manifests into Kubernetes objects, and returns the objects with the warnings.
+// The objects of unknown kinds are errors, unless force is true.
+func checkCustomManifests(data, namespace string, force bool) ([]common.ManifestObject, []string, error) {
+	result, err := common.CheckManifestObjects(data, namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(result.Problems) > 0 && !force {
+		return nil, result.Warnings, fmt.Errorf("%s Use --force to store the custom manifests anyway.", strings.Join(result.Problems, " "))
+	}
+	return result.Objects, append(result.Problems, result.Warnings...), nil
+}
+
+func UpdateOperatorForCustomManifests(manifestsCMDFlags manifestsFlags, p *pkg.OperatorParams) ([]common.ManifestObject, []string, error) {
+	data, err := common.ReadManifestsFiles(manifestsCMDFlags.Files)
+	if err != nil {
+		return nil, nil, err
+	}
+	objects, warnings, err := checkCustomManifests(data, manifestsCMDFlags.Namespace, manifestsCMDFlags.Force)
+	if err != nil {
+		return nil, warnings, err
+	}
+	if !manifestsCMDFlags.Replace {
+		bundled, bundledWarnings, err := getBundledManifestObjects(manifestsCMDFlags, p)
+		warnings = append(warnings, bundledWarnings...)
+		if err != nil {
+			return nil, warnings, err
+		}
+		objects = common.SubtractManifestObjects(objects, bundled)
+	}
+
 	kubeClient, err := p.NewKubeClient()
 	if err != nil {
-		return fmt.Errorf("cannot get source cluster kube config, please use --kubeconfig or export environment variable KUBECONFIG to set\n")
+		return nil, warnings, fmt.Errorf("cannot get source cluster kube config, please use --kubeconfig or export environment variable KUBECONFIG to set\n")
 	}
 
 	kubeResource := common.KubeResource{
 		KubeClient: kubeClient,
 	}
+	name := manifestsCMDFlags.Name
+	if name == "" {
+		name = common.DefaultCustomManifestsName
+	}
 
-	data, err := common.ReadFile(manifestsCMDFlags.File)
+	// The ConfigMaps are stored in the namespace of the operator, since they can only be mounted from there
+	customManifests, err := kubeResource.GetCustomManifests(manifestsCMDFlags.OperatorNamespace)
 	if err != nil {
-		return err
+		return nil, warnings, err
+	}
+	customManifests = common.SetCustomManifests(customManifests, name, data, manifestsCMDFlags.Overwrite)
+	cmNames, err := kubeResource.SaveCustomManifests(manifestsCMDFlags.OperatorNamespace, customManifests)
+	if err != nil {
+		return nil, warnings, err
 	}
 
-	if err = kubeResource.CreateOrUpdateConfigMap(common.ConfigMapName, manifestsCMDFlags.Namespace, data, manifestsCMDFlags.Overwrite); err != nil {
-		return err
+	if err = kubeResource.UpdateOperatorDeployment(common.KnativeOperatorName, manifestsCMDFlags.OperatorNamespace, cmNames); err != nil {
+		return nil, warnings, err
 	}
 
-	if err = kubeResource.UpdateOperatorDeployment(common.KnativeOperatorName, manifestsCMDFlags.OperatorNamespace); err != nil {
-		return err
+	return objects, warnings, nil
+}
+
+// getBundledManifestObjects returns the objects in the manifests at the URLs in spec.manifests, which replace the
+// manifests shipped with the Knative Operator. The manifests, which cannot be downloaded, are reported as warnings.
+func getBundledManifestObjects(manifestsCMDFlags manifestsFlags, p *pkg.OperatorParams) ([]common.ManifestObject, []string, error) {
+	ksCR, err := common.GetKnativeOperatorCR(p)
+	if err != nil {
+		return nil, nil, err
+	}
+	commonSpec, err := ksCR.GetCommonSpec(getManifestsComponent(manifestsCMDFlags), manifestsCMDFlags.Namespace)
+	if apierrs.IsNotFound(err) {
+		return nil, nil, nil
+	} else if err != nil {
+		return nil, nil, err
 	}
 
-	return nil
+	objects := []common.ManifestObject{}
+	warnings := []string{}
+	for _, manifest := range commonSpec.Manifests {
+		data, err := common.DownloadFile(manifest.Url)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("The manifests at %s cannot be downloaded to compare the objects with: %v", manifest.Url, err))
+			continue
+		}
+		result, err := common.CheckManifestObjects(data, manifestsCMDFlags.Namespace)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("The manifests at %s cannot be parsed to compare the objects with: %v", manifest.Url, err))
+			continue
+		}
+		objects = append(objects, result.Objects...)
+	}
+	return objects, warnings, nil
 }
 
-func configureManifests(manifestsCMDFlags manifestsFlags, p *pkg.OperatorParams) error {
-	if !manifestsCMDFlags.Accessible {
-		if err := UpdateOperatorForCustomManifests(manifestsCMDFlags, p); err != nil {
-			return err
+func configureManifests(manifestsCMDFlags manifestsFlags, p *pkg.OperatorParams) ([]common.ManifestObject, []string, error) {
+	var objects []common.ManifestObject
+	var warnings []string
+	if !manifestsCMDFlags.Accessible && len(manifestsCMDFlags.URLs) == 0 {
+		var err error
+		if objects, warnings, err = UpdateOperatorForCustomManifests(manifestsCMDFlags, p); err != nil {
+			return nil, warnings, err
 		}
 	}
 
 	// Update the custom resource
-	component := common.ServingComponent
+	yamlTemplateString, err := common.GenerateOperatorCRString(getManifestsComponent(manifestsCMDFlags), manifestsCMDFlags.Namespace, p)
+	if err != nil {
+		return nil, warnings, err
+	}
+
+	overlayContent := getOverlayYamlContentManifest(manifestsCMDFlags)
+	valuesYaml, err := getYamlValuesContentManifests(manifestsCMDFlags)
+	if err != nil {
+		return nil, warnings, err
+	}
+	if err = common.ApplyManifests(yamlTemplateString, overlayContent, valuesYaml, p); err != nil {
+		return nil, warnings, err
+	}
+	return objects, warnings, nil
+}
+
+func getManifestsComponent(manifestsCMDFlags manifestsFlags) string {
 	if strings.EqualFold(manifestsCMDFlags.Component, common.EventingComponent) {
-		component = common.EventingComponent
+		return common.EventingComponent
+	}
+	return common.ServingComponent
+}
+
+// getManifestsPaths returns the links of the manifests to configure in the custom resource
+func getManifestsPaths(manifestsCMDFlags manifestsFlags) []string {
+	if len(manifestsCMDFlags.URLs) > 0 {
+		return manifestsCMDFlags.URLs
 	}
-	yamlTemplateString, err := common.GenerateOperatorCRString(component, manifestsCMDFlags.Namespace, p)
+	if manifestsCMDFlags.Accessible {
+		return manifestsCMDFlags.Files
+	}
+	return []string{common.MountPath}
+}
+
+func listManifests(cmd *cobra.Command, manifestsCMDFlags manifestsFlags, p *pkg.OperatorParams) error {
+	ksCR, err := common.GetKnativeOperatorCR(p)
+	if err != nil {
+		return err
+	}
+	commonSpec, err := ksCR.GetCommonSpec(getManifestsComponent(manifestsCMDFlags), manifestsCMDFlags.Namespace)
 	if err != nil {
 		return err
 	}
 
-	overlayContent := getOverlayYamlContentManifest(manifestsCMDFlags)
-	valuesYaml := getYamlValuesContentManifests(manifestsCMDFlags)
-	if err = common.ApplyManifests(yamlTemplateString, overlayContent, valuesYaml, p); err != nil {
+	kubeClient, err := p.NewKubeClient()
+	if err != nil {
+		return fmt.Errorf("cannot get source cluster kube config, please use --kubeconfig or export environment variable KUBECONFIG to set\n")
+	}
+	kubeResource := common.KubeResource{
+		KubeClient: kubeClient,
+	}
+	customManifests, err := kubeResource.GetCustomManifests(manifestsCMDFlags.OperatorNamespace)
+	if err != nil {
 		return err
 	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "%s", formatManifests(commonSpec.Manifests, commonSpec.AdditionalManifests))
+	fmt.Fprintf(cmd.OutOrStdout(), "%s", formatCustomManifests(customManifests, manifestsCMDFlags.OperatorNamespace))
 	return nil
 }
 
+// formatCustomManifests returns the names and the number of documents of the custom manifests stored for the operator
+func formatCustomManifests(customManifests []common.CustomManifests, operatorNamespace string) string {
+	if len(customManifests) == 0 {
+		return fmt.Sprintf("customManifests in the namespace '%s': []\n", operatorNamespace)
+	}
+	contentArray := []string{fmt.Sprintf("customManifests in the namespace '%s':", operatorNamespace)}
+	for _, manifest := range customManifests {
+		contentArray = append(contentArray, fmt.Sprintf("- %s (%d documents, %d bytes)", manifest.Name,
+			len(common.SplitManifestsDocuments(manifest.Data)), len(manifest.Data)))
+	}
+	return fmt.Sprintf("%s\n", strings.Join(contentArray, "\n"))
+}
+
+func formatManifests(manifests, additionalManifests []base.Manifest) string {
+	contentArray := []string{}
+	for _, section := range []struct {
+		name      string
+		manifests []base.Manifest
+	}{{"manifests", manifests}, {"additionalManifests", additionalManifests}} {
+		if len(section.manifests) == 0 {
+			contentArray = append(contentArray, fmt.Sprintf("%s: []", section.name))
+			continue
+		}
+		contentArray = append(contentArray, fmt.Sprintf("%s:", section.name))
+		for _, manifest := range section.manifests {
+			contentArray = append(contentArray, fmt.Sprintf("- %s", manifest.Url))
+		}
+	}
+	return fmt.Sprintf("%s\n", strings.Join(contentArray, "\n"))
+}
+
 func getOverlayYamlContentManifest(manifestsCMDFlags manifestsFlags) string {
 	baseOverlayContent := servingManifestsOverlay
 	if strings.EqualFold(manifestsCMDFlags.Component, common.EventingComponent) {
@@ -159,28 +373,34 @@ func getManifestsConfiguration(manifestsCMDFlags manifestsFlags) string {
 		resourceArray = append(resourceArray, tag)
 	}
 
-	field := fmt.Sprintf("%s%s:", common.Spaces(2), "additionalManifests")
+	fieldName := "additionalManifests"
+	if manifestsCMDFlags.Replace {
+		fieldName = "manifests"
+	}
+	field := fmt.Sprintf("%s%s:", common.Spaces(2), fieldName)
 	resourceArray = append(resourceArray, field)
 
-	tag = fmt.Sprintf("%s%s", common.Spaces(2), common.FieldByName("URL"))
-	resourceArray = append(resourceArray, tag)
+	for i := range getManifestsPaths(manifestsCMDFlags) {
+		tag = fmt.Sprintf("%s%s", common.Spaces(2), common.FieldByName("URL"))
+		resourceArray = append(resourceArray, tag)
 
-	field = fmt.Sprintf("%s- %s: %s", common.Spaces(2), "URL", "#@ data.values.manifestsPath")
-	resourceArray = append(resourceArray, field)
+		field = fmt.Sprintf("%s- %s: #@ data.values.manifestsPaths[%d]", common.Spaces(2), "URL", i)
+		resourceArray = append(resourceArray, field)
+	}
 
 	return strings.Join(resourceArray, "\n")
 }
 
-func getYamlValuesContentManifests(manifestsCMDFlags manifestsFlags) string {
+func getYamlValuesContentManifests(manifestsCMDFlags manifestsFlags) (string, error) {
 	contentArray := []string{}
 	header := "#@data/values\n---"
 	contentArray = append(contentArray, header)
 	namespace := fmt.Sprintf("namespace: %s", manifestsCMDFlags.Namespace)
 	contentArray = append(contentArray, namespace)
-	deployName := fmt.Sprintf("manifestsPath: %s", common.MountPath)
-	if manifestsCMDFlags.Accessible {
-		deployName = fmt.Sprintf("manifestsPath: %s", manifestsCMDFlags.File)
+	paths, err := json.Marshal(getManifestsPaths(manifestsCMDFlags))
+	if err != nil {
+		return "", err
 	}
-	contentArray = append(contentArray, deployName)
-	return strings.Join(contentArray, "\n")
+	contentArray = append(contentArray, fmt.Sprintf("manifestsPaths: %s", paths))
+	return strings.Join(contentArray, "\n"), nil
 }
@@ -18,7 +18,9 @@ import (
 	"fmt"
 	"testing"
 
+	"knative.dev/kn-plugin-operator/pkg/command/common"
 	"knative.dev/kn-plugin-operator/pkg/command/testingUtil"
+	"knative.dev/operator/pkg/apis/operator/base"
 )
 
 func TestValidateManifestsFlags(t *testing.T) {
@@ -33,11 +35,54 @@ func TestValidateManifestsFlags(t *testing.T) {
 			Namespace:         "test-eventing",
 			OperatorNamespace: "eventing-controller",
 		},
-		expectedResult: fmt.Errorf("You need to specify the local path of the file containing the custom manifests."),
+		expectedResult: fmt.Errorf("You need to specify the local path of the file containing the custom manifests, or the URLs of the manifests."),
+	}, {
+		name: "Knative Serving with URLs",
+		manifestsCMDFlags: manifestsFlags{
+			URLs:      []string{"https://example.com/serving-core.yaml"},
+			Replace:   true,
+			Component: "serving",
+			Namespace: "knative-serving",
+		},
+		expectedResult: nil,
+	}, {
+		name: "Knative Serving with both file and URLs",
+		manifestsCMDFlags: manifestsFlags{
+			Files:     []string{"file.yaml"},
+			URLs:      []string{"https://example.com/serving-core.yaml"},
+			Component: "serving",
+			Namespace: "knative-serving",
+		},
+		expectedResult: fmt.Errorf("You cannot specify --file and --url at the same time."),
+	}, {
+		name: "Knative Serving with empty URL",
+		manifestsCMDFlags: manifestsFlags{
+			URLs:      []string{" "},
+			Component: "serving",
+			Namespace: "knative-serving",
+		},
+		expectedResult: fmt.Errorf("The URL of the manifests cannot be empty."),
+	}, {
+		name: "Knative Serving with list",
+		manifestsCMDFlags: manifestsFlags{
+			List:      true,
+			Component: "serving",
+			Namespace: "knative-serving",
+		},
+		expectedResult: nil,
+	}, {
+		name: "Knative Serving with list and URLs",
+		manifestsCMDFlags: manifestsFlags{
+			List:      true,
+			URLs:      []string{"https://example.com/serving-core.yaml"},
+			Component: "serving",
+			Namespace: "knative-serving",
+		},
+		expectedResult: fmt.Errorf("You cannot specify --file or --url together with --list."),
 	}, {
 		name: "Knative Eventing",
 		manifestsCMDFlags: manifestsFlags{
-			File:              "test-file.yaml",
+			Files:             []string{"test-file.yaml"},
 			Component:         "eventing",
 			Namespace:         "test-eventing",
 			OperatorNamespace: "eventing-controller",
@@ -46,7 +91,7 @@ func TestValidateManifestsFlags(t *testing.T) {
 	}, {
 		name: "Knative Eventing with no namespace",
 		manifestsCMDFlags: manifestsFlags{
-			File:              "file.yaml",
+			Files:             []string{"file.yaml"},
 			Component:         "eventing",
 			OperatorNamespace: "eventing-controller",
 		},
@@ -54,12 +99,40 @@ func TestValidateManifestsFlags(t *testing.T) {
 	}, {
 		name: "Knative Eventing with invalid component",
 		manifestsCMDFlags: manifestsFlags{
-			File:              "file.yaml",
+			Files:             []string{"file.yaml"},
 			Namespace:         "test",
 			Component:         "test",
 			OperatorNamespace: "eventing-controller",
 		},
 		expectedResult: fmt.Errorf("You need to specify the component for Knative: serving or eventing."),
+	}, {
+		name: "Knative Serving with named custom manifests in multiple files",
+		manifestsCMDFlags: manifestsFlags{
+			Files:             []string{"dashboards.yaml", "alerts/"},
+			Name:              "monitoring",
+			Namespace:         "test-serving",
+			Component:         "serving",
+			OperatorNamespace: "knative-operator",
+		},
+		expectedResult: nil,
+	}, {
+		name: "Knative Serving with the name for the URLs",
+		manifestsCMDFlags: manifestsFlags{
+			URLs:      []string{"https://example.com/custom.yaml"},
+			Name:      "monitoring",
+			Namespace: "test-serving",
+			Component: "serving",
+		},
+		expectedResult: fmt.Errorf("You can only specify --name for the custom manifests in the local files."),
+	}, {
+		name: "Knative Serving with invalid name",
+		manifestsCMDFlags: manifestsFlags{
+			Files:     []string{"dashboards.yaml"},
+			Name:      "Monitoring",
+			Namespace: "test-serving",
+			Component: "serving",
+		},
+		expectedResult: fmt.Errorf("The name Monitoring of the custom manifests should consist of lower case alphanumeric characters or '-', and start and end with an alphanumeric character."),
 	}} {
 		t.Run(tt.name, func(t *testing.T) {
 			result := validateManifestsFlags(tt.manifestsCMDFlags)
@@ -86,11 +159,11 @@ func TestGetYamlValuesContentManifests(t *testing.T) {
 		expectedResult: `#@data/values
 ---
 namespace: test-eventing
-manifestsPath: /knative-custom-manifest`,
+manifestsPaths: ["/knative-custom-manifest"]`,
 	}, {
 		name: "Knative Eventing with accessible file",
 		manifestsCMDFlags: manifestsFlags{
-			File:       "public-file-link",
+			Files:      []string{"public-file-link"},
 			Component:  "eventing",
 			Namespace:  "test-eventing",
 			Accessible: true,
@@ -98,10 +171,22 @@ manifestsPath: /knative-custom-manifest`,
 		expectedResult: `#@data/values
 ---
 namespace: test-eventing
-manifestsPath: public-file-link`,
+manifestsPaths: ["public-file-link"]`,
+	}, {
+		name: "Knative Serving with URLs",
+		manifestsCMDFlags: manifestsFlags{
+			URLs:      []string{"https://example.com/serving-crds.yaml", "https://example.com/serving-core.yaml"},
+			Component: "serving",
+			Namespace: "knative-serving",
+		},
+		expectedResult: `#@data/values
+---
+namespace: knative-serving
+manifestsPaths: ["https://example.com/serving-crds.yaml","https://example.com/serving-core.yaml"]`,
 	}} {
 		t.Run(tt.name, func(t *testing.T) {
-			result := getYamlValuesContentManifests(tt.manifestsCMDFlags)
+			result, err := getYamlValuesContentManifests(tt.manifestsCMDFlags)
+			testingUtil.AssertEqual(t, err, nil)
 			testingUtil.AssertEqual(t, result, tt.expectedResult)
 		})
 	}
@@ -115,7 +200,7 @@ func TestGetOverlayYamlContentManifests(t *testing.T) {
 	}{{
 		name: "Knative Eventing",
 		manifestsCMDFlags: manifestsFlags{
-			File:      "local-test-file",
+			Files:     []string{"local-test-file"},
 			Component: "eventing",
 			Namespace: "test-eventing",
 		},
@@ -135,11 +220,11 @@ spec:
   #@overlay/match missing_ok=True
   additionalManifests:
   #@overlay/match by="URL",missing_ok=True
-  - URL: #@ data.values.manifestsPath`,
+  - URL: #@ data.values.manifestsPaths[0]`,
 	}, {
 		name: "Knative Serving with overwrite mode",
 		manifestsCMDFlags: manifestsFlags{
-			File:      "local-test-file",
+			Files:     []string{"local-test-file"},
 			Component: "serving",
 			Namespace: "test-eventing",
 			Overwrite: true,
@@ -161,7 +246,34 @@ spec:
   #@overlay/replace or_add=True
   additionalManifests:
   #@overlay/match by="URL",missing_ok=True
-  - URL: #@ data.values.manifestsPath`,
+  - URL: #@ data.values.manifestsPaths[0]`,
+	}, {
+		name: "Knative Serving with URLs replacing the manifests",
+		manifestsCMDFlags: manifestsFlags{
+			URLs:      []string{"https://example.com/serving-crds.yaml", "https://example.com/serving-core.yaml"},
+			Component: "serving",
+			Namespace: "knative-serving",
+			Replace:   true,
+		},
+		expectedResult: `#@ load("@ytt:overlay", "overlay")
+#@ load("@ytt:data", "data")
+
+#@overlay/match by=overlay.subset({"kind": "KnativeServing"}),expects=1
+---
+apiVersion: operator.knative.dev/v1beta1
+kind: KnativeServing
+metadata:
+  #@overlay/match missing_ok=True
+  namespace: #@ data.values.namespace
+#@overlay/match missing_ok=True
+spec:
+
+  #@overlay/match missing_ok=True
+  manifests:
+  #@overlay/match by="URL",missing_ok=True
+  - URL: #@ data.values.manifestsPaths[0]
+  #@overlay/match by="URL",missing_ok=True
+  - URL: #@ data.values.manifestsPaths[1]`,
 	}} {
 		t.Run(tt.name, func(t *testing.T) {
 			result := getOverlayYamlContentManifest(tt.manifestsCMDFlags)
@@ -169,3 +281,86 @@ spec:
 		})
 	}
 }
+
+func TestFormatManifests(t *testing.T) {
+	for _, tt := range []struct {
+		name                string
+		manifests           []base.Manifest
+		additionalManifests []base.Manifest
+		expectedResult      string
+	}{{
+		name:           "No manifests",
+		expectedResult: "manifests: []\nadditionalManifests: []\n",
+	}, {
+		name:                "Manifests and additional manifests",
+		manifests:           []base.Manifest{{Url: "https://example.com/serving-crds.yaml"}, {Url: "https://example.com/serving-core.yaml"}},
+		additionalManifests: []base.Manifest{{Url: "/knative-custom-manifest"}},
+		expectedResult: `manifests:
+- https://example.com/serving-crds.yaml
+- https://example.com/serving-core.yaml
+additionalManifests:
+- /knative-custom-manifest
+`,
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := formatManifests(tt.manifests, tt.additionalManifests)
+			testingUtil.AssertEqual(t, result, tt.expectedResult)
+		})
+	}
+}
+
+func TestFormatCustomManifests(t *testing.T) {
+	testingUtil.AssertEqual(t, formatCustomManifests(nil, "default"), "customManifests in the namespace 'default': []\n")
+	testingUtil.AssertEqual(t, formatCustomManifests([]common.CustomManifests{{
+		Name: "monitoring",
+		Data: "a: 1\n---\nb: 2\n",
+	}}, "knative-operator"), `customManifests in the namespace 'knative-operator':
+- monitoring (2 documents, 14 bytes)
+`)
+}
+
+func TestCheckCustomManifests(t *testing.T) {
+	data := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: dashboards
+  namespace: knative-serving
+---
+apiVersion: monitoring.coreos.com/v1
+kind: ServiceMonitor
+metadata:
+  name: activator
+  namespace: monitoring
+`
+	for _, tt := range []struct {
+		name             string
+		force            bool
+		expectedObjects  []common.ManifestObject
+		expectedWarnings []string
+		expectedErr      error
+	}{{
+		name:             "Unknown kinds are errors",
+		expectedWarnings: []string{},
+		expectedErr: fmt.Errorf("The kind ServiceMonitor of ServiceMonitor monitoring/activator in monitoring.coreos.com/v1 is unknown. " +
+			"Use --force to store the custom manifests anyway."),
+	}, {
+		name:  "Unknown kinds are warnings with force",
+		force: true,
+		expectedObjects: []common.ManifestObject{
+			{APIVersion: "v1", Kind: "ConfigMap", Name: "dashboards", Namespace: "knative-serving"},
+			{APIVersion: "monitoring.coreos.com/v1", Kind: "ServiceMonitor", Name: "activator", Namespace: "monitoring"},
+		},
+		expectedWarnings: []string{"The kind ServiceMonitor of ServiceMonitor monitoring/activator in monitoring.coreos.com/v1 is unknown."},
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			objects, warnings, err := checkCustomManifests(data, "knative-serving", tt.force)
+			testingUtil.AssertDeepEqual(t, objects, tt.expectedObjects)
+			testingUtil.AssertDeepEqual(t, warnings, tt.expectedWarnings)
+			if tt.expectedErr != nil {
+				testingUtil.AssertEqual(t, err.Error(), tt.expectedErr.Error())
+			} else {
+				testingUtil.AssertEqual(t, err, nil)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configure
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc" // from https://github.com/kubernetes/client-go/issues/345
+
+	"knative.dev/kn-plugin-operator/pkg"
+	"knative.dev/kn-plugin-operator/pkg/command/common"
+)
+
+//go:embed overlay/ks_namespace.yaml
+var servingNamespaceOverlay string
+
+//go:embed overlay/ke_namespace.yaml
+var eventingNamespaceOverlay string
+
+var namespaceLabelCMDFlags common.KeyValueFlags
+
+var namespaceAnnotationCMDFlags common.KeyValueFlags
+
+// newNamespaceLabelCommand represents the configure commands to configure the labels for the Knative namespace
+func newNamespaceLabelCommand(p *pkg.OperatorParams) *cobra.Command {
+	var configureNamespaceLabelsCmd = &cobra.Command{
+		Use:   "namespace-labels",
+		Short: "Configure the labels for the namespace of Knative Serving and Eventing",
+		Example: `
+  # Configure the labels for the namespace of Knative Serving
+  kn operator configure namespace-labels --component serving --key istio-injection --value enabled --namespace knative-serving`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespaceLabelCMDFlags.Label = true
+			if err := validateKeyValuePairs(namespaceLabelCMDFlags); err != nil {
+				return err
+			}
+
+			err := configureNamespaceMetadata(namespaceLabelCMDFlags, p)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "The specified label has been configured for the namespace '%s'.\n",
+				namespaceLabelCMDFlags.Namespace)
+			return nil
+		},
+	}
+
+	configureNamespaceLabelsCmd.Flags().StringVar(&namespaceLabelCMDFlags.Key, "key", "", "The key of the label")
+	configureNamespaceLabelsCmd.Flags().StringVar(&namespaceLabelCMDFlags.Value, "value", "", "The value of the label")
+	configureNamespaceLabelsCmd.Flags().StringVarP(&namespaceLabelCMDFlags.Component, "component", "c", "", "The flag to specify the component name")
+	configureNamespaceLabelsCmd.Flags().StringVarP(&namespaceLabelCMDFlags.Namespace, "namespace", "n", "", "The namespace of the Knative Operator or the Knative component")
+
+	return configureNamespaceLabelsCmd
+}
+
+// newNamespaceAnnotationCommand represents the configure commands to configure the annotations for the Knative namespace
+func newNamespaceAnnotationCommand(p *pkg.OperatorParams) *cobra.Command {
+	var configureNamespaceAnnotationsCmd = &cobra.Command{
+		Use:   "namespace-annotations",
+		Short: "Configure the annotations for the namespace of Knative Serving and Eventing",
+		Example: `
+  # Configure the annotations for the namespace of Knative Eventing
+  kn operator configure namespace-annotations --component eventing --key key --value value --namespace knative-eventing`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespaceAnnotationCMDFlags.Annotation = true
+			if err := validateKeyValuePairs(namespaceAnnotationCMDFlags); err != nil {
+				return err
+			}
+
+			err := configureNamespaceMetadata(namespaceAnnotationCMDFlags, p)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "The specified annotation has been configured for the namespace '%s'.\n",
+				namespaceAnnotationCMDFlags.Namespace)
+			return nil
+		},
+	}
+
+	configureNamespaceAnnotationsCmd.Flags().StringVar(&namespaceAnnotationCMDFlags.Key, "key", "", "The key of the annotation")
+	configureNamespaceAnnotationsCmd.Flags().StringVar(&namespaceAnnotationCMDFlags.Value, "value", "", "The value of the annotation")
+	configureNamespaceAnnotationsCmd.Flags().StringVarP(&namespaceAnnotationCMDFlags.Component, "component", "c", "", "The flag to specify the component name")
+	configureNamespaceAnnotationsCmd.Flags().StringVarP(&namespaceAnnotationCMDFlags.Namespace, "namespace", "n", "", "The namespace of the Knative Operator or the Knative component")
+
+	return configureNamespaceAnnotationsCmd
+}
+
+func configureNamespaceMetadata(namespaceCMDFlags common.KeyValueFlags, p *pkg.OperatorParams) error {
+	component := common.ServingComponent
+	if strings.EqualFold(namespaceCMDFlags.Component, common.EventingComponent) {
+		component = common.EventingComponent
+	}
+	yamlTemplateString, err := common.GenerateOperatorCRString(component, namespaceCMDFlags.Namespace, p)
+	if err != nil {
+		return err
+	}
+
+	overlayContent := getOverlayYamlContentNamespace(namespaceCMDFlags)
+	valuesYaml := getYamlValuesContentNamespace(namespaceCMDFlags)
+	if err := common.ApplyManifests(yamlTemplateString, overlayContent, valuesYaml, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+func getOverlayYamlContentNamespace(namespaceCMDFlags common.KeyValueFlags) string {
+	baseOverlayContent := servingNamespaceOverlay
+	if strings.EqualFold(namespaceCMDFlags.Component, common.EventingComponent) {
+		baseOverlayContent = eventingNamespaceOverlay
+	}
+	namespaceContent := getNamespaceConfiguration(namespaceCMDFlags)
+	baseOverlayContent = fmt.Sprintf("%s\n%s", baseOverlayContent, namespaceContent)
+	return baseOverlayContent
+}
+
+func getNamespaceConfiguration(namespaceCMDFlags common.KeyValueFlags) string {
+	resourceArray := []string{}
+
+	tag := fmt.Sprintf("%s%s", common.Spaces(2), common.YttMatchingTag)
+	resourceArray = append(resourceArray, tag)
+	field := fmt.Sprintf("%s%s:", common.Spaces(2), "namespace")
+	resourceArray = append(resourceArray, field)
+
+	tag = fmt.Sprintf("%s%s", common.Spaces(4), common.YttMatchingTag)
+	resourceArray = append(resourceArray, tag)
+	if namespaceCMDFlags.Annotation {
+		field = fmt.Sprintf("%s%s:", common.Spaces(4), "annotations")
+	} else {
+		field = fmt.Sprintf("%s%s:", common.Spaces(4), "labels")
+	}
+	resourceArray = append(resourceArray, field)
+
+	tag = fmt.Sprintf("%s%s", common.Spaces(6), common.YttMatchingTag)
+	resourceArray = append(resourceArray, tag)
+	keyValueField := fmt.Sprintf("%s%s: %s", common.Spaces(6), namespaceCMDFlags.Key, "#@ data.values.value")
+	resourceArray = append(resourceArray, keyValueField)
+
+	return strings.Join(resourceArray, "\n")
+}
+
+func getYamlValuesContentNamespace(namespaceCMDFlags common.KeyValueFlags) string {
+	contentArray := []string{}
+	header := "#@data/values\n---"
+	contentArray = append(contentArray, header)
+	namespace := fmt.Sprintf("namespace: %s", namespaceCMDFlags.Namespace)
+	contentArray = append(contentArray, namespace)
+
+	// Quote the value, since labels and annotations only accept strings, and escape the quotes and the backslashes
+	value, _ := json.Marshal(namespaceCMDFlags.Value)
+	contentArray = append(contentArray, fmt.Sprintf("value: %s", value))
+	return strings.Join(contentArray, "\n")
+}
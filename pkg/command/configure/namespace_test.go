@@ -0,0 +1,130 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configure
+
+import (
+	"testing"
+
+	"knative.dev/kn-plugin-operator/pkg/command/common"
+	"knative.dev/kn-plugin-operator/pkg/command/testingUtil"
+)
+
+func TestGetOverlayYamlContentNamespace(t *testing.T) {
+	for _, tt := range []struct {
+		name              string
+		namespaceCMDFlags common.KeyValueFlags
+		expectedResult    string
+	}{{
+		name: "Knative Serving with the namespace label",
+		namespaceCMDFlags: common.KeyValueFlags{
+			Key:       "pod-security.kubernetes.io/enforce",
+			Value:     "restricted",
+			Component: "serving",
+			Namespace: "test-serving",
+			Label:     true,
+		},
+		expectedResult: `#@ load("@ytt:overlay", "overlay")
+#@ load("@ytt:data", "data")
+
+#@overlay/match by=overlay.subset({"kind": "KnativeServing"}),expects=1
+---
+apiVersion: operator.knative.dev/v1beta1
+kind: KnativeServing
+metadata:
+  #@overlay/match missing_ok=True
+  namespace: #@ data.values.namespace
+#@overlay/match missing_ok=True
+spec:
+
+  #@overlay/match missing_ok=True
+  namespace:
+    #@overlay/match missing_ok=True
+    labels:
+      #@overlay/match missing_ok=True
+      pod-security.kubernetes.io/enforce: #@ data.values.value`,
+	}, {
+		name: "Knative Eventing with the namespace annotation",
+		namespaceCMDFlags: common.KeyValueFlags{
+			Key:        "test-key",
+			Value:      "test-value",
+			Component:  "eventing",
+			Namespace:  "test-eventing",
+			Annotation: true,
+		},
+		expectedResult: `#@ load("@ytt:overlay", "overlay")
+#@ load("@ytt:data", "data")
+
+#@overlay/match by=overlay.subset({"kind": "KnativeEventing"}),expects=1
+---
+apiVersion: operator.knative.dev/v1beta1
+kind: KnativeEventing
+metadata:
+  #@overlay/match missing_ok=True
+  namespace: #@ data.values.namespace
+#@overlay/match missing_ok=True
+spec:
+
+  #@overlay/match missing_ok=True
+  namespace:
+    #@overlay/match missing_ok=True
+    annotations:
+      #@overlay/match missing_ok=True
+      test-key: #@ data.values.value`,
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := getOverlayYamlContentNamespace(tt.namespaceCMDFlags)
+			testingUtil.AssertEqual(t, result, tt.expectedResult)
+		})
+	}
+}
+
+func TestGetYamlValuesContentNamespace(t *testing.T) {
+	for _, tt := range []struct {
+		name              string
+		namespaceCMDFlags common.KeyValueFlags
+		expectedResult    string
+	}{{
+		name: "Knative Serving with the namespace label",
+		namespaceCMDFlags: common.KeyValueFlags{
+			Key:       "istio-injection",
+			Value:     "enabled",
+			Component: "serving",
+			Namespace: "test-serving",
+			Label:     true,
+		},
+		expectedResult: `#@data/values
+---
+namespace: test-serving
+value: "enabled"`,
+	}, {
+		name: "Knative Serving with the namespace annotation containing quotes and backslashes",
+		namespaceCMDFlags: common.KeyValueFlags{
+			Key:        "example.com/config",
+			Value:      `{"path": "C:\\knative"}`,
+			Component:  "serving",
+			Namespace:  "test-serving",
+			Annotation: true,
+		},
+		expectedResult: `#@data/values
+---
+namespace: test-serving
+value: "{\"path\": \"C:\\\\knative\"}"`,
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := getYamlValuesContentNamespace(tt.namespaceCMDFlags)
+			testingUtil.AssertEqual(t, result, tt.expectedResult)
+		})
+	}
+}
@@ -41,7 +41,9 @@ func newNodeSelectorCommand(p *pkg.OperatorParams) *cobra.Command {
 		Short: "Configure the node selectors for Knative Serving and Eventing deployments",
 		Example: `
   # Configure the nodeSelectors for Knative Serving and Eventing deployments
-  kn operator configure nodeSelectors --component eventing --deployName eventing-controller --key key --value value --namespace knative-eventing`,
+  kn operator configure nodeSelectors --component eventing --deployName eventing-controller --key key --value value --namespace knative-eventing
+  # Configure the nodeSelectors for all the deployments of Knative Serving
+  kn operator configure nodeSelectors --component serving --all-deployments --key key --value value --namespace knative-serving`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := validateNodeSelectorFlags(nodeSelectorCMDFlags); err != nil {
 				return err
@@ -53,7 +55,7 @@ func newNodeSelectorCommand(p *pkg.OperatorParams) *cobra.Command {
 			}
 
 			fmt.Fprintf(cmd.OutOrStdout(), "The specified annotation has been configured for the deployment %s in the deployment '%s'.\n",
-				nodeSelectorCMDFlags.DeployName, nodeSelectorCMDFlags.Namespace)
+				getDeploymentDescription(nodeSelectorCMDFlags), nodeSelectorCMDFlags.Namespace)
 			return nil
 		},
 	}
@@ -61,6 +63,7 @@ func newNodeSelectorCommand(p *pkg.OperatorParams) *cobra.Command {
 	configureNodeSelectorsCmd.Flags().StringVar(&nodeSelectorCMDFlags.Key, "key", "", "The key of the data in the configmap")
 	configureNodeSelectorsCmd.Flags().StringVar(&nodeSelectorCMDFlags.Value, "value", "", "The value of the data in the configmap")
 	configureNodeSelectorsCmd.Flags().StringVar(&nodeSelectorCMDFlags.DeployName, "deployName", "", "The flag to specify the deployment name")
+	configureNodeSelectorsCmd.Flags().BoolVar(&nodeSelectorCMDFlags.AllDeployments, "all-deployments", false, allDeploymentsUsage)
 	configureNodeSelectorsCmd.Flags().StringVarP(&nodeSelectorCMDFlags.Component, "component", "c", "", "The flag to specify the component name")
 	configureNodeSelectorsCmd.Flags().StringVarP(&nodeSelectorCMDFlags.Namespace, "namespace", "n", "", "The namespace of the Knative Operator or the Knative component")
 
@@ -71,7 +74,10 @@ func validateNodeSelectorFlags(keyValuesCMDFlags common.KeyValueFlags) error {
 	if err := validateKeyValuePairs(keyValuesCMDFlags); err != nil {
 		return err
 	}
-	if keyValuesCMDFlags.DeployName == "" {
+	if err := validateAllDeploymentsFlags(keyValuesCMDFlags.AllDeployments, keyValuesCMDFlags.DeployName); err != nil {
+		return err
+	}
+	if keyValuesCMDFlags.DeployName == "" && !keyValuesCMDFlags.AllDeployments {
 		return fmt.Errorf("You need to specify the name of the deployment.")
 	}
 	return nil
@@ -87,12 +93,11 @@ func configureNodeSelectors(nodeSelectorCMDFlags common.KeyValueFlags, p *pkg.Op
 		return err
 	}
 
-	overlayContent := getOverlayYamlContentNodeSelector(nodeSelectorCMDFlags)
-	valuesYaml := getYamlValuesContent(nodeSelectorCMDFlags)
-	if err := common.ApplyManifests(yamlTemplateString, overlayContent, valuesYaml, p); err != nil {
+	overlays, err := getKeyValueOverlays(nodeSelectorCMDFlags, component, getOverlayYamlContentNodeSelector, p)
+	if err != nil {
 		return err
 	}
-	return nil
+	return common.ApplyManifestsWithOverlays(yamlTemplateString, overlays, p)
 }
 
 func getOverlayYamlContentNodeSelector(nodeSelectorCMDFlags common.KeyValueFlags) string {
@@ -111,7 +116,7 @@ func getNodeSelectorConfiguration(annotationCMDFlags common.KeyValueFlags) strin
 	tag := fmt.Sprintf("%s%s", common.Spaces(2), common.YttMatchingTag)
 	resourceArray = append(resourceArray, tag)
 
-	field := fmt.Sprintf("%s%s:", common.Spaces(2), "deployments")
+	field := fmt.Sprintf("%s%s:", common.Spaces(2), "workloads")
 	resourceArray = append(resourceArray, field)
 
 	field = fmt.Sprintf("%s%s", common.Spaces(2), common.FieldByName("name"))
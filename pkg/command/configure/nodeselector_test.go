@@ -93,6 +93,16 @@ func TestValidateNodeSelectorFlags(t *testing.T) {
 			DeployName: "eventing-controller",
 		},
 		expectedResult: fmt.Errorf("You need to specify the value."),
+	}, {
+		name: "Knative Serving with all the deployments",
+		nodeSelectorCMDFlags: common.KeyValueFlags{
+			Key:            "test-key",
+			Value:          "test-value",
+			Component:      "serving",
+			Namespace:      "test-serving",
+			AllDeployments: true,
+		},
+		expectedResult: nil,
 	}} {
 		t.Run(tt.name, func(t *testing.T) {
 			result := validateNodeSelectorFlags(tt.nodeSelectorCMDFlags)
@@ -133,7 +143,7 @@ metadata:
 spec:
 
   #@overlay/match missing_ok=True
-  deployments:
+  workloads:
   #@overlay/match by="name",missing_ok=True
   - name: #@ data.values.deployName
     #@overlay/match missing_ok=True
@@ -0,0 +1,327 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configure
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc" // from https://github.com/kubernetes/client-go/issues/345
+
+	"knative.dev/kn-plugin-operator/pkg"
+	"knative.dev/kn-plugin-operator/pkg/command/common"
+	"knative.dev/operator/pkg/apis/operator/base"
+	"knative.dev/operator/pkg/apis/operator/v1beta1"
+)
+
+//go:embed overlay/ks_pdb.yaml
+var servingPDBOverlay string
+
+//go:embed overlay/ke_pdb.yaml
+var eventingPDBOverlay string
+
+type PDBFlags struct {
+	Name           string
+	MinAvailable   string
+	MaxUnavailable string
+	Component      string
+	Namespace      string
+	List           bool
+}
+
+var pdbCMDFlags PDBFlags
+
+// newPDBCommand represents the configure commands to configure the PodDisruptionBudgets for Knative Serving or Eventing
+func newPDBCommand(p *pkg.OperatorParams) *cobra.Command {
+	var configurePDBCmd = &cobra.Command{
+		Use:   "pdb",
+		Short: "Configure the PodDisruptionBudgets for Knative Serving and Eventing",
+		Example: `
+  # Configure the minimum available pods of the PodDisruptionBudget activator-pdb
+  kn operator configure pdb --component serving --name activator-pdb --minAvailable 2 --namespace knative-serving
+  # Configure the maximum unavailable pods of the PodDisruptionBudget eventing-webhook
+  kn operator configure pdb --component eventing --name eventing-webhook --maxUnavailable 50% --namespace knative-eventing
+  # List the PodDisruptionBudgets configured for Knative Serving
+  kn operator configure pdb --component serving --list --namespace knative-serving`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validatePDBFlags(pdbCMDFlags); err != nil {
+				return err
+			}
+
+			if pdbCMDFlags.List {
+				return listPDBs(cmd, pdbCMDFlags, p)
+			}
+
+			warning, err := configurePDB(pdbCMDFlags, p)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "The PodDisruptionBudget %s has been configured in the namespace '%s'.\n",
+				pdbCMDFlags.Name, pdbCMDFlags.Namespace)
+			if warning != "" {
+				fmt.Fprintf(cmd.OutOrStdout(), "Warning: %s\n", warning)
+			}
+			return nil
+		},
+	}
+
+	configurePDBCmd.Flags().StringVar(&pdbCMDFlags.Name, "name", "", "The name of the PodDisruptionBudget")
+	configurePDBCmd.Flags().StringVar(&pdbCMDFlags.MinAvailable, "minAvailable", "", "The number or the percentage of the pods that must be available after the eviction")
+	configurePDBCmd.Flags().StringVar(&pdbCMDFlags.MaxUnavailable, "maxUnavailable", "", "The number or the percentage of the pods that can be unavailable after the eviction")
+	configurePDBCmd.Flags().StringVarP(&pdbCMDFlags.Component, "component", "c", "", "The flag to specify the component name")
+	configurePDBCmd.Flags().StringVarP(&pdbCMDFlags.Namespace, "namespace", "n", "", "The namespace of the Knative Operator or the Knative component")
+	configurePDBCmd.Flags().BoolVar(&pdbCMDFlags.List, "list", false, "The flag to list the PodDisruptionBudgets, or the one specified by --name")
+
+	return configurePDBCmd
+}
+
+func validatePDBFlags(pdbCMDFlags PDBFlags) error {
+	if pdbCMDFlags.List {
+		if pdbCMDFlags.MinAvailable != "" || pdbCMDFlags.MaxUnavailable != "" {
+			return fmt.Errorf("You cannot specify --minAvailable or --maxUnavailable together with --list.")
+		}
+		return validatePDBTarget(pdbCMDFlags)
+	}
+	if pdbCMDFlags.Name == "" {
+		return fmt.Errorf("You need to specify the name of the PodDisruptionBudget.")
+	}
+	if pdbCMDFlags.MinAvailable == "" && pdbCMDFlags.MaxUnavailable == "" {
+		return fmt.Errorf("You need to specify either minAvailable or maxUnavailable.")
+	}
+	if pdbCMDFlags.MinAvailable != "" && pdbCMDFlags.MaxUnavailable != "" {
+		return fmt.Errorf("You cannot specify both minAvailable and maxUnavailable.")
+	}
+	if pdbCMDFlags.MinAvailable != "" {
+		if _, err := parsePDBValue(pdbCMDFlags.MinAvailable); err != nil {
+			return fmt.Errorf("You need to specify the minAvailable to a non-negative integer or a percentage.")
+		}
+	}
+	if pdbCMDFlags.MaxUnavailable != "" {
+		if _, err := parsePDBValue(pdbCMDFlags.MaxUnavailable); err != nil {
+			return fmt.Errorf("You need to specify the maxUnavailable to a non-negative integer or a percentage.")
+		}
+	}
+	return validatePDBTarget(pdbCMDFlags)
+}
+
+// validatePDBTarget checks the component and the namespace of the PodDisruptionBudgets
+func validatePDBTarget(pdbCMDFlags PDBFlags) error {
+	if pdbCMDFlags.Component == "" {
+		return fmt.Errorf("You need to specify the component name.")
+	}
+	if !strings.EqualFold(pdbCMDFlags.Component, common.ServingComponent) && !strings.EqualFold(pdbCMDFlags.Component, common.EventingComponent) {
+		return fmt.Errorf("You need to specify the component for Knative: serving or eventing.")
+	}
+	if pdbCMDFlags.Namespace == "" {
+		return fmt.Errorf("You need to specify the namespace.")
+	}
+	return nil
+}
+
+// parsePDBValue parses the value of minAvailable or maxUnavailable, which is either an integer or a percentage.
+func parsePDBValue(value string) (intstr.IntOrString, error) {
+	result := intstr.Parse(value)
+	if result.Type == intstr.Int {
+		if result.IntVal < 0 {
+			return result, fmt.Errorf("invalid value %s", value)
+		}
+		return result, nil
+	}
+
+	percentage, err := strconv.Atoi(strings.TrimSuffix(value, "%"))
+	if !strings.HasSuffix(value, "%") || err != nil || percentage < 0 || percentage > 100 {
+		return result, fmt.Errorf("invalid value %s", value)
+	}
+	return result, nil
+}
+
+func configurePDB(pdbCMDFlags PDBFlags, p *pkg.OperatorParams) (string, error) {
+	component := common.ServingComponent
+	if strings.EqualFold(pdbCMDFlags.Component, common.EventingComponent) {
+		component = common.EventingComponent
+	}
+
+	ksCR, err := common.GetKnativeOperatorCR(p)
+	if err != nil {
+		return "", err
+	}
+	kCR, err := ksCR.GetCRInterface(component, pdbCMDFlags.Namespace)
+	if err != nil {
+		return "", err
+	}
+
+	yamlTemplateString, err := common.GenerateOperatorCRString(component, pdbCMDFlags.Namespace, p)
+	if err != nil {
+		return "", err
+	}
+
+	overlayContent := getOverlayYamlContentPDB(pdbCMDFlags)
+	valuesYaml, err := getYamlValuesContentPDB(pdbCMDFlags)
+	if err != nil {
+		return "", err
+	}
+
+	if err = common.ApplyManifests(yamlTemplateString, overlayContent, valuesYaml, p); err != nil {
+		return "", err
+	}
+
+	var commonSpec *base.CommonSpec
+	switch cr := kCR.(type) {
+	case *v1beta1.KnativeServing:
+		commonSpec = &cr.Spec.CommonSpec
+	case *v1beta1.KnativeEventing:
+		commonSpec = &cr.Spec.CommonSpec
+	}
+	return getPDBDrainWarning(pdbCMDFlags, commonSpec), nil
+}
+
+// getPDBDrainWarning returns a warning, if the PodDisruptionBudget does not allow any pod to be evicted
+// with the number of replicas configured for the deployment, which blocks the node drains.
+func getPDBDrainWarning(pdbCMDFlags PDBFlags, commonSpec *base.CommonSpec) string {
+	if commonSpec == nil {
+		return ""
+	}
+
+	// Knative names the PodDisruptionBudget after its deployment, with or without the suffix -pdb.
+	deployName := strings.TrimSuffix(pdbCMDFlags.Name, "-pdb")
+	var replicas *int32
+	if commonSpec.HighAvailability != nil {
+		replicas = commonSpec.HighAvailability.Replicas
+	}
+	for _, deploy := range commonSpec.GetWorkloadOverrides() {
+		if deploy.Name == deployName && deploy.Replicas != nil {
+			replicas = deploy.Replicas
+		}
+	}
+	if replicas == nil {
+		return ""
+	}
+
+	allowedDisruptions := 0
+	if pdbCMDFlags.MinAvailable != "" {
+		minAvailable, err := parsePDBValue(pdbCMDFlags.MinAvailable)
+		if err != nil {
+			return ""
+		}
+		available, _ := intstr.GetScaledValueFromIntOrPercent(&minAvailable, int(*replicas), true)
+		allowedDisruptions = int(*replicas) - available
+	} else {
+		maxUnavailable, err := parsePDBValue(pdbCMDFlags.MaxUnavailable)
+		if err != nil {
+			return ""
+		}
+		allowedDisruptions, _ = intstr.GetScaledValueFromIntOrPercent(&maxUnavailable, int(*replicas), true)
+	}
+
+	if allowedDisruptions <= 0 {
+		return fmt.Sprintf("the PodDisruptionBudget %s allows no pod to be evicted with %d replica(s) of the deployment %s, which blocks the node drains.",
+			pdbCMDFlags.Name, *replicas, deployName)
+	}
+	return ""
+}
+
+func listPDBs(cmd *cobra.Command, pdbCMDFlags PDBFlags, p *pkg.OperatorParams) error {
+	ksCR, err := common.GetKnativeOperatorCR(p)
+	if err != nil {
+		return err
+	}
+	commonSpec, err := ksCR.GetCommonSpec(pdbCMDFlags.Component, pdbCMDFlags.Namespace)
+	if err != nil {
+		return err
+	}
+
+	lines := formatPDBs(commonSpec.PodDisruptionBudgetOverride, pdbCMDFlags.Name)
+	if len(lines) == 0 {
+		if pdbCMDFlags.Name != "" {
+			fmt.Fprintf(cmd.OutOrStdout(), "The PodDisruptionBudget %s is not configured in the namespace '%s'.\n", pdbCMDFlags.Name, pdbCMDFlags.Namespace)
+		} else {
+			fmt.Fprintf(cmd.OutOrStdout(), "No PodDisruptionBudget is configured in the namespace '%s'.\n", pdbCMDFlags.Namespace)
+		}
+		return nil
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "The PodDisruptionBudgets configured in the namespace '%s':\n%s\n", pdbCMDFlags.Namespace,
+		strings.Join(lines, common.LineWrapper))
+	return nil
+}
+
+// formatPDBs formats the PodDisruptionBudgets into lines with minAvailable or maxUnavailable. Only the
+// PodDisruptionBudget with the name is formatted, if the name is not empty.
+func formatPDBs(pdbs []base.PodDisruptionBudgetOverride, name string) []string {
+	lines := []string{}
+	for _, pdb := range pdbs {
+		if name != "" && pdb.Name != name {
+			continue
+		}
+		fields := []string{}
+		if pdb.MinAvailable != nil {
+			fields = append(fields, fmt.Sprintf("minAvailable=%s", pdb.MinAvailable.String()))
+		}
+		if pdb.MaxUnavailable != nil {
+			fields = append(fields, fmt.Sprintf("maxUnavailable=%s", pdb.MaxUnavailable.String()))
+		}
+		lines = append(lines, fmt.Sprintf("  %s: %s", pdb.Name, strings.Join(fields, ", ")))
+	}
+	return lines
+}
+
+func getOverlayYamlContentPDB(pdbCMDFlags PDBFlags) string {
+	baseOverlayContent := servingPDBOverlay
+	if strings.EqualFold(pdbCMDFlags.Component, common.EventingComponent) {
+		baseOverlayContent = eventingPDBOverlay
+	}
+	return baseOverlayContent
+}
+
+func getYamlValuesContentPDB(pdbCMDFlags PDBFlags) (string, error) {
+	contentArray := []string{}
+	header := "#@data/values\n---"
+	contentArray = append(contentArray, header)
+
+	namespace := fmt.Sprintf("namespace: %s", pdbCMDFlags.Namespace)
+	contentArray = append(contentArray, namespace)
+
+	pdb := base.PodDisruptionBudgetOverride{
+		Name: pdbCMDFlags.Name,
+	}
+	if pdbCMDFlags.MinAvailable != "" {
+		minAvailable, err := parsePDBValue(pdbCMDFlags.MinAvailable)
+		if err != nil {
+			return "", err
+		}
+		pdb.PodDisruptionBudgetSpec = policyv1.PodDisruptionBudgetSpec{MinAvailable: &minAvailable}
+	} else {
+		maxUnavailable, err := parsePDBValue(pdbCMDFlags.MaxUnavailable)
+		if err != nil {
+			return "", err
+		}
+		pdb.PodDisruptionBudgetSpec = policyv1.PodDisruptionBudgetSpec{MaxUnavailable: &maxUnavailable}
+	}
+
+	value, err := json.Marshal(&pdb)
+	if err != nil {
+		return "", err
+	}
+	pdbField := fmt.Sprintf("podDisruptionBudget: %s", value)
+	contentArray = append(contentArray, pdbField)
+
+	return strings.Join(contentArray, "\n"), nil
+}
@@ -0,0 +1,288 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configure
+
+import (
+	"fmt"
+	"testing"
+
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"knative.dev/kn-plugin-operator/pkg/command/testingUtil"
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+func TestValidatePDBFlags(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		pdbCMDFlags    PDBFlags
+		expectedResult error
+	}{{
+		name: "PDB flags with minAvailable",
+		pdbCMDFlags: PDBFlags{
+			Name:         "activator-pdb",
+			MinAvailable: "2",
+			Component:    "serving",
+			Namespace:    "test-serving",
+		},
+		expectedResult: nil,
+	}, {
+		name: "PDB flags with maxUnavailable in percentage",
+		pdbCMDFlags: PDBFlags{
+			Name:           "activator-pdb",
+			MaxUnavailable: "50%",
+			Component:      "serving",
+			Namespace:      "test-serving",
+		},
+		expectedResult: nil,
+	}, {
+		name: "PDB flags without name",
+		pdbCMDFlags: PDBFlags{
+			MinAvailable: "2",
+			Component:    "serving",
+			Namespace:    "test-serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify the name of the PodDisruptionBudget."),
+	}, {
+		name: "PDB flags without minAvailable or maxUnavailable",
+		pdbCMDFlags: PDBFlags{
+			Name:      "activator-pdb",
+			Component: "serving",
+			Namespace: "test-serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify either minAvailable or maxUnavailable."),
+	}, {
+		name: "PDB flags with both minAvailable and maxUnavailable",
+		pdbCMDFlags: PDBFlags{
+			Name:           "activator-pdb",
+			MinAvailable:   "1",
+			MaxUnavailable: "1",
+			Component:      "serving",
+			Namespace:      "test-serving",
+		},
+		expectedResult: fmt.Errorf("You cannot specify both minAvailable and maxUnavailable."),
+	}, {
+		name: "PDB flags with negative minAvailable",
+		pdbCMDFlags: PDBFlags{
+			Name:         "activator-pdb",
+			MinAvailable: "-1",
+			Component:    "serving",
+			Namespace:    "test-serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify the minAvailable to a non-negative integer or a percentage."),
+	}, {
+		name: "PDB flags with invalid maxUnavailable",
+		pdbCMDFlags: PDBFlags{
+			Name:           "activator-pdb",
+			MaxUnavailable: "120%",
+			Component:      "serving",
+			Namespace:      "test-serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify the maxUnavailable to a non-negative integer or a percentage."),
+	}, {
+		name: "PDB flags with invalid component",
+		pdbCMDFlags: PDBFlags{
+			Name:         "activator-pdb",
+			MinAvailable: "2",
+			Component:    "test",
+			Namespace:    "test-serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify the component for Knative: serving or eventing."),
+	}, {
+		name: "PDB flags without namespace",
+		pdbCMDFlags: PDBFlags{
+			Name:         "activator-pdb",
+			MinAvailable: "2",
+			Component:    "serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify the namespace."),
+	}, {
+		name: "PDB flags to list",
+		pdbCMDFlags: PDBFlags{
+			Component: "serving",
+			Namespace: "test-serving",
+			List:      true,
+		},
+		expectedResult: nil,
+	}, {
+		name: "PDB flags to list with minAvailable",
+		pdbCMDFlags: PDBFlags{
+			MinAvailable: "2",
+			Component:    "serving",
+			Namespace:    "test-serving",
+			List:         true,
+		},
+		expectedResult: fmt.Errorf("You cannot specify --minAvailable or --maxUnavailable together with --list."),
+	}, {
+		name: "PDB flags to list without namespace",
+		pdbCMDFlags: PDBFlags{
+			Component: "eventing",
+			List:      true,
+		},
+		expectedResult: fmt.Errorf("You need to specify the namespace."),
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := validatePDBFlags(tt.pdbCMDFlags)
+			if tt.expectedResult == nil {
+				testingUtil.AssertEqual(t, result, nil)
+			} else {
+				testingUtil.AssertEqual(t, result.Error(), tt.expectedResult.Error())
+			}
+		})
+	}
+}
+
+func TestFormatPDBs(t *testing.T) {
+	minAvailable := intstr.FromString("80%")
+	maxUnavailable := intstr.FromInt(1)
+	pdbs := []base.PodDisruptionBudgetOverride{{
+		Name:                    "activator-pdb",
+		PodDisruptionBudgetSpec: policyv1.PodDisruptionBudgetSpec{MinAvailable: &minAvailable},
+	}, {
+		Name:                    "webhook-pdb",
+		PodDisruptionBudgetSpec: policyv1.PodDisruptionBudgetSpec{MaxUnavailable: &maxUnavailable},
+	}}
+
+	for _, tt := range []struct {
+		name           string
+		pdbName        string
+		expectedResult []string
+	}{{
+		name:           "All PodDisruptionBudgets",
+		expectedResult: []string{"  activator-pdb: minAvailable=80%", "  webhook-pdb: maxUnavailable=1"},
+	}, {
+		name:           "PodDisruptionBudget with the name",
+		pdbName:        "webhook-pdb",
+		expectedResult: []string{"  webhook-pdb: maxUnavailable=1"},
+	}, {
+		name:           "Unknown PodDisruptionBudget",
+		pdbName:        "autoscaler-pdb",
+		expectedResult: []string{},
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			testingUtil.AssertDeepEqual(t, formatPDBs(pdbs, tt.pdbName), tt.expectedResult)
+		})
+	}
+}
+
+func TestGetPDBDrainWarning(t *testing.T) {
+	replicas := func(value int32) *int32 {
+		return &value
+	}
+	for _, tt := range []struct {
+		name           string
+		pdbCMDFlags    PDBFlags
+		commonSpec     *base.CommonSpec
+		expectedResult string
+	}{{
+		name: "PDB allowing evictions",
+		pdbCMDFlags: PDBFlags{
+			Name:         "activator-pdb",
+			MinAvailable: "2",
+		},
+		commonSpec: &base.CommonSpec{
+			HighAvailability: &base.HighAvailability{Replicas: replicas(3)},
+		},
+		expectedResult: "",
+	}, {
+		name: "PDB blocking evictions with the HA replicas",
+		pdbCMDFlags: PDBFlags{
+			Name:         "activator-pdb",
+			MinAvailable: "2",
+		},
+		commonSpec: &base.CommonSpec{
+			HighAvailability: &base.HighAvailability{Replicas: replicas(2)},
+		},
+		expectedResult: "the PodDisruptionBudget activator-pdb allows no pod to be evicted with 2 replica(s) of the deployment activator, which blocks the node drains.",
+	}, {
+		name: "PDB blocking evictions with the replicas of the deployment",
+		pdbCMDFlags: PDBFlags{
+			Name:         "activator-pdb",
+			MinAvailable: "100%",
+		},
+		commonSpec: &base.CommonSpec{
+			HighAvailability: &base.HighAvailability{Replicas: replicas(2)},
+			DeploymentOverride: []base.WorkloadOverride{{
+				Name:     "activator",
+				Replicas: replicas(4),
+			}},
+		},
+		expectedResult: "the PodDisruptionBudget activator-pdb allows no pod to be evicted with 4 replica(s) of the deployment activator, which blocks the node drains.",
+	}, {
+		name: "PDB blocking evictions with maxUnavailable",
+		pdbCMDFlags: PDBFlags{
+			Name:           "eventing-webhook",
+			MaxUnavailable: "0",
+		},
+		commonSpec: &base.CommonSpec{
+			DeploymentOverride: []base.WorkloadOverride{{
+				Name:     "eventing-webhook",
+				Replicas: replicas(3),
+			}},
+		},
+		expectedResult: "the PodDisruptionBudget eventing-webhook allows no pod to be evicted with 3 replica(s) of the deployment eventing-webhook, which blocks the node drains.",
+	}, {
+		name: "PDB without the configured replicas",
+		pdbCMDFlags: PDBFlags{
+			Name:         "activator-pdb",
+			MinAvailable: "2",
+		},
+		commonSpec:     &base.CommonSpec{},
+		expectedResult: "",
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := getPDBDrainWarning(tt.pdbCMDFlags, tt.commonSpec)
+			testingUtil.AssertEqual(t, result, tt.expectedResult)
+		})
+	}
+}
+
+func TestGetYamlValuesContentPDB(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		pdbCMDFlags    PDBFlags
+		expectedResult string
+	}{{
+		name: "Knative Serving with minAvailable",
+		pdbCMDFlags: PDBFlags{
+			Name:         "activator-pdb",
+			MinAvailable: "2",
+			Component:    "serving",
+			Namespace:    "test-serving",
+		},
+		expectedResult: `#@data/values
+---
+namespace: test-serving
+podDisruptionBudget: {"name":"activator-pdb","minAvailable":2}`,
+	}, {
+		name: "Knative Eventing with maxUnavailable",
+		pdbCMDFlags: PDBFlags{
+			Name:           "eventing-webhook",
+			MaxUnavailable: "50%",
+			Component:      "eventing",
+			Namespace:      "test-eventing",
+		},
+		expectedResult: `#@data/values
+---
+namespace: test-eventing
+podDisruptionBudget: {"name":"eventing-webhook","maxUnavailable":"50%"}`,
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := getYamlValuesContentPDB(tt.pdbCMDFlags)
+			testingUtil.AssertEqual(t, err, nil)
+			testingUtil.AssertEqual(t, result, tt.expectedResult)
+		})
+	}
+}
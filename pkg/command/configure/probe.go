@@ -0,0 +1,232 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configure
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc" // from https://github.com/kubernetes/client-go/issues/345
+
+	"knative.dev/kn-plugin-operator/pkg"
+	"knative.dev/kn-plugin-operator/pkg/command/common"
+)
+
+//go:embed overlay/ks_probe.yaml
+var servingProbeOverlay string
+
+//go:embed overlay/ke_probe.yaml
+var eventingProbeOverlay string
+
+type ProbeFlags struct {
+	Type                string
+	InitialDelaySeconds int32
+	TimeoutSeconds      int32
+	PeriodSeconds       int32
+	SuccessThreshold    int32
+	FailureThreshold    int32
+	Component           string
+	Namespace           string
+	DeployName          string
+	ContainerName       string
+	// Changed are the names of the probe fields specified on the command line, since 0 is a valid value
+	Changed []string
+}
+
+var probeCMDFlags ProbeFlags
+
+// The names of the probe fields, which are also the names of the flags
+var probeFieldNames = []string{"initialDelaySeconds", "timeoutSeconds", "periodSeconds", "successThreshold", "failureThreshold"}
+
+func getValidProbeTypes() []string {
+	return []string{"readiness", "liveness"}
+}
+
+// newProbesCommand represents the configure commands to configure the readiness and liveness probes for Knative deployments
+func newProbesCommand(p *pkg.OperatorParams) *cobra.Command {
+	var configureProbesCmd = &cobra.Command{
+		Use:   "probes",
+		Short: "Configure the readiness and liveness probes for Knative Serving and Eventing deployments",
+		Example: `
+  # Configure the readiness probe for the container webhook of the deployment webhook
+  kn operator configure probes --component serving --deployName webhook --container webhook --type readiness --initialDelaySeconds 10 --periodSeconds 5 --failureThreshold 6 --namespace knative-serving`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			probeCMDFlags.Changed = []string{}
+			for _, name := range probeFieldNames {
+				if cmd.Flags().Changed(name) {
+					probeCMDFlags.Changed = append(probeCMDFlags.Changed, name)
+				}
+			}
+			if err := validateProbeFlags(probeCMDFlags); err != nil {
+				return err
+			}
+
+			err := configureProbes(probeCMDFlags, p)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "The %s probe has been configured for the container %s of the deployment %s in the namespace '%s'.\n",
+				probeCMDFlags.Type, probeCMDFlags.ContainerName, probeCMDFlags.DeployName, probeCMDFlags.Namespace)
+			return nil
+		},
+	}
+
+	configureProbesCmd.Flags().StringVar(&probeCMDFlags.Type, "type", "", "The type of the probe: readiness or liveness")
+	configureProbesCmd.Flags().Int32Var(&probeCMDFlags.InitialDelaySeconds, "initialDelaySeconds", 0, "The number of seconds after the container has started before the probes are initiated")
+	configureProbesCmd.Flags().Int32Var(&probeCMDFlags.TimeoutSeconds, "timeoutSeconds", 0, "The number of seconds after which the probe times out")
+	configureProbesCmd.Flags().Int32Var(&probeCMDFlags.PeriodSeconds, "periodSeconds", 0, "How often in seconds to perform the probe")
+	configureProbesCmd.Flags().Int32Var(&probeCMDFlags.SuccessThreshold, "successThreshold", 0, "The minimum consecutive successes for the probe to be considered successful after having failed")
+	configureProbesCmd.Flags().Int32Var(&probeCMDFlags.FailureThreshold, "failureThreshold", 0, "The minimum consecutive failures for the probe to be considered failed after having succeeded")
+	configureProbesCmd.Flags().StringVar(&probeCMDFlags.DeployName, "deployName", "", "The flag to specify the deployment name")
+	configureProbesCmd.Flags().StringVar(&probeCMDFlags.ContainerName, "container", "", "The flag to specify the container name")
+	configureProbesCmd.Flags().StringVarP(&probeCMDFlags.Component, "component", "c", "", "The flag to specify the component name")
+	configureProbesCmd.Flags().StringVarP(&probeCMDFlags.Namespace, "namespace", "n", "", "The namespace of the Knative Operator or the Knative component")
+
+	return configureProbesCmd
+}
+
+func validateProbeFlags(probeCMDFlags ProbeFlags) error {
+	if !common.Contains(getValidProbeTypes(), probeCMDFlags.Type) {
+		return fmt.Errorf("You need to specify the type to one of the following values: readiness or liveness.")
+	}
+	fields := getProbeFields(probeCMDFlags)
+	if len(fields) == 0 {
+		return fmt.Errorf("You need to specify at least one of the following parameters: initialDelaySeconds, timeoutSeconds, periodSeconds, successThreshold or failureThreshold.")
+	}
+	for _, field := range fields {
+		if field.name == "initialDelaySeconds" && field.value < 0 {
+			return fmt.Errorf("You need to specify the initialDelaySeconds to a non-negative integer.")
+		}
+		if field.name != "initialDelaySeconds" && field.value < 1 {
+			return fmt.Errorf("You need to specify the %s to an integer greater than 0.", field.name)
+		}
+	}
+	if probeCMDFlags.Type == "liveness" && probeCMDFlags.SuccessThreshold > 1 {
+		return fmt.Errorf("You need to specify the successThreshold to 1, if the type is liveness.")
+	}
+	if probeCMDFlags.Component == "" {
+		return fmt.Errorf("You need to specify the component name.")
+	}
+	if !strings.EqualFold(probeCMDFlags.Component, common.ServingComponent) && !strings.EqualFold(probeCMDFlags.Component, common.EventingComponent) {
+		return fmt.Errorf("You need to specify the component for Knative: serving or eventing.")
+	}
+	if probeCMDFlags.DeployName == "" {
+		return fmt.Errorf("You need to specify the name of the deployment.")
+	}
+	if probeCMDFlags.ContainerName == "" {
+		return fmt.Errorf("You need to specify the name for the container.")
+	}
+	if probeCMDFlags.Namespace == "" {
+		return fmt.Errorf("You need to specify the namespace.")
+	}
+	return nil
+}
+
+func configureProbes(probeCMDFlags ProbeFlags, p *pkg.OperatorParams) error {
+	component := common.ServingComponent
+	if strings.EqualFold(probeCMDFlags.Component, common.EventingComponent) {
+		component = common.EventingComponent
+	}
+	yamlTemplateString, err := common.GenerateOperatorCRString(component, probeCMDFlags.Namespace, p)
+	if err != nil {
+		return err
+	}
+
+	overlayContent := getOverlayYamlContentProbe(probeCMDFlags)
+	valuesYaml := getYamlValuesContentProbe(probeCMDFlags)
+
+	if err = common.ApplyManifests(yamlTemplateString, overlayContent, valuesYaml, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+func getOverlayYamlContentProbe(probeCMDFlags ProbeFlags) string {
+	baseOverlayContent := servingProbeOverlay
+	if strings.EqualFold(probeCMDFlags.Component, common.EventingComponent) {
+		baseOverlayContent = eventingProbeOverlay
+	}
+	probeContent := getProbeConfiguration(probeCMDFlags)
+	baseOverlayContent = fmt.Sprintf("%s\n%s", baseOverlayContent, probeContent)
+	return baseOverlayContent
+}
+
+func getProbeConfiguration(probeCMDFlags ProbeFlags) string {
+	resourceArray := []string{}
+	tag := fmt.Sprintf("%s%s", common.Spaces(4), common.YttMatchingTag)
+	resourceArray = append(resourceArray, tag)
+	probesField := fmt.Sprintf("%s%sProbes:", common.Spaces(4), probeCMDFlags.Type)
+	resourceArray = append(resourceArray, probesField)
+
+	tag = fmt.Sprintf("%s%s", common.Spaces(4), common.FieldByName("container"))
+	resourceArray = append(resourceArray, tag)
+	containerField := fmt.Sprintf("%s%s", common.Spaces(4), "- container: #@ data.values.container")
+	resourceArray = append(resourceArray, containerField)
+
+	for _, field := range getProbeFields(probeCMDFlags) {
+		tag = fmt.Sprintf("%s%s", common.Spaces(6), common.YttMatchingTag)
+		resourceArray = append(resourceArray, tag)
+		probeField := fmt.Sprintf("%s%s: #@ data.values.%s", common.Spaces(6), field.name, field.name)
+		resourceArray = append(resourceArray, probeField)
+	}
+
+	return strings.Join(resourceArray, "\n")
+}
+
+type probeField struct {
+	name  string
+	value int32
+}
+
+// getProbeFields returns the probe fields specified on the command line
+func getProbeFields(probeCMDFlags ProbeFlags) []probeField {
+	fields := []probeField{}
+	for _, field := range []probeField{
+		{name: "initialDelaySeconds", value: probeCMDFlags.InitialDelaySeconds},
+		{name: "timeoutSeconds", value: probeCMDFlags.TimeoutSeconds},
+		{name: "periodSeconds", value: probeCMDFlags.PeriodSeconds},
+		{name: "successThreshold", value: probeCMDFlags.SuccessThreshold},
+		{name: "failureThreshold", value: probeCMDFlags.FailureThreshold},
+	} {
+		if common.Contains(probeCMDFlags.Changed, field.name) {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+func getYamlValuesContentProbe(probeCMDFlags ProbeFlags) string {
+	contentArray := []string{}
+	header := "#@data/values\n---"
+	contentArray = append(contentArray, header)
+
+	namespace := fmt.Sprintf("namespace: %s", probeCMDFlags.Namespace)
+	contentArray = append(contentArray, namespace)
+
+	deployName := fmt.Sprintf("deployName: %s", probeCMDFlags.DeployName)
+	contentArray = append(contentArray, deployName)
+
+	containerName := fmt.Sprintf("container: %s", probeCMDFlags.ContainerName)
+	contentArray = append(contentArray, containerName)
+
+	for _, field := range getProbeFields(probeCMDFlags) {
+		contentArray = append(contentArray, fmt.Sprintf("%s: %d", field.name, field.value))
+	}
+
+	return strings.Join(contentArray, "\n")
+}
@@ -0,0 +1,281 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configure
+
+import (
+	"fmt"
+	"testing"
+
+	"knative.dev/kn-plugin-operator/pkg/command/testingUtil"
+)
+
+func TestValidateProbeFlags(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		probeCMDFlags  ProbeFlags
+		expectedResult error
+	}{{
+		name: "Probe flags with all the parameters",
+		probeCMDFlags: ProbeFlags{
+			Type:                "readiness",
+			InitialDelaySeconds: 10,
+			PeriodSeconds:       5,
+			FailureThreshold:    6,
+			Component:           "serving",
+			Namespace:           "test-serving",
+			DeployName:          "webhook",
+			ContainerName:       "webhook",
+			Changed:             []string{"initialDelaySeconds", "periodSeconds", "failureThreshold"},
+		},
+		expectedResult: nil,
+	}, {
+		name: "Probe flags with invalid type",
+		probeCMDFlags: ProbeFlags{
+			Type:          "startup",
+			PeriodSeconds: 5,
+			Component:     "serving",
+			Namespace:     "test-serving",
+			DeployName:    "webhook",
+			ContainerName: "webhook",
+			Changed:       []string{"periodSeconds"},
+		},
+		expectedResult: fmt.Errorf("You need to specify the type to one of the following values: readiness or liveness."),
+	}, {
+		name: "Probe flags without any probe field",
+		probeCMDFlags: ProbeFlags{
+			Type:          "readiness",
+			Component:     "serving",
+			Namespace:     "test-serving",
+			DeployName:    "webhook",
+			ContainerName: "webhook",
+		},
+		expectedResult: fmt.Errorf("You need to specify at least one of the following parameters: initialDelaySeconds, timeoutSeconds, periodSeconds, successThreshold or failureThreshold."),
+	}, {
+		name: "Probe flags with negative initialDelaySeconds",
+		probeCMDFlags: ProbeFlags{
+			Type:                "readiness",
+			InitialDelaySeconds: -1,
+			Component:           "serving",
+			Namespace:           "test-serving",
+			DeployName:          "webhook",
+			ContainerName:       "webhook",
+			Changed:             []string{"initialDelaySeconds"},
+		},
+		expectedResult: fmt.Errorf("You need to specify the initialDelaySeconds to a non-negative integer."),
+	}, {
+		name: "Probe flags with initialDelaySeconds set to 0",
+		probeCMDFlags: ProbeFlags{
+			Type:                "readiness",
+			InitialDelaySeconds: 0,
+			Component:           "serving",
+			Namespace:           "test-serving",
+			DeployName:          "webhook",
+			ContainerName:       "webhook",
+			Changed:             []string{"initialDelaySeconds"},
+		},
+		expectedResult: nil,
+	}, {
+		name: "Probe flags with periodSeconds set to 0",
+		probeCMDFlags: ProbeFlags{
+			Type:          "readiness",
+			PeriodSeconds: 0,
+			Component:     "serving",
+			Namespace:     "test-serving",
+			DeployName:    "webhook",
+			ContainerName: "webhook",
+			Changed:       []string{"periodSeconds"},
+		},
+		expectedResult: fmt.Errorf("You need to specify the periodSeconds to an integer greater than 0."),
+	}, {
+		name: "Probe flags with negative failureThreshold",
+		probeCMDFlags: ProbeFlags{
+			Type:             "liveness",
+			FailureThreshold: -3,
+			Component:        "serving",
+			Namespace:        "test-serving",
+			DeployName:       "webhook",
+			ContainerName:    "webhook",
+			Changed:          []string{"failureThreshold"},
+		},
+		expectedResult: fmt.Errorf("You need to specify the failureThreshold to an integer greater than 0."),
+	}, {
+		name: "Liveness probe flags with successThreshold greater than 1",
+		probeCMDFlags: ProbeFlags{
+			Type:             "liveness",
+			SuccessThreshold: 2,
+			Component:        "serving",
+			Namespace:        "test-serving",
+			DeployName:       "webhook",
+			ContainerName:    "webhook",
+			Changed:          []string{"successThreshold"},
+		},
+		expectedResult: fmt.Errorf("You need to specify the successThreshold to 1, if the type is liveness."),
+	}, {
+		name: "Probe flags with invalid component",
+		probeCMDFlags: ProbeFlags{
+			Type:          "readiness",
+			PeriodSeconds: 5,
+			Component:     "test",
+			Namespace:     "test-serving",
+			DeployName:    "webhook",
+			ContainerName: "webhook",
+			Changed:       []string{"periodSeconds"},
+		},
+		expectedResult: fmt.Errorf("You need to specify the component for Knative: serving or eventing."),
+	}, {
+		name: "Probe flags without deployment name",
+		probeCMDFlags: ProbeFlags{
+			Type:          "readiness",
+			PeriodSeconds: 5,
+			Component:     "serving",
+			Namespace:     "test-serving",
+			ContainerName: "webhook",
+			Changed:       []string{"periodSeconds"},
+		},
+		expectedResult: fmt.Errorf("You need to specify the name of the deployment."),
+	}, {
+		name: "Probe flags without container name",
+		probeCMDFlags: ProbeFlags{
+			Type:          "readiness",
+			PeriodSeconds: 5,
+			Component:     "serving",
+			Namespace:     "test-serving",
+			DeployName:    "webhook",
+			Changed:       []string{"periodSeconds"},
+		},
+		expectedResult: fmt.Errorf("You need to specify the name for the container."),
+	}, {
+		name: "Probe flags without namespace",
+		probeCMDFlags: ProbeFlags{
+			Type:          "readiness",
+			PeriodSeconds: 5,
+			Component:     "serving",
+			DeployName:    "webhook",
+			ContainerName: "webhook",
+			Changed:       []string{"periodSeconds"},
+		},
+		expectedResult: fmt.Errorf("You need to specify the namespace."),
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := validateProbeFlags(tt.probeCMDFlags)
+			if tt.expectedResult == nil {
+				testingUtil.AssertEqual(t, result, nil)
+			} else {
+				testingUtil.AssertEqual(t, result.Error(), tt.expectedResult.Error())
+			}
+		})
+	}
+}
+
+func TestGetOverlayYamlContentProbe(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		probeCMDFlags  ProbeFlags
+		expectedResult string
+	}{{
+		name: "Knative Eventing with the liveness probe",
+		probeCMDFlags: ProbeFlags{
+			Type:                "liveness",
+			InitialDelaySeconds: 20,
+			TimeoutSeconds:      3,
+			Component:           "eventing",
+			Namespace:           "test-eventing",
+			DeployName:          "eventing-webhook",
+			ContainerName:       "eventing-webhook",
+			Changed:             []string{"initialDelaySeconds", "timeoutSeconds"},
+		},
+		expectedResult: `#@ load("@ytt:overlay", "overlay")
+#@ load("@ytt:data", "data")
+
+#@overlay/match by=overlay.subset({"kind": "KnativeEventing"}),expects=1
+---
+apiVersion: operator.knative.dev/v1beta1
+kind: KnativeEventing
+metadata:
+  #@overlay/match missing_ok=True
+  namespace: #@ data.values.namespace
+#@overlay/match missing_ok=True
+spec:
+  #@overlay/match missing_ok=True
+  workloads:
+  #@overlay/match by="name",missing_ok=True
+  - name: #@ data.values.deployName
+
+    #@overlay/match missing_ok=True
+    livenessProbes:
+    #@overlay/match by="container",missing_ok=True
+    - container: #@ data.values.container
+      #@overlay/match missing_ok=True
+      initialDelaySeconds: #@ data.values.initialDelaySeconds
+      #@overlay/match missing_ok=True
+      timeoutSeconds: #@ data.values.timeoutSeconds`,
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := getOverlayYamlContentProbe(tt.probeCMDFlags)
+			testingUtil.AssertEqual(t, result, tt.expectedResult)
+		})
+	}
+}
+
+func TestGetYamlValuesContentProbe(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		probeCMDFlags  ProbeFlags
+		expectedResult string
+	}{{
+		name: "Knative Serving with the readiness probe",
+		probeCMDFlags: ProbeFlags{
+			Type:             "readiness",
+			PeriodSeconds:    5,
+			SuccessThreshold: 2,
+			FailureThreshold: 6,
+			Component:        "serving",
+			Namespace:        "test-serving",
+			DeployName:       "webhook",
+			ContainerName:    "webhook",
+			Changed:          []string{"periodSeconds", "successThreshold", "failureThreshold"},
+		},
+		expectedResult: `#@data/values
+---
+namespace: test-serving
+deployName: webhook
+container: webhook
+periodSeconds: 5
+successThreshold: 2
+failureThreshold: 6`,
+	}, {
+		name: "Knative Serving with initialDelaySeconds set to 0",
+		probeCMDFlags: ProbeFlags{
+			Type:                "readiness",
+			InitialDelaySeconds: 0,
+			Component:           "serving",
+			Namespace:           "test-serving",
+			DeployName:          "webhook",
+			ContainerName:       "webhook",
+			Changed:             []string{"initialDelaySeconds"},
+		},
+		expectedResult: `#@data/values
+---
+namespace: test-serving
+deployName: webhook
+container: webhook
+initialDelaySeconds: 0`,
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := getYamlValuesContentProbe(tt.probeCMDFlags)
+			testingUtil.AssertEqual(t, result, tt.expectedResult)
+		})
+	}
+}
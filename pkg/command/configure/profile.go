@@ -0,0 +1,229 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configure
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc" // from https://github.com/kubernetes/client-go/issues/345
+	"k8s.io/client-go/util/retry"
+
+	"knative.dev/kn-plugin-operator/pkg"
+	"knative.dev/kn-plugin-operator/pkg/command/common"
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+type profileFlags struct {
+	Name      string
+	Component string
+	Namespace string
+	Dir       string
+	DryRun    bool
+	List      bool
+}
+
+var profileCMDFlags profileFlags
+
+// newProfileCommand represents the configure command to apply a profile to Knative Serving or Eventing
+func newProfileCommand(p *pkg.OperatorParams) *cobra.Command {
+	var configureProfileCmd = &cobra.Command{
+		Use:   "profile",
+		Short: "Configure Knative Serving or Eventing with a profile",
+		Long: `Configure Knative Serving or Eventing with a profile, a curated set of overrides applied in a single update.
+
+The built-in profiles are production, development and minimal. The user-defined profiles are loaded from the YAML
+files in the directory set by --dir, and replace the built-in profiles with the same names. A profile has a name, a
+description and a list of overrides, each with the component, the spec fragment to apply, and optionally the Knative
+version the override is available since:
+
+  name: team
+  description: The settings of the team.
+  overrides:
+  - component: serving
+    since: v1.13
+    spec:
+      config:
+        config-autoscaler:
+          scale-to-zero-grace-period: 60s
+
+The spec fragments are merged into the current spec: the maps are merged, the lists of workloads, services,
+containers and the like are merged item by item with the same name or container, and the other values are replaced.
+The diff of the spec is printed, and with --dry-run nothing is applied.`,
+		Example: `
+  # Show the changes of the production profile for Knative Serving
+  kn operator configure profile --name production -c serving --namespace knative-serving --dry-run
+  # Configure Knative Eventing with the minimal profile
+  kn operator configure profile --name minimal -c eventing --namespace knative-eventing
+  # Configure Knative Serving with a user-defined profile
+  kn operator configure profile --name team -c serving --namespace knative-serving --dir ./profiles
+  # List the built-in and user-defined profiles
+  kn operator configure profile --list --dir ./profiles`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateProfileFlags(profileCMDFlags); err != nil {
+				return err
+			}
+
+			if profileCMDFlags.List {
+				profiles, err := common.GetProfiles(profileCMDFlags.Dir)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "%s", common.FormatProfiles(profiles))
+				return nil
+			}
+
+			if profileCMDFlags.Namespace == "" {
+				profileCMDFlags.Namespace = common.DefaultKnativeServingNamespace
+				if strings.EqualFold(profileCMDFlags.Component, common.EventingComponent) {
+					profileCMDFlags.Namespace = common.DefaultKnativeEventingNamespace
+				}
+			}
+
+			return configureProfile(cmd, profileCMDFlags, p)
+		},
+	}
+
+	configureProfileCmd.Flags().StringVar(&profileCMDFlags.Name, "name", "", "The name of the profile")
+	configureProfileCmd.Flags().StringVarP(&profileCMDFlags.Component, "component", "c", "", "The flag to specify the component name: serving or eventing")
+	configureProfileCmd.Flags().StringVarP(&profileCMDFlags.Namespace, "namespace", "n", "", "The namespace of the Knative component")
+	configureProfileCmd.Flags().StringVar(&profileCMDFlags.Dir, "dir", "", "The directory with the YAML files of the user-defined profiles")
+	configureProfileCmd.Flags().BoolVar(&profileCMDFlags.DryRun, "dry-run", false, "The flag to only print the changes without applying them")
+	configureProfileCmd.Flags().BoolVar(&profileCMDFlags.List, "list", false, "The flag to list the available profiles")
+
+	return configureProfileCmd
+}
+
+func validateProfileFlags(profileCMDFlags profileFlags) error {
+	if profileCMDFlags.List {
+		if profileCMDFlags.Name != "" {
+			return fmt.Errorf("You cannot specify --name together with --list.")
+		}
+		return nil
+	}
+	if profileCMDFlags.Name == "" {
+		return fmt.Errorf("You need to specify the name of the profile.")
+	}
+	if !strings.EqualFold(profileCMDFlags.Component, common.ServingComponent) &&
+		!strings.EqualFold(profileCMDFlags.Component, common.EventingComponent) {
+		return fmt.Errorf("You need to specify the component for Knative: serving or eventing.")
+	}
+	return nil
+}
+
+func configureProfile(cmd *cobra.Command, profileCMDFlags profileFlags, p *pkg.OperatorParams) error {
+	component := common.ServingComponent
+	if strings.EqualFold(profileCMDFlags.Component, common.EventingComponent) {
+		component = common.EventingComponent
+	}
+	profiles, err := common.GetProfiles(profileCMDFlags.Dir)
+	if err != nil {
+		return err
+	}
+	profile := common.FindProfile(profiles, profileCMDFlags.Name)
+	if profile == nil {
+		names := []string{}
+		for _, profile := range profiles {
+			names = append(names, profile.Name)
+		}
+		return fmt.Errorf("The profile %s is not found. The available profiles are: %s.", profileCMDFlags.Name, strings.Join(names, ", "))
+	}
+
+	ksCR, err := common.GetKnativeOperatorCR(p)
+	if err != nil {
+		return err
+	}
+	state, err := ksCR.GetKnativeCRState(component, profileCMDFlags.Namespace)
+	if err != nil {
+		return err
+	}
+
+	var diff string
+	var warnings []string
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		commonSpec, err := ksCR.GetCommonSpec(component, profileCMDFlags.Namespace)
+		if err != nil {
+			return err
+		}
+		version := commonSpec.Version
+		if state != nil && state.Version != "" {
+			version = state.Version
+		}
+
+		merged, notes, err := common.ApplyProfile(profile, component, version, commonSpec)
+		if err != nil {
+			return err
+		}
+		warnings, err = checkProfileConfig(component, version, commonSpec.Config, merged.Config)
+		if err != nil {
+			return err
+		}
+		warnings = append(notes, warnings...)
+		diff, err = common.GetCommonSpecDiff(commonSpec, merged, "current", fmt.Sprintf("profile %s", profile.Name))
+		if err != nil || diff == "" || profileCMDFlags.DryRun {
+			return err
+		}
+		return ksCR.UpdateCommonSpec(component, profileCMDFlags.Namespace, merged)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, warning := range warnings {
+		fmt.Fprintf(cmd.OutOrStdout(), "Warning: %s\n", warning)
+	}
+	if diff == "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "Knative %s already has the profile %s in the namespace '%s'.\n", component,
+			profile.Name, profileCMDFlags.Namespace)
+		return nil
+	}
+	fmt.Fprint(cmd.OutOrStdout(), diff)
+	if profileCMDFlags.DryRun {
+		return nil
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "The profile %s has been applied to Knative %s in the namespace '%s'.\n", profile.Name,
+		component, profileCMDFlags.Namespace)
+	return nil
+}
+
+// checkProfileConfig checks the data of the ConfigMaps changed by the profile against the catalogue, and returns the
+// unknown keys and the invalid values as warnings
+func checkProfileConfig(component, version string, current, merged base.ConfigMapData) ([]string, error) {
+	catalogue, err := common.GetConfigMapCatalogue()
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for name := range merged {
+		if !reflect.DeepEqual(current[name], merged[name]) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	warnings := []string{}
+	for _, name := range names {
+		entries := common.FindConfigMapEntries(catalogue, component, name)
+		if len(entries) == 0 {
+			continue
+		}
+		problems, unknown := common.CheckConfigMapData(entries[0], version, merged[name])
+		warnings = append(append(warnings, problems...), unknown...)
+	}
+	return warnings, nil
+}
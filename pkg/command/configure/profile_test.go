@@ -0,0 +1,96 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configure
+
+import (
+	"fmt"
+	"testing"
+
+	"knative.dev/kn-plugin-operator/pkg/command/common"
+	"knative.dev/kn-plugin-operator/pkg/command/testingUtil"
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+func TestValidateProfileFlags(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		flags       profileFlags
+		expectedErr error
+	}{{
+		name:  "Apply a profile",
+		flags: profileFlags{Name: "production", Component: "serving"},
+	}, {
+		name:  "List the profiles",
+		flags: profileFlags{List: true},
+	}, {
+		name:        "List with a name",
+		flags:       profileFlags{Name: "production", List: true},
+		expectedErr: fmt.Errorf("You cannot specify --name together with --list."),
+	}, {
+		name:        "Missing name",
+		flags:       profileFlags{Component: "serving"},
+		expectedErr: fmt.Errorf("You need to specify the name of the profile."),
+	}, {
+		name:        "Invalid component",
+		flags:       profileFlags{Name: "production", Component: "test"},
+		expectedErr: fmt.Errorf("You need to specify the component for Knative: serving or eventing."),
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateProfileFlags(tt.flags)
+			if tt.expectedErr != nil {
+				testingUtil.AssertEqual(t, err.Error(), tt.expectedErr.Error())
+			} else {
+				testingUtil.AssertEqual(t, err, nil)
+			}
+		})
+	}
+}
+
+func TestCheckProfileConfig(t *testing.T) {
+	current := base.ConfigMapData{
+		"config-autoscaler": {"stable-window": "invalid"},
+		"network":           {"ingress-class": "kourier.ingress.networking.knative.dev"},
+	}
+	merged := base.ConfigMapData{
+		"config-autoscaler": {"stable-window": "invalid"},
+		"network":           {"ingress-class": "kourier.ingress.networking.knative.dev", "external-domain-tls": "Enabled"},
+		"config-unknown":    {"key": "value"},
+		"config-gc":         {"min-non-active-revisions": "two"},
+	}
+	warnings, err := checkProfileConfig("serving", "1.12", current, merged)
+	testingUtil.AssertEqual(t, err, nil)
+	// The unchanged ConfigMaps, and the ones out of the catalogue are not checked
+	testingUtil.AssertEqual(t, len(warnings), 2)
+
+	warnings, err = checkProfileConfig("serving", "1.13", current, merged)
+	testingUtil.AssertEqual(t, err, nil)
+	testingUtil.AssertEqual(t, len(warnings), 1)
+}
+
+func TestCheckBuiltInProfilesConfig(t *testing.T) {
+	profiles, err := common.GetProfiles("")
+	testingUtil.AssertEqual(t, err, nil)
+	for _, profile := range profiles {
+		for _, component := range []string{common.ServingComponent, common.EventingComponent} {
+			merged, _, err := common.ApplyProfile(&profile, component, "1.13", &base.CommonSpec{})
+			testingUtil.AssertEqual(t, err, nil)
+			warnings, err := checkProfileConfig(component, "1.13", base.ConfigMapData{}, merged.Config)
+			testingUtil.AssertEqual(t, err, nil)
+			testingUtil.AssertDeepEqual(t, warnings, []string{})
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configure
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc" // from https://github.com/kubernetes/client-go/issues/345
+
+	"knative.dev/kn-plugin-operator/pkg"
+	"knative.dev/kn-plugin-operator/pkg/command/common"
+)
+
+//go:embed overlay/ks_registry.yaml
+var servingRegistryOverlay string
+
+//go:embed overlay/ke_registry.yaml
+var eventingRegistryOverlay string
+
+type RegistryFlags struct {
+	Default   string
+	Component string
+	Namespace string
+}
+
+var registryCMDFlags RegistryFlags
+
+// newRegistryCommand represents the configure commands to configure the default registry for Knative images
+func newRegistryCommand(p *pkg.OperatorParams) *cobra.Command {
+	var configureRegistryCmd = &cobra.Command{
+		Use:   "registry",
+		Short: "Configure the default image registry for Knative",
+		Example: `
+  # Configure the default image reference template for all the Knative Serving images
+  kn operator configure registry --component serving --default 'registry.corp/knative/${NAME}:v1.x' --namespace knative-serving`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateRegistryFlags(registryCMDFlags); err != nil {
+				return err
+			}
+
+			err := configureRegistry(registryCMDFlags, p)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "The default registry has been configured in the namespace '%s'.\n",
+				registryCMDFlags.Namespace)
+			return nil
+		},
+	}
+
+	configureRegistryCmd.Flags().StringVar(&registryCMDFlags.Default, "default", "", "The default image reference template, in which ${NAME} is replaced by the container or image name")
+	configureRegistryCmd.Flags().StringVarP(&registryCMDFlags.Component, "component", "c", "", "The flag to specify the component name")
+	configureRegistryCmd.Flags().StringVarP(&registryCMDFlags.Namespace, "namespace", "n", "", "The namespace of the Knative Operator or the Knative component")
+
+	return configureRegistryCmd
+}
+
+func validateRegistryFlags(registryCMDFlags RegistryFlags) error {
+	if registryCMDFlags.Default == "" {
+		return fmt.Errorf("You need to specify the default image reference template.")
+	}
+	if !strings.Contains(registryCMDFlags.Default, "${NAME}") {
+		return fmt.Errorf("You need to specify the default image reference template with ${NAME}.")
+	}
+	if registryCMDFlags.Namespace == "" {
+		return fmt.Errorf("You need to specify the namespace.")
+	}
+	if registryCMDFlags.Component == "" {
+		return fmt.Errorf("You need to specify the component name.")
+	}
+	if !strings.EqualFold(registryCMDFlags.Component, common.ServingComponent) && !strings.EqualFold(registryCMDFlags.Component, common.EventingComponent) {
+		return fmt.Errorf("You need to specify the component for Knative: serving or eventing.")
+	}
+	return nil
+}
+
+func configureRegistry(registryCMDFlags RegistryFlags, p *pkg.OperatorParams) error {
+	component := common.ServingComponent
+	if strings.EqualFold(registryCMDFlags.Component, common.EventingComponent) {
+		component = common.EventingComponent
+	}
+	yamlTemplateString, err := common.GenerateOperatorCRString(component, registryCMDFlags.Namespace, p)
+	if err != nil {
+		return err
+	}
+
+	overlayContent := getOverlayYamlContentRegistry(registryCMDFlags)
+	valuesYaml := getYamlValuesContentRegistry(registryCMDFlags)
+	if err := common.ApplyManifests(yamlTemplateString, overlayContent, valuesYaml, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+func getOverlayYamlContentRegistry(registryCMDFlags RegistryFlags) string {
+	baseOverlayContent := servingRegistryOverlay
+	if strings.EqualFold(registryCMDFlags.Component, common.EventingComponent) {
+		baseOverlayContent = eventingRegistryOverlay
+	}
+	return baseOverlayContent
+}
+
+func getYamlValuesContentRegistry(registryCMDFlags RegistryFlags) string {
+	contentArray := []string{}
+	header := "#@data/values\n---"
+	contentArray = append(contentArray, header)
+	namespace := fmt.Sprintf("namespace: %s", registryCMDFlags.Namespace)
+	contentArray = append(contentArray, namespace)
+	// Quote the default registry, and escape the quotes and the backslashes
+	defaultRegistry, _ := json.Marshal(registryCMDFlags.Default)
+	contentArray = append(contentArray, fmt.Sprintf("default: %s", defaultRegistry))
+	return strings.Join(contentArray, "\n")
+}
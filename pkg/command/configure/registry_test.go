@@ -0,0 +1,112 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configure
+
+import (
+	"fmt"
+	"testing"
+
+	"knative.dev/kn-plugin-operator/pkg/command/testingUtil"
+)
+
+func TestValidateRegistryFlags(t *testing.T) {
+	for _, tt := range []struct {
+		name             string
+		registryCMDFlags RegistryFlags
+		expectedResult   error
+	}{{
+		name: "Registry flags with all the parameters",
+		registryCMDFlags: RegistryFlags{
+			Default:   "registry.corp/knative/${NAME}:v1.x",
+			Component: "serving",
+			Namespace: "test-serving",
+		},
+		expectedResult: nil,
+	}, {
+		name: "Registry flags without default",
+		registryCMDFlags: RegistryFlags{
+			Component: "serving",
+			Namespace: "test-serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify the default image reference template."),
+	}, {
+		name: "Registry flags with default missing the name placeholder",
+		registryCMDFlags: RegistryFlags{
+			Default:   "registry.corp/knative/activator:v1.x",
+			Component: "serving",
+			Namespace: "test-serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify the default image reference template with ${NAME}."),
+	}, {
+		name: "Registry flags without namespace",
+		registryCMDFlags: RegistryFlags{
+			Default:   "registry.corp/knative/${NAME}:v1.x",
+			Component: "serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify the namespace."),
+	}, {
+		name: "Registry flags with invalid component",
+		registryCMDFlags: RegistryFlags{
+			Default:   "registry.corp/knative/${NAME}:v1.x",
+			Component: "test",
+			Namespace: "test-serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify the component for Knative: serving or eventing."),
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := validateRegistryFlags(tt.registryCMDFlags)
+			if tt.expectedResult == nil {
+				testingUtil.AssertEqual(t, result, nil)
+			} else {
+				testingUtil.AssertEqual(t, result.Error(), tt.expectedResult.Error())
+			}
+		})
+	}
+}
+
+func TestGetYamlValuesContentRegistry(t *testing.T) {
+	for _, tt := range []struct {
+		name             string
+		registryCMDFlags RegistryFlags
+		expectedResult   string
+	}{{
+		name: "Knative Serving with the default registry",
+		registryCMDFlags: RegistryFlags{
+			Default:   "registry.corp/knative/${NAME}:v1.x",
+			Component: "serving",
+			Namespace: "test-serving",
+		},
+		expectedResult: `#@data/values
+---
+namespace: test-serving
+default: "registry.corp/knative/${NAME}:v1.x"`,
+	}, {
+		name: "Knative Eventing with the default registry containing a quote",
+		registryCMDFlags: RegistryFlags{
+			Default:   `registry.corp/knative/${NAME}:"v1.x"`,
+			Component: "eventing",
+			Namespace: "test-eventing",
+		},
+		expectedResult: `#@data/values
+---
+namespace: test-eventing
+default: "registry.corp/knative/${NAME}:\"v1.x\""`,
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := getYamlValuesContentRegistry(tt.registryCMDFlags)
+			testingUtil.AssertEqual(t, result, tt.expectedResult)
+		})
+	}
+}
@@ -40,6 +40,8 @@ type ResourcesFlags struct {
 	Namespace     string
 	Container     string
 	DeployName    string
+
+	AllDeployments bool
 }
 
 var resourcesCMDFlags ResourcesFlags
@@ -51,7 +53,9 @@ func newResourcesCommand(p *pkg.OperatorParams) *cobra.Command {
 		Short: "Configure the resource for Knative Serving and Eventing deployments",
 		Example: `
   # Configure the resource for Knative Serving and Eventing deployments
-  kn operator configure resources --component eventing --deployName eventing-controller --container eventing-controller --requestMemory 200Mi --requestCPU 200m --namespace knative-eventing`,
+  kn operator configure resources --component eventing --deployName eventing-controller --container eventing-controller --requestMemory 200Mi --requestCPU 200m --namespace knative-eventing
+  # Configure the resource for all the containers of all the deployments of Knative Eventing
+  kn operator configure resources --component eventing --all-deployments --requestMemory 200Mi --requestCPU 200m --namespace knative-eventing`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := validateResourcesFlags(resourcesCMDFlags); err != nil {
 				return err
@@ -73,6 +77,7 @@ func newResourcesCommand(p *pkg.OperatorParams) *cobra.Command {
 	configureResourcesCmd.Flags().StringVar(&resourcesCMDFlags.RequestCPU, "requestCPU", "", "The flag to specify the request CPU")
 	configureResourcesCmd.Flags().StringVar(&resourcesCMDFlags.RequestMemory, "requestMemory", "", "The flag to specify the request memory")
 	configureResourcesCmd.Flags().StringVar(&resourcesCMDFlags.DeployName, "deployName", "", "The flag to specify the deployment name")
+	configureResourcesCmd.Flags().BoolVar(&resourcesCMDFlags.AllDeployments, "all-deployments", false, allDeploymentsUsage+". If the container is not specified, all the containers are configured.")
 	configureResourcesCmd.Flags().StringVarP(&resourcesCMDFlags.Component, "component", "c", "", "The flag to specify the component name")
 	configureResourcesCmd.Flags().StringVar(&resourcesCMDFlags.Container, "container", "", "The flag to specify the container name")
 	configureResourcesCmd.Flags().StringVarP(&resourcesCMDFlags.Namespace, "namespace", "n", "", "The namespace of the Knative Operator or the Knative component")
@@ -103,13 +108,16 @@ func validateResourcesFlags(resourcesCMDFlags ResourcesFlags) error {
 		return fmt.Errorf("You need to specify at least one resource parameter: limitCPU, limitMemory, requestCPU or requestMemory.")
 	}
 
-	if resourcesCMDFlags.Container == "" {
+	if resourcesCMDFlags.Container == "" && !resourcesCMDFlags.AllDeployments {
 		return fmt.Errorf("You need to specify the container name.")
 	}
 	if resourcesCMDFlags.Component == "" {
 		return fmt.Errorf("You need to specify the component name.")
 	}
-	if resourcesCMDFlags.DeployName == "" {
+	if err := validateAllDeploymentsFlags(resourcesCMDFlags.AllDeployments, resourcesCMDFlags.DeployName); err != nil {
+		return err
+	}
+	if resourcesCMDFlags.DeployName == "" && !resourcesCMDFlags.AllDeployments {
 		return fmt.Errorf("You need to specify the name of the deployment.")
 	}
 	if resourcesCMDFlags.Namespace == "" {
@@ -128,13 +136,19 @@ func configureResources(resourcesCMDFlags ResourcesFlags, p *pkg.OperatorParams)
 		return err
 	}
 
-	overlayContent := getOverlayYamlContentResource(resourcesCMDFlags)
-	valuesYaml := getYamlValuesContentResources(resourcesCMDFlags)
-
-	if err := common.ApplyManifests(yamlTemplateString, overlayContent, valuesYaml, p); err != nil {
+	targets, err := getTargetWorkloads(resourcesCMDFlags.AllDeployments, component, resourcesCMDFlags.Namespace,
+		resourcesCMDFlags.DeployName, resourcesCMDFlags.Container, p)
+	if err != nil {
 		return err
 	}
-	return nil
+	overlays := []common.OverlayValues{}
+	for _, target := range targets {
+		flags := resourcesCMDFlags
+		flags.DeployName = target.DeployName
+		flags.Container = target.ContainerName
+		overlays = append(overlays, common.OverlayValues{Overlay: getOverlayYamlContentResource(flags), Values: getYamlValuesContentResources(flags)})
+	}
+	return common.ApplyManifestsWithOverlays(yamlTemplateString, overlays, p)
 }
 
 func getOverlayYamlContentResource(resourcesCMDFlags ResourcesFlags) string {
@@ -51,7 +51,7 @@ metadata:
 #@overlay/match missing_ok=True
 spec:
   #@overlay/match missing_ok=True
-  deployments:
+  workloads:
   #@overlay/match by="name",missing_ok=True
   - name: #@ data.values.deployName
     #@overlay/match missing_ok=True
@@ -96,7 +96,7 @@ metadata:
 #@overlay/match missing_ok=True
 spec:
   #@overlay/match missing_ok=True
-  deployments:
+  workloads:
   #@overlay/match by="name",missing_ok=True
   - name: #@ data.values.deployName
     #@overlay/match missing_ok=True
@@ -139,7 +139,7 @@ metadata:
 #@overlay/match missing_ok=True
 spec:
   #@overlay/match missing_ok=True
-  deployments:
+  workloads:
   #@overlay/match by="name",missing_ok=True
   - name: #@ data.values.deployName
     #@overlay/match missing_ok=True
@@ -177,7 +177,7 @@ metadata:
 #@overlay/match missing_ok=True
 spec:
   #@overlay/match missing_ok=True
-  deployments:
+  workloads:
   #@overlay/match by="name",missing_ok=True
   - name: #@ data.values.deployName
     #@overlay/match missing_ok=True
@@ -211,7 +211,7 @@ metadata:
 #@overlay/match missing_ok=True
 spec:
   #@overlay/match missing_ok=True
-  deployments:
+  workloads:
   #@overlay/match by="name",missing_ok=True
   - name: #@ data.values.deployName
     #@overlay/match missing_ok=True
@@ -397,6 +397,15 @@ func TestValidateResourcesFlags(t *testing.T) {
 			Container:  "activator",
 		},
 		expectedResult: fmt.Errorf("You need to specify the namespace."),
+	}, {
+		name: "Knative Serving with all the deployments and no container",
+		resourcesCMDFlags: ResourcesFlags{
+			RequestMemory:  "999M",
+			Component:      "serving",
+			Namespace:      "test-serving",
+			AllDeployments: true,
+		},
+		expectedResult: nil,
 	}} {
 		t.Run(tt.name, func(t *testing.T) {
 			result := validateResourcesFlags(tt.resourcesCMDFlags)
@@ -39,6 +39,8 @@ type TolerationsFlags struct {
 	Component  string
 	Namespace  string
 	DeployName string
+
+	AllDeployments bool
 }
 
 var tolerationsCMDFlags TolerationsFlags
@@ -58,7 +60,9 @@ func newTolerationsCommand(p *pkg.OperatorParams) *cobra.Command {
 		Short: "Configure the tolerations for Knative Serving and Eventing deployments",
 		Example: `
   # Configure the tolerations for Knative Serving and Eventing deployments
-  kn operator configure tolerations --component eventing --deployName eventing-controller --key example-key --operator Exists --effect NoSchedule --namespace knative-eventing`,
+  kn operator configure tolerations --component eventing --deployName eventing-controller --key example-key --operator Exists --effect NoSchedule --namespace knative-eventing
+  # Configure the tolerations for all the deployments of Knative Eventing
+  kn operator configure tolerations --component eventing --all-deployments --key example-key --operator Exists --effect NoSchedule --namespace knative-eventing`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := validateTolerationsFlags(tolerationsCMDFlags); err != nil {
 				return err
@@ -80,6 +84,7 @@ func newTolerationsCommand(p *pkg.OperatorParams) *cobra.Command {
 	configureTolerationsCmd.Flags().StringVar(&tolerationsCMDFlags.Operator, "operator", "", "The flag to specify the operator")
 	configureTolerationsCmd.Flags().StringVar(&tolerationsCMDFlags.Effect, "effect", "", "The flag to specify the effect")
 	configureTolerationsCmd.Flags().StringVar(&tolerationsCMDFlags.DeployName, "deployName", "", "The flag to specify the deployment name")
+	configureTolerationsCmd.Flags().BoolVar(&tolerationsCMDFlags.AllDeployments, "all-deployments", false, allDeploymentsUsage)
 	configureTolerationsCmd.Flags().StringVarP(&tolerationsCMDFlags.Component, "component", "c", "", "The flag to specify the component name")
 	configureTolerationsCmd.Flags().StringVarP(&tolerationsCMDFlags.Namespace, "namespace", "n", "", "The namespace of the Knative Operator or the Knative component")
 
@@ -102,7 +107,10 @@ func validateTolerationsFlags(tolerationsCMDFlags TolerationsFlags) error {
 	if strings.EqualFold(tolerationsCMDFlags.Operator, "Equal") && tolerationsCMDFlags.Value == "" {
 		return fmt.Errorf("You need to specify the value, if the Operator is Equal.")
 	}
-	if tolerationsCMDFlags.DeployName == "" {
+	if err := validateAllDeploymentsFlags(tolerationsCMDFlags.AllDeployments, tolerationsCMDFlags.DeployName); err != nil {
+		return err
+	}
+	if tolerationsCMDFlags.DeployName == "" && !tolerationsCMDFlags.AllDeployments {
 		return fmt.Errorf("You need to specify the name of the deployment.")
 	}
 	if tolerationsCMDFlags.Namespace == "" {
@@ -121,13 +129,18 @@ func configureTolerations(tolerationsCMDFlags TolerationsFlags, p *pkg.OperatorP
 		return err
 	}
 
-	overlayContent := getOverlayYamlContent(tolerationsCMDFlags)
-	valuesYaml := getYamlValuesContentTolerations(tolerationsCMDFlags)
-
-	if err = common.ApplyManifests(yamlTemplateString, overlayContent, valuesYaml, p); err != nil {
+	deployNames, err := getTargetDeployments(tolerationsCMDFlags.AllDeployments, component, tolerationsCMDFlags.Namespace,
+		tolerationsCMDFlags.DeployName, p)
+	if err != nil {
 		return err
 	}
-	return nil
+	overlays := []common.OverlayValues{}
+	for _, deployName := range deployNames {
+		flags := tolerationsCMDFlags
+		flags.DeployName = deployName
+		overlays = append(overlays, common.OverlayValues{Overlay: getOverlayYamlContent(flags), Values: getYamlValuesContentTolerations(flags)})
+	}
+	return common.ApplyManifestsWithOverlays(yamlTemplateString, overlays, p)
 }
 
 func getOverlayYamlContent(tolerationsCMDFlags TolerationsFlags) string {
@@ -49,7 +49,7 @@ metadata:
 #@overlay/match missing_ok=True
 spec:
   #@overlay/match missing_ok=True
-  deployments:
+  workloads:
   #@overlay/match by="name",missing_ok=True
   - name: #@ data.values.deployName
     #@overlay/match missing_ok=True
@@ -83,7 +83,7 @@ metadata:
 #@overlay/match missing_ok=True
 spec:
   #@overlay/match missing_ok=True
-  deployments:
+  workloads:
   #@overlay/match by="name",missing_ok=True
   - name: #@ data.values.deployName
     #@overlay/match missing_ok=True
@@ -212,6 +212,29 @@ func TestValidateTolerationsFlags(t *testing.T) {
 			DeployName: "test",
 		},
 		expectedResult: fmt.Errorf("You need to specify the value, if the Operator is Equal."),
+	}, {
+		name: "Knative Serving with all the deployments",
+		tolerationsCMDFlags: TolerationsFlags{
+			Key:            "test-key",
+			Operator:       "Exists",
+			Effect:         "NoSchedule",
+			Component:      "serving",
+			Namespace:      "test-serving",
+			AllDeployments: true,
+		},
+		expectedResult: nil,
+	}, {
+		name: "Knative Serving with all the deployments and the deployment name",
+		tolerationsCMDFlags: TolerationsFlags{
+			Key:            "test-key",
+			Operator:       "Exists",
+			Effect:         "NoSchedule",
+			Component:      "serving",
+			Namespace:      "test-serving",
+			DeployName:     "controller",
+			AllDeployments: true,
+		},
+		expectedResult: fmt.Errorf("You cannot specify the name of the deployment together with --all-deployments."),
 	}} {
 		t.Run(tt.name, func(t *testing.T) {
 			result := validateTolerationsFlags(tt.tolerationsCMDFlags)
@@ -0,0 +1,222 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configure
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc" // from https://github.com/kubernetes/client-go/issues/345
+
+	"knative.dev/kn-plugin-operator/pkg"
+	"knative.dev/kn-plugin-operator/pkg/command/common"
+)
+
+//go:embed overlay/ks_topology_spread.yaml
+var servingTopologySpreadOverlay string
+
+//go:embed overlay/ke_topology_spread.yaml
+var eventingTopologySpreadOverlay string
+
+type TopologySpreadFlags struct {
+	TopologyKey       string
+	MaxSkew           int32
+	WhenUnsatisfiable string
+	LabelSelector     string
+	Component         string
+	Namespace         string
+	DeployName        string
+}
+
+var topologySpreadCMDFlags TopologySpreadFlags
+
+func getValidWhenUnsatisfiable() []string {
+	return []string{"DoNotSchedule", "ScheduleAnyway"}
+}
+
+// newTopologySpreadCommand represents the configure commands to configure the topology spread constraints for Knative deployments
+func newTopologySpreadCommand(p *pkg.OperatorParams) *cobra.Command {
+	var configureTopologySpreadCmd = &cobra.Command{
+		Use:   "topology-spread",
+		Short: "Configure the topology spread constraints for Knative Serving and Eventing deployments",
+		Example: `
+  # Spread the pods of the deployment activator evenly across zones
+  kn operator configure topology-spread --component serving --deployName activator --topologyKey topology.kubernetes.io/zone --maxSkew 1 --whenUnsatisfiable DoNotSchedule --namespace knative-serving`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateTopologySpreadFlags(topologySpreadCMDFlags); err != nil {
+				return err
+			}
+
+			err := configureTopologySpread(topologySpreadCMDFlags, p)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "The specified topology spread constraint has been configured for the deployment %s in the namespace '%s'.\n",
+				topologySpreadCMDFlags.DeployName, topologySpreadCMDFlags.Namespace)
+			return nil
+		},
+	}
+
+	configureTopologySpreadCmd.Flags().StringVar(&topologySpreadCMDFlags.TopologyKey, "topologyKey", "", "The key of the node labels defining the topology domain")
+	configureTopologySpreadCmd.Flags().Int32Var(&topologySpreadCMDFlags.MaxSkew, "maxSkew", 1, "The maximum permitted difference of the number of pods between topology domains")
+	configureTopologySpreadCmd.Flags().StringVar(&topologySpreadCMDFlags.WhenUnsatisfiable, "whenUnsatisfiable", "DoNotSchedule", "The way to deal with a pod if it does not satisfy the constraint: DoNotSchedule or ScheduleAnyway")
+	configureTopologySpreadCmd.Flags().StringVar(&topologySpreadCMDFlags.LabelSelector, "labelSelector", "", "The comma separated key=value labels of the pods to spread (default is the pod labels of the deployment)")
+	configureTopologySpreadCmd.Flags().StringVar(&topologySpreadCMDFlags.DeployName, "deployName", "", "The flag to specify the deployment name")
+	configureTopologySpreadCmd.Flags().StringVarP(&topologySpreadCMDFlags.Component, "component", "c", "", "The flag to specify the component name")
+	configureTopologySpreadCmd.Flags().StringVarP(&topologySpreadCMDFlags.Namespace, "namespace", "n", "", "The namespace of the Knative Operator or the Knative component")
+
+	return configureTopologySpreadCmd
+}
+
+func validateTopologySpreadFlags(topologySpreadCMDFlags TopologySpreadFlags) error {
+	if topologySpreadCMDFlags.TopologyKey == "" {
+		return fmt.Errorf("You need to specify the topology key.")
+	}
+	if topologySpreadCMDFlags.MaxSkew < 1 {
+		return fmt.Errorf("You need to specify the maxSkew to an integer greater than 0.")
+	}
+	if !common.Contains(getValidWhenUnsatisfiable(), topologySpreadCMDFlags.WhenUnsatisfiable) {
+		return fmt.Errorf("You need to specify the whenUnsatisfiable to one of the following values: DoNotSchedule or ScheduleAnyway.")
+	}
+	if _, err := parseLabelSelector(topologySpreadCMDFlags.LabelSelector); err != nil {
+		return err
+	}
+	if topologySpreadCMDFlags.DeployName == "" {
+		return fmt.Errorf("You need to specify the name of the deployment.")
+	}
+	if topologySpreadCMDFlags.Namespace == "" {
+		return fmt.Errorf("You need to specify the namespace.")
+	}
+	if topologySpreadCMDFlags.Component == "" {
+		return fmt.Errorf("You need to specify the component name.")
+	}
+	if !strings.EqualFold(topologySpreadCMDFlags.Component, common.ServingComponent) && !strings.EqualFold(topologySpreadCMDFlags.Component, common.EventingComponent) {
+		return fmt.Errorf("You need to specify the component for Knative: serving or eventing.")
+	}
+	return nil
+}
+
+func configureTopologySpread(topologySpreadCMDFlags TopologySpreadFlags, p *pkg.OperatorParams) error {
+	component := common.ServingComponent
+	if strings.EqualFold(topologySpreadCMDFlags.Component, common.EventingComponent) {
+		component = common.EventingComponent
+	}
+
+	matchLabels, err := parseLabelSelector(topologySpreadCMDFlags.LabelSelector)
+	if err != nil {
+		return err
+	}
+	if len(matchLabels) == 0 {
+		// Default to the labels selecting the pods of the deployment itself
+		if matchLabels, err = getDeploymentPodLabels(topologySpreadCMDFlags.DeployName, topologySpreadCMDFlags.Namespace, p); err != nil {
+			return err
+		}
+	}
+
+	yamlTemplateString, err := common.GenerateOperatorCRString(component, topologySpreadCMDFlags.Namespace, p)
+	if err != nil {
+		return err
+	}
+
+	overlayContent := getOverlayYamlContentTopologySpread(topologySpreadCMDFlags)
+	valuesYaml, err := getYamlValuesContentTopologySpread(topologySpreadCMDFlags, matchLabels)
+	if err != nil {
+		return err
+	}
+
+	if err = common.ApplyManifests(yamlTemplateString, overlayContent, valuesYaml, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+// getDeploymentPodLabels returns the pod labels of the deployment in the cluster. If the deployment is not
+// available, the label app set to the name of the deployment is used, as all Knative deployments have it.
+func getDeploymentPodLabels(deployName, namespace string, p *pkg.OperatorParams) (map[string]string, error) {
+	client, err := p.NewKubeClient()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get source cluster kube config, please use --kubeconfig or export environment variable KUBECONFIG to set\n")
+	}
+	deploy := common.Deployment{
+		Client: client,
+	}
+
+	labels, err := deploy.GetPodLabels(deployName, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if len(labels) == 0 {
+		labels = map[string]string{"app": deployName}
+	}
+	return labels, nil
+}
+
+func parseLabelSelector(labelSelector string) (map[string]string, error) {
+	labels := map[string]string{}
+	for _, pair := range strings.Split(labelSelector, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		keyValue := strings.SplitN(pair, "=", 2)
+		if len(keyValue) != 2 || keyValue[0] == "" {
+			return nil, fmt.Errorf("You need to specify the labelSelector in the format of key1=value1,key2=value2.")
+		}
+		labels[keyValue[0]] = keyValue[1]
+	}
+	return labels, nil
+}
+
+func getOverlayYamlContentTopologySpread(topologySpreadCMDFlags TopologySpreadFlags) string {
+	baseOverlayContent := servingTopologySpreadOverlay
+	if strings.EqualFold(topologySpreadCMDFlags.Component, common.EventingComponent) {
+		baseOverlayContent = eventingTopologySpreadOverlay
+	}
+	return baseOverlayContent
+}
+
+func getYamlValuesContentTopologySpread(topologySpreadCMDFlags TopologySpreadFlags, matchLabels map[string]string) (string, error) {
+	contentArray := []string{}
+	header := "#@data/values\n---"
+	contentArray = append(contentArray, header)
+
+	namespace := fmt.Sprintf("namespace: %s", topologySpreadCMDFlags.Namespace)
+	contentArray = append(contentArray, namespace)
+
+	deployName := fmt.Sprintf("deployName: %s", topologySpreadCMDFlags.DeployName)
+	contentArray = append(contentArray, deployName)
+
+	topologyKey := fmt.Sprintf("topologyKey: %s", topologySpreadCMDFlags.TopologyKey)
+	contentArray = append(contentArray, topologyKey)
+
+	maxSkew := fmt.Sprintf("maxSkew: %d", topologySpreadCMDFlags.MaxSkew)
+	contentArray = append(contentArray, maxSkew)
+
+	whenUnsatisfiable := fmt.Sprintf("whenUnsatisfiable: %s", topologySpreadCMDFlags.WhenUnsatisfiable)
+	contentArray = append(contentArray, whenUnsatisfiable)
+
+	value, err := json.Marshal(&metav1.LabelSelector{MatchLabels: matchLabels})
+	if err != nil {
+		return "", err
+	}
+	labelSelector := fmt.Sprintf("labelSelector: %s", value)
+	contentArray = append(contentArray, labelSelector)
+
+	return strings.Join(contentArray, "\n"), nil
+}
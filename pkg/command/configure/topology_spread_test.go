@@ -0,0 +1,207 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configure
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"knative.dev/kn-plugin-operator/pkg/command/testingUtil"
+)
+
+func TestValidateTopologySpreadFlags(t *testing.T) {
+	for _, tt := range []struct {
+		name                   string
+		topologySpreadCMDFlags TopologySpreadFlags
+		expectedResult         error
+	}{{
+		name: "Topology spread flags with all the parameters",
+		topologySpreadCMDFlags: TopologySpreadFlags{
+			TopologyKey:       "topology.kubernetes.io/zone",
+			MaxSkew:           1,
+			WhenUnsatisfiable: "DoNotSchedule",
+			LabelSelector:     "app=activator,role=test",
+			Component:         "serving",
+			Namespace:         "test-serving",
+			DeployName:        "activator",
+		},
+		expectedResult: nil,
+	}, {
+		name: "Topology spread flags without topology key",
+		topologySpreadCMDFlags: TopologySpreadFlags{
+			MaxSkew:           1,
+			WhenUnsatisfiable: "DoNotSchedule",
+			Component:         "serving",
+			Namespace:         "test-serving",
+			DeployName:        "activator",
+		},
+		expectedResult: fmt.Errorf("You need to specify the topology key."),
+	}, {
+		name: "Topology spread flags with invalid maxSkew",
+		topologySpreadCMDFlags: TopologySpreadFlags{
+			TopologyKey:       "topology.kubernetes.io/zone",
+			MaxSkew:           0,
+			WhenUnsatisfiable: "DoNotSchedule",
+			Component:         "serving",
+			Namespace:         "test-serving",
+			DeployName:        "activator",
+		},
+		expectedResult: fmt.Errorf("You need to specify the maxSkew to an integer greater than 0."),
+	}, {
+		name: "Topology spread flags with invalid whenUnsatisfiable",
+		topologySpreadCMDFlags: TopologySpreadFlags{
+			TopologyKey:       "topology.kubernetes.io/zone",
+			MaxSkew:           1,
+			WhenUnsatisfiable: "Ignore",
+			Component:         "serving",
+			Namespace:         "test-serving",
+			DeployName:        "activator",
+		},
+		expectedResult: fmt.Errorf("You need to specify the whenUnsatisfiable to one of the following values: DoNotSchedule or ScheduleAnyway."),
+	}, {
+		name: "Topology spread flags with invalid label selector",
+		topologySpreadCMDFlags: TopologySpreadFlags{
+			TopologyKey:       "topology.kubernetes.io/zone",
+			MaxSkew:           1,
+			WhenUnsatisfiable: "ScheduleAnyway",
+			LabelSelector:     "app",
+			Component:         "serving",
+			Namespace:         "test-serving",
+			DeployName:        "activator",
+		},
+		expectedResult: fmt.Errorf("You need to specify the labelSelector in the format of key1=value1,key2=value2."),
+	}, {
+		name: "Topology spread flags without deployment name",
+		topologySpreadCMDFlags: TopologySpreadFlags{
+			TopologyKey:       "topology.kubernetes.io/zone",
+			MaxSkew:           1,
+			WhenUnsatisfiable: "DoNotSchedule",
+			Component:         "serving",
+			Namespace:         "test-serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify the name of the deployment."),
+	}, {
+		name: "Topology spread flags with invalid component",
+		topologySpreadCMDFlags: TopologySpreadFlags{
+			TopologyKey:       "topology.kubernetes.io/zone",
+			MaxSkew:           1,
+			WhenUnsatisfiable: "DoNotSchedule",
+			Component:         "test",
+			Namespace:         "test-serving",
+			DeployName:        "activator",
+		},
+		expectedResult: fmt.Errorf("You need to specify the component for Knative: serving or eventing."),
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := validateTopologySpreadFlags(tt.topologySpreadCMDFlags)
+			if tt.expectedResult == nil {
+				testingUtil.AssertEqual(t, result, nil)
+			} else {
+				testingUtil.AssertEqual(t, result.Error(), tt.expectedResult.Error())
+			}
+		})
+	}
+}
+
+func TestGetOverlayYamlContentTopologySpread(t *testing.T) {
+	for _, tt := range []struct {
+		name                   string
+		topologySpreadCMDFlags TopologySpreadFlags
+		expectedResultFile     string
+	}{{
+		name: "Knative Serving",
+		topologySpreadCMDFlags: TopologySpreadFlags{
+			Component: "serving",
+		},
+		expectedResultFile: "testdata/overlay/ks_topology_spread.yaml",
+	}, {
+		name: "Knative Eventing",
+		topologySpreadCMDFlags: TopologySpreadFlags{
+			Component: "eventing",
+		},
+		expectedResultFile: "testdata/overlay/ke_topology_spread.yaml",
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := getOverlayYamlContentTopologySpread(tt.topologySpreadCMDFlags)
+			expected, err := os.ReadFile(tt.expectedResultFile)
+			testingUtil.AssertEqual(t, err, nil)
+			testingUtil.AssertEqual(t, result, string(expected))
+		})
+	}
+}
+
+func TestGetYamlValuesContentTopologySpread(t *testing.T) {
+	for _, tt := range []struct {
+		name                   string
+		topologySpreadCMDFlags TopologySpreadFlags
+		matchLabels            map[string]string
+		expectedResult         string
+	}{{
+		name: "Knative Serving",
+		topologySpreadCMDFlags: TopologySpreadFlags{
+			TopologyKey:       "topology.kubernetes.io/zone",
+			MaxSkew:           1,
+			WhenUnsatisfiable: "DoNotSchedule",
+			Component:         "serving",
+			Namespace:         "test-serving",
+			DeployName:        "activator",
+		},
+		matchLabels: map[string]string{"app": "activator", "role": "activator"},
+		expectedResult: `#@data/values
+---
+namespace: test-serving
+deployName: activator
+topologyKey: topology.kubernetes.io/zone
+maxSkew: 1
+whenUnsatisfiable: DoNotSchedule
+labelSelector: {"matchLabels":{"app":"activator","role":"activator"}}`,
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := getYamlValuesContentTopologySpread(tt.topologySpreadCMDFlags, tt.matchLabels)
+			testingUtil.AssertEqual(t, err, nil)
+			testingUtil.AssertEqual(t, result, tt.expectedResult)
+		})
+	}
+}
+
+func TestParseLabelSelector(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		labelSelector  string
+		expectedResult map[string]string
+		expectedErr    bool
+	}{{
+		name:           "Empty label selector",
+		labelSelector:  "",
+		expectedResult: map[string]string{},
+	}, {
+		name:           "Label selector with multiple labels",
+		labelSelector:  "app=activator, role=test",
+		expectedResult: map[string]string{"app": "activator", "role": "test"},
+	}, {
+		name:          "Label selector without value",
+		labelSelector: "app",
+		expectedErr:   true,
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseLabelSelector(tt.labelSelector)
+			testingUtil.AssertEqual(t, err != nil, tt.expectedErr)
+			if !tt.expectedErr {
+				testingUtil.AssertDeepEqual(t, result, tt.expectedResult)
+			}
+		})
+	}
+}
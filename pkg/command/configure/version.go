@@ -0,0 +1,214 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configure
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc" // from https://github.com/kubernetes/client-go/issues/345
+
+	"knative.dev/kn-plugin-operator/pkg"
+	"knative.dev/kn-plugin-operator/pkg/command/common"
+	"knative.dev/kn-plugin-operator/pkg/command/install"
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+//go:embed overlay/ks_version.yaml
+var servingVersionOverlay string
+
+//go:embed overlay/ke_version.yaml
+var eventingVersionOverlay string
+
+type VersionFlags struct {
+	Version   string
+	Component string
+	Namespace string
+}
+
+var versionCMDFlags VersionFlags
+
+// newVersionCommand represents the configure commands to configure the version of Knative Serving or Eventing
+func newVersionCommand(p *pkg.OperatorParams) *cobra.Command {
+	var configureVersionCmd = &cobra.Command{
+		Use:   "version",
+		Short: "Configure the version of Knative Serving or Eventing",
+		Long: `Configure the version of the installed Knative Serving or Eventing.
+
+The version is changed one minor version at a time, waiting for Knative to be ready after each stage, in the same
+way as the install command upgrades or downgrades Knative.`,
+		Example: `
+  # Configure the version of Knative Serving to 1.8
+  kn operator configure version --version 1.8 --component serving --namespace knative-serving`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateVersionFlags(versionCMDFlags); err != nil {
+				return err
+			}
+
+			err := configureVersion(cmd, versionCMDFlags, p)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "The version of Knative %s has been configured to %s in the namespace '%s'.\n",
+				versionCMDFlags.Component, versionCMDFlags.Version, versionCMDFlags.Namespace)
+			return nil
+		},
+	}
+
+	configureVersionCmd.Flags().StringVar(&versionCMDFlags.Version, "version", "", "The version of Knative Serving or Eventing")
+	configureVersionCmd.Flags().StringVarP(&versionCMDFlags.Component, "component", "c", "", "The name of the Knative Component: serving or eventing")
+	configureVersionCmd.Flags().StringVarP(&versionCMDFlags.Namespace, "namespace", "n", "", "The namespace of the Knative component")
+
+	return configureVersionCmd
+}
+
+func validateVersionFlags(versionCMDFlags VersionFlags) error {
+	if versionCMDFlags.Version == "" {
+		return fmt.Errorf("You need to specify the version.")
+	}
+	if versionCMDFlags.Version != common.Latest && versionCMDFlags.Version != common.Nightly {
+		version := versionCMDFlags.Version
+		if !strings.HasPrefix(version, "v") {
+			version = fmt.Sprintf("v%s", version)
+		}
+		if valid, _ := common.GetMajor(version); !valid {
+			return fmt.Errorf("The version %s is not a valid semantic version.", versionCMDFlags.Version)
+		}
+	}
+	if !strings.EqualFold(versionCMDFlags.Component, common.ServingComponent) && !strings.EqualFold(versionCMDFlags.Component, common.EventingComponent) {
+		return fmt.Errorf("You need to specify the component for Knative: serving or eventing.")
+	}
+	if versionCMDFlags.Namespace == "" {
+		return fmt.Errorf("You need to specify the namespace.")
+	}
+	return nil
+}
+
+func configureVersion(cmd *cobra.Command, versionCMDFlags VersionFlags, p *pkg.OperatorParams) error {
+	currentVersion, err := getCurrentVersion(versionCMDFlags, p)
+	if err != nil {
+		return err
+	}
+
+	versions, err := install.GenerateVersionStages(currentVersion, versionCMDFlags.Version)
+	if err != nil {
+		return err
+	}
+
+	for _, version := range versions {
+		fmt.Fprintf(cmd.OutOrStdout(), "Migrating Knative %s to Version %s...\n", versionCMDFlags.Component, version)
+		stageFlags := versionCMDFlags
+		stageFlags.Version = version
+
+		yamlTemplateString, err := common.GenerateOperatorCRString(versionCMDFlags.Component, versionCMDFlags.Namespace, p)
+		if err != nil {
+			return err
+		}
+
+		overlayContent := getOverlayYamlContentVersion(stageFlags)
+		if err = common.ApplyManifests(yamlTemplateString, overlayContent, getYamlValuesContentVersion(stageFlags), p); err != nil {
+			return err
+		}
+
+		if err = install.EnsureKnativeComponentReady(versionCMDFlags.Component, versionCMDFlags.Namespace, version, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getCurrentVersion returns the version of the installed Knative component. The version comes from the custom
+// resource, which also covers Knative running in a remote cluster. The local deployments are only checked, if the
+// custom resource is not found in the namespace, or its version is not known yet.
+func getCurrentVersion(versionCMDFlags VersionFlags, p *pkg.OperatorParams) (string, error) {
+	ksCR, err := common.GetKnativeOperatorCR(p)
+	if err != nil {
+		return "", err
+	}
+	state, err := ksCR.GetKnativeCRState(versionCMDFlags.Component, versionCMDFlags.Namespace)
+	if err != nil {
+		return "", err
+	}
+	notReconciledErr := fmt.Errorf("The current version of Knative %s in the namespace %s is unknown, since the custom resource has not been reconciled yet. Please wait until it is ready.",
+		versionCMDFlags.Component, versionCMDFlags.Namespace)
+	if state != nil {
+		commonSpec, err := ksCR.GetCommonSpec(versionCMDFlags.Component, versionCMDFlags.Namespace)
+		if err != nil {
+			return "", err
+		}
+		if version := getCRVersion(state, commonSpec); version != "" {
+			return version, nil
+		}
+		if state.ClusterProfileRef != nil {
+			return "", notReconciledErr
+		}
+	}
+
+	client, err := p.NewKubeClient()
+	if err != nil {
+		return "", fmt.Errorf("cannot get source cluster kube config, please use --kubeconfig or export environment variable KUBECONFIG to set\n")
+	}
+	deploy := common.Deployment{
+		Client: client,
+	}
+	exists, ns, currentVersion, err := deploy.CheckIfKnativeInstalled(versionCMDFlags.Component)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		if state != nil {
+			return "", notReconciledErr
+		}
+		return "", fmt.Errorf("Knative %s is not installed. Please use the install command instead.", versionCMDFlags.Component)
+	}
+	if !strings.EqualFold(ns, versionCMDFlags.Namespace) {
+		return "", fmt.Errorf("The namespace %s you specified is not consistent with the existing namespace for Knative Component %s",
+			versionCMDFlags.Namespace, ns)
+	}
+	return currentVersion, nil
+}
+
+// getCRVersion returns status.version of the custom resource, or spec.version until the custom resource is reconciled
+// for the first time. It returns an empty string, if spec.version is not a release version either.
+func getCRVersion(state *common.KnativeCRState, commonSpec *base.CommonSpec) string {
+	if state.Version != "" {
+		return state.Version
+	}
+	if strings.EqualFold(commonSpec.Version, common.Latest) || strings.EqualFold(commonSpec.Version, common.Nightly) {
+		return ""
+	}
+	return commonSpec.Version
+}
+
+func getOverlayYamlContentVersion(versionCMDFlags VersionFlags) string {
+	if strings.EqualFold(versionCMDFlags.Component, common.EventingComponent) {
+		return eventingVersionOverlay
+	}
+	return servingVersionOverlay
+}
+
+func getYamlValuesContentVersion(versionCMDFlags VersionFlags) string {
+	contentArray := []string{}
+	header := "#@data/values\n---"
+	contentArray = append(contentArray, header)
+	namespace := fmt.Sprintf("namespace: %s", versionCMDFlags.Namespace)
+	contentArray = append(contentArray, namespace)
+	version := fmt.Sprintf("version: \"%s\"", versionCMDFlags.Version)
+	contentArray = append(contentArray, version)
+	return strings.Join(contentArray, "\n")
+}
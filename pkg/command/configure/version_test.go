@@ -0,0 +1,171 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configure
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"knative.dev/kn-plugin-operator/pkg/command/common"
+	"knative.dev/kn-plugin-operator/pkg/command/testingUtil"
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+func TestValidateVersionFlags(t *testing.T) {
+	for _, tt := range []struct {
+		name            string
+		versionCMDFlags VersionFlags
+		expectedResult  error
+	}{{
+		name: "Knative Serving",
+		versionCMDFlags: VersionFlags{
+			Version:   "1.8",
+			Component: "serving",
+			Namespace: "knative-serving",
+		},
+		expectedResult: nil,
+	}, {
+		name: "Knative Eventing with latest",
+		versionCMDFlags: VersionFlags{
+			Version:   "latest",
+			Component: "eventing",
+			Namespace: "knative-eventing",
+		},
+		expectedResult: nil,
+	}, {
+		name: "Knative Serving without version",
+		versionCMDFlags: VersionFlags{
+			Component: "serving",
+			Namespace: "knative-serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify the version."),
+	}, {
+		name: "Knative Serving with invalid version",
+		versionCMDFlags: VersionFlags{
+			Version:   "invalid",
+			Component: "serving",
+			Namespace: "knative-serving",
+		},
+		expectedResult: fmt.Errorf("The version invalid is not a valid semantic version."),
+	}, {
+		name: "Knative Serving without component",
+		versionCMDFlags: VersionFlags{
+			Version:   "1.8",
+			Namespace: "knative-serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify the component for Knative: serving or eventing."),
+	}, {
+		name: "Knative Serving without namespace",
+		versionCMDFlags: VersionFlags{
+			Version:   "1.8",
+			Component: "serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify the namespace."),
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := validateVersionFlags(tt.versionCMDFlags)
+			if tt.expectedResult == nil {
+				testingUtil.AssertEqual(t, result, nil)
+			} else {
+				testingUtil.AssertEqual(t, result.Error(), tt.expectedResult.Error())
+			}
+		})
+	}
+}
+
+func TestGetCRVersion(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		state          *common.KnativeCRState
+		commonSpec     *base.CommonSpec
+		expectedResult string
+	}{{
+		name:           "Reconciled custom resource",
+		state:          &common.KnativeCRState{Version: "1.12.2"},
+		commonSpec:     &base.CommonSpec{Version: "1.13"},
+		expectedResult: "1.12.2",
+	}, {
+		name:           "Custom resource not reconciled yet",
+		state:          &common.KnativeCRState{},
+		commonSpec:     &base.CommonSpec{Version: "1.11"},
+		expectedResult: "1.11",
+	}, {
+		name:           "Custom resource not reconciled yet with the latest version",
+		state:          &common.KnativeCRState{},
+		commonSpec:     &base.CommonSpec{Version: "latest"},
+		expectedResult: "",
+	}, {
+		name:           "Custom resource not reconciled yet without version",
+		state:          &common.KnativeCRState{},
+		commonSpec:     &base.CommonSpec{},
+		expectedResult: "",
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			testingUtil.AssertEqual(t, getCRVersion(tt.state, tt.commonSpec), tt.expectedResult)
+		})
+	}
+}
+
+func TestGetYamlValuesContentVersion(t *testing.T) {
+	for _, tt := range []struct {
+		name            string
+		versionCMDFlags VersionFlags
+		expectedResult  string
+	}{{
+		name: "Knative Serving",
+		versionCMDFlags: VersionFlags{
+			Version:   "1.8",
+			Component: "serving",
+			Namespace: "knative-serving",
+		},
+		expectedResult: `#@data/values
+---
+namespace: knative-serving
+version: "1.8"`,
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := getYamlValuesContentVersion(tt.versionCMDFlags)
+			testingUtil.AssertEqual(t, result, tt.expectedResult)
+		})
+	}
+}
+
+func TestGetOverlayYamlContentVersion(t *testing.T) {
+	for _, tt := range []struct {
+		name               string
+		versionCMDFlags    VersionFlags
+		expectedResultFile string
+	}{{
+		name: "Knative Serving",
+		versionCMDFlags: VersionFlags{
+			Component: "serving",
+		},
+		expectedResultFile: "testdata/overlay/ks_version.yaml",
+	}, {
+		name: "Knative Eventing",
+		versionCMDFlags: VersionFlags{
+			Component: "eventing",
+		},
+		expectedResultFile: "testdata/overlay/ke_version.yaml",
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := getOverlayYamlContentVersion(tt.versionCMDFlags)
+			expected, err := os.ReadFile(tt.expectedResultFile)
+			testingUtil.AssertEqual(t, err, nil)
+			testingUtil.AssertEqual(t, result, string(expected))
+		})
+	}
+}
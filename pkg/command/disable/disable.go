@@ -0,0 +1,36 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package disable
+
+import (
+	"github.com/spf13/cobra"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc" // from https://github.com/kubernetes/client-go/issues/345
+	"knative.dev/kn-plugin-operator/pkg"
+)
+
+// NewDisableCommand represents the disable commands for Knative Serving
+func NewDisableCommand(p *pkg.OperatorParams) *cobra.Command {
+	var disableCmd = &cobra.Command{
+		Use:   "disable",
+		Short: "Disable the features for Knative Serving",
+		Example: `
+  # Disable the security guard for Knative Serving
+  kn-operator disable security-guard --namespace knative-serving`,
+	}
+
+	disableCmd.AddCommand(newSecurityGuardCommand(p))
+
+	return disableCmd
+}
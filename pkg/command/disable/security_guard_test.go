@@ -0,0 +1,44 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package disable
+
+import (
+	"testing"
+
+	"knative.dev/kn-plugin-operator/pkg/command/testingUtil"
+)
+
+func TestGetSecurityGuardValuesContent(t *testing.T) {
+	for _, tt := range []struct {
+		name                  string
+		securityGuardCMDFlags securityGuardFlags
+		expectedResult        string
+	}{{
+		name: "Knative Serving with the security guard disabled",
+		securityGuardCMDFlags: securityGuardFlags{
+			Namespace: "test-serving",
+		},
+		expectedResult: `#@data/values
+---
+namespace: test-serving
+enabled: false`,
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := getSecurityGuardValuesContent(tt.securityGuardCMDFlags)
+			testingUtil.AssertEqual(t, result, tt.expectedResult)
+		})
+	}
+}
@@ -0,0 +1,456 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc" // from https://github.com/kubernetes/client-go/issues/345
+
+	"knative.dev/kn-plugin-operator/pkg"
+	"knative.dev/kn-plugin-operator/pkg/command/common"
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+type editFlags struct {
+	Component string
+	Namespace string
+	Force     bool
+}
+
+var editCmdFlags editFlags
+
+// openEditor opens the file in the editor and waits for it to exit
+var openEditor = func(path string) error {
+	editor := getEditor()
+	cmd := exec.Command(editor[0], append(editor[1:], path)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// NewEditCommand represents the command to edit the spec of the Knative custom resources in an editor
+func NewEditCommand(p *pkg.OperatorParams) *cobra.Command {
+	var editCmd = &cobra.Command{
+		Use:   "edit",
+		Short: "Edit the spec of the Knative Serving or Eventing custom resource",
+		Long: `Edit the spec of the Knative Serving or Eventing custom resource in the editor set by KUBE_EDITOR or EDITOR,
+falling back to vi. The fields shared by Knative Serving and Eventing are edited, like config, workloads and services.
+
+The spec is validated after the editor exits: the changed values of the known ConfigMaps, the resource quantities,
+the tolerations and the names of the deployments. If the spec is invalid, the editor is reopened with the errors at
+the top of the file. The spec is applied once it is valid. Save an empty file to cancel the edit. Only the values and
+the deployment names added or changed by the edit are checked. With --force, the values of the ConfigMaps not matching
+the catalogue and the unknown deployment names are reported as warnings instead of errors.
+
+If the custom resource is changed while the editor is open, the edit is not applied, and the edited spec is kept in a
+temporary file.`,
+		Example: `
+  # Edit the spec of Knative Serving
+  kn operator edit -c serving --namespace knative-serving
+  # Edit the spec of Knative Eventing with vim
+  KUBE_EDITOR=vim kn operator edit -c eventing --namespace knative-eventing`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateEditFlags(editCmdFlags); err != nil {
+				return err
+			}
+
+			if editCmdFlags.Namespace == "" {
+				editCmdFlags.Namespace = common.DefaultKnativeServingNamespace
+				if strings.EqualFold(editCmdFlags.Component, common.EventingComponent) {
+					editCmdFlags.Namespace = common.DefaultKnativeEventingNamespace
+				}
+			}
+
+			return editSpec(cmd, editCmdFlags, p)
+		},
+	}
+
+	editCmd.Flags().StringVarP(&editCmdFlags.Component, "component", "c", "", "The name of the Knative Component: serving or eventing")
+	editCmd.Flags().StringVarP(&editCmdFlags.Namespace, "namespace", "n", "", "The namespace of the Knative component")
+	editCmd.Flags().BoolVar(&editCmdFlags.Force, "force", false, "The flag to apply the invalid values of the ConfigMaps and the unknown deployment names with warnings instead of errors")
+
+	return editCmd
+}
+
+func validateEditFlags(editCmdFlags editFlags) error {
+	if !strings.EqualFold(editCmdFlags.Component, common.ServingComponent) &&
+		!strings.EqualFold(editCmdFlags.Component, common.EventingComponent) {
+		return fmt.Errorf("You need to specify the component for Knative: serving or eventing.")
+	}
+	return nil
+}
+
+func editSpec(cmd *cobra.Command, editCmdFlags editFlags, p *pkg.OperatorParams) error {
+	component := common.ServingComponent
+	if strings.EqualFold(editCmdFlags.Component, common.EventingComponent) {
+		component = common.EventingComponent
+	}
+	ksCR, err := common.GetKnativeOperatorCR(p)
+	if err != nil {
+		return err
+	}
+	commonSpec, resourceVersion, err := ksCR.GetCommonSpecWithResourceVersion(component, editCmdFlags.Namespace)
+	if err != nil {
+		return err
+	}
+	state, err := ksCR.GetKnativeCRState(component, editCmdFlags.Namespace)
+	if err != nil {
+		return err
+	}
+	deployments, err := getDeploymentNames(component, editCmdFlags.Namespace, p)
+	if err != nil {
+		return err
+	}
+	catalogue, err := common.GetConfigMapCatalogue()
+	if err != nil {
+		return err
+	}
+
+	specValidator := &specValidator{
+		component:   component,
+		existing:    commonSpec,
+		deployments: deployments,
+		catalogue:   catalogue,
+		force:       editCmdFlags.Force,
+	}
+	if state != nil {
+		specValidator.version = state.Version
+	}
+	edited, err := editCommonSpec(component, commonSpec, specValidator.validate)
+	if err != nil {
+		return err
+	}
+	if edited == nil {
+		fmt.Fprintf(cmd.OutOrStdout(), "Edit cancelled, no changes made.\n")
+		return nil
+	}
+
+	// The spec is only applied to the custom resource as it was before the edit, so that the changes made meanwhile
+	// are not overwritten
+	err = ksCR.UpdateCommonSpecAtResourceVersion(component, editCmdFlags.Namespace, edited, resourceVersion)
+	if apierrs.IsConflict(err) {
+		path, saveErr := saveEditedSpec(edited)
+		if saveErr != nil {
+			return saveErr
+		}
+		return fmt.Errorf("Knative %s has been changed in the namespace '%s' while it was edited, so the edit is not applied. "+
+			"The edited spec is kept in %s. Please run the command again.", component, editCmdFlags.Namespace, path)
+	} else if err != nil {
+		return err
+	}
+	for _, warning := range specValidator.warnings {
+		fmt.Fprintf(cmd.OutOrStdout(), "Warning: %s\n", warning)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Knative %s has been edited in the namespace '%s'.\n", component, editCmdFlags.Namespace)
+	return nil
+}
+
+// getDeploymentNames returns the names of the deployments of the Knative component installed in the cluster
+func getDeploymentNames(component, namespace string, p *pkg.OperatorParams) ([]string, error) {
+	client, err := p.NewKubeClient()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get source cluster kube config, please use --kubeconfig or export environment variable KUBECONFIG to set\n")
+	}
+	deploy := common.Deployment{
+		Client: client,
+	}
+	deploymentContainers, err := deploy.GetComponentDeployments(component, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return common.DeploymentNames(deploymentContainers), nil
+}
+
+// saveEditedSpec writes the edited spec into a temporary file, which is kept, and returns the path of the file
+func saveEditedSpec(commonSpec *base.CommonSpec) (string, error) {
+	content, err := yaml.Marshal(commonSpec)
+	if err != nil {
+		return "", err
+	}
+	file, err := os.CreateTemp("", "kn-operator-edit-*.yaml")
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	if _, err = file.Write(content); err != nil {
+		return "", err
+	}
+	return file.Name(), nil
+}
+
+// getEditor returns the command of the editor set by KUBE_EDITOR or EDITOR, the same as kubectl edit
+func getEditor() []string {
+	for _, env := range []string{"KUBE_EDITOR", "EDITOR"} {
+		if editor := strings.Fields(os.Getenv(env)); len(editor) > 0 {
+			return editor
+		}
+	}
+	return []string{"vi"}
+}
+
+// editCommonSpec opens the spec in the editor until the edited spec is valid. It returns nil, if the edit is
+// cancelled with an empty file or if the spec is not changed.
+func editCommonSpec(component string, commonSpec *base.CommonSpec, validate func(*base.CommonSpec) []string) (*base.CommonSpec, error) {
+	original, err := yaml.Marshal(commonSpec)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.CreateTemp("", "kn-operator-edit-*.yaml")
+	if err != nil {
+		return nil, err
+	}
+	path := file.Name()
+	file.Close()
+	defer os.Remove(path)
+
+	content := string(original)
+	problems := []string{}
+	for {
+		if err := os.WriteFile(path, []byte(addEditHeader(component, content, problems)), 0600); err != nil {
+			return nil, err
+		}
+		if err := openEditor(path); err != nil {
+			return nil, fmt.Errorf("failed to run the editor: %w", err)
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		content = removeEditHeader(string(b))
+		if strings.TrimSpace(content) == "" || strings.TrimSpace(content) == strings.TrimSpace(string(original)) {
+			return nil, nil
+		}
+
+		var edited *base.CommonSpec
+		edited, problems = parseCommonSpec(content)
+		if len(problems) == 0 {
+			problems = validate(edited)
+		}
+		if len(problems) == 0 {
+			return edited, nil
+		}
+	}
+}
+
+// addEditHeader adds the instructions and the problems of the last edit as comments at the top of the content
+func addEditHeader(component, content string, problems []string) string {
+	contentArray := []string{
+		fmt.Sprintf("# Please edit the spec of Knative %s below. The lines beginning with '#' at the top of the file", component),
+		"# are ignored, and an empty file cancels the edit.",
+	}
+	if len(problems) > 0 {
+		contentArray = append(contentArray, "#", "# The spec is invalid:")
+		for _, problem := range problems {
+			contentArray = append(contentArray, fmt.Sprintf("# - %s", strings.ReplaceAll(problem, "\n", "\n#   ")))
+		}
+	}
+	contentArray = append(contentArray, "#", content)
+	return strings.Join(contentArray, "\n")
+}
+
+// removeEditHeader removes the comments at the top of the content. The comments further down are kept, since they can
+// be part of the values, like _example in the ConfigMaps.
+func removeEditHeader(content string) string {
+	lines := strings.Split(content, "\n")
+	for len(lines) > 0 && strings.HasPrefix(lines[0], "#") {
+		lines = lines[1:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// parseCommonSpec decodes the edited content into the spec, rejecting the unknown fields
+func parseCommonSpec(content string) (*base.CommonSpec, []string) {
+	jsonData, err := yaml.YAMLToJSON([]byte(content))
+	if err != nil {
+		return nil, []string{fmt.Sprintf("The spec cannot be parsed: %v", err)}
+	}
+	commonSpec := &base.CommonSpec{}
+	decoder := json.NewDecoder(bytes.NewReader(jsonData))
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(commonSpec); err != nil {
+		return nil, []string{fmt.Sprintf("The spec cannot be parsed: %v", err)}
+	}
+	return commonSpec, nil
+}
+
+// specValidator validates the edited spec against the existing spec and the installed Knative component
+type specValidator struct {
+	component   string
+	version     string
+	existing    *base.CommonSpec
+	deployments []string
+	catalogue   []common.ConfigMapEntry
+	force       bool
+	// warnings are the warnings of the last validation
+	warnings []string
+}
+
+// validate returns the problems of the edited spec
+func (v *specValidator) validate(commonSpec *base.CommonSpec) []string {
+	problems := []string{}
+	v.warnings = []string{}
+	if err := common.ValidateClusterProfileRefChange(v.component, v.existing.ClusterProfileRef, commonSpec.ClusterProfileRef); err != nil {
+		problems = append(problems, err.Error())
+	}
+	problems = append(problems, v.validateConfigMaps(commonSpec)...)
+	for _, workloads := range []struct {
+		field     string
+		overrides []base.WorkloadOverride
+	}{{"spec.workloads", commonSpec.Workloads}, {"spec.deployments", commonSpec.DeploymentOverride}} {
+		for _, workload := range workloads.overrides {
+			problems = append(problems, v.validateWorkloadName(workloads.field, workload.Name)...)
+			problems = append(problems, validateResources(fmt.Sprintf("the deployment %s", workload.Name), workload.Resources)...)
+			problems = append(problems, validateTolerations(workload.Name, workload.Tolerations)...)
+		}
+	}
+	problems = append(problems, validateResources("spec.resources", commonSpec.DeprecatedResources)...)
+	return problems
+}
+
+// validateConfigMaps checks the changed data of the ConfigMaps in the catalogue for the Knative version. The existing
+// data is not checked, so that it does not block the edit. The unknown keys are warnings, and the invalid values are
+// warnings too with force.
+func (v *specValidator) validateConfigMaps(commonSpec *base.CommonSpec) []string {
+	version := v.version
+	if commonSpec.Version != "" && !strings.EqualFold(commonSpec.Version, "latest") {
+		version = commonSpec.Version
+	}
+	names := make([]string, 0, len(commonSpec.Config))
+	for name := range commonSpec.Config {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	problems := []string{}
+	for _, name := range names {
+		entries := common.FindConfigMapEntries(v.catalogue, v.component, name)
+		if len(entries) == 0 {
+			continue
+		}
+		data := getChangedConfigMapData(v.existing.Config, name, commonSpec.Config[name])
+		cmProblems, warnings := common.CheckConfigMapData(entries[0], version, data)
+		if v.force {
+			v.warnings = append(v.warnings, cmProblems...)
+		} else {
+			problems = append(problems, cmProblems...)
+		}
+		v.warnings = append(v.warnings, warnings...)
+	}
+	return problems
+}
+
+// getChangedConfigMapData returns the keys of the ConfigMap, which are added or changed compared to the existing
+// config. The existing ConfigMap is found with or without the prefix config-.
+func getChangedConfigMapData(existing base.ConfigMapData, name string, data map[string]string) map[string]string {
+	existingData, found := existing[name]
+	if !found {
+		existingData = existing[common.AddOrRemovePrefix(name, common.ConfigMapPrefix)]
+	}
+	changed := map[string]string{}
+	for key, value := range data {
+		if existingValue, found := existingData[key]; !found || existingValue != value {
+			changed[key] = value
+		}
+	}
+	return changed
+}
+
+// validateWorkloadName checks that the deployment is installed. The name is not checked, if it is in the existing
+// spec, or if no deployment is found, e.g. when Knative is deployed into a remote cluster. The unknown name is a
+// warning with force.
+func (v *specValidator) validateWorkloadName(field, name string) []string {
+	if len(v.deployments) == 0 || common.Contains(v.deployments, name) || v.isExistingWorkload(name) {
+		return nil
+	}
+	problem := fmt.Sprintf("The deployment %s in %s is unknown in Knative %s.", name, field, v.component)
+	if suggestion := common.SuggestName(v.deployments, name); suggestion != "" {
+		problem = fmt.Sprintf("%s Did you mean %s?", problem, suggestion)
+	}
+	if v.force {
+		v.warnings = append(v.warnings, problem)
+		return nil
+	}
+	return []string{problem}
+}
+
+// isExistingWorkload checks whether the workload is overridden in spec.workloads or spec.deployments of the existing
+// spec, e.g. a StatefulSet or a deployment not installed yet
+func (v *specValidator) isExistingWorkload(name string) bool {
+	for _, overrides := range [][]base.WorkloadOverride{v.existing.Workloads, v.existing.DeploymentOverride} {
+		for _, workload := range overrides {
+			if workload.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// validateResources checks that the containers are specified and the requests do not exceed the limits
+func validateResources(target string, resources []base.ResourceRequirementsOverride) []string {
+	problems := []string{}
+	for _, resource := range resources {
+		if resource.Container == "" {
+			problems = append(problems, fmt.Sprintf("You need to specify the container of the resources in %s.", target))
+			continue
+		}
+		for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory, corev1.ResourceEphemeralStorage} {
+			request, hasRequest := resource.Requests[name]
+			limit, hasLimit := resource.Limits[name]
+			if hasRequest && hasLimit && request.Cmp(limit) > 0 {
+				problems = append(problems, fmt.Sprintf("The %s request %s of the container %s in %s should not exceed the limit %s.",
+					name, request.String(), resource.Container, target, limit.String()))
+			}
+		}
+	}
+	return problems
+}
+
+// validateTolerations checks the operators and the effects of the tolerations of the deployment
+func validateTolerations(deployName string, tolerations []corev1.Toleration) []string {
+	problems := []string{}
+	for _, toleration := range tolerations {
+		switch toleration.Operator {
+		case "", corev1.TolerationOpEqual:
+		case corev1.TolerationOpExists:
+			if toleration.Value != "" {
+				problems = append(problems, fmt.Sprintf("The toleration %s of the deployment %s should have no value with the operator Exists.",
+					toleration.Key, deployName))
+			}
+		default:
+			problems = append(problems, fmt.Sprintf("The operator %s of the toleration %s of the deployment %s should be Equal or Exists.",
+				toleration.Operator, toleration.Key, deployName))
+		}
+		switch toleration.Effect {
+		case "", corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+		default:
+			problems = append(problems, fmt.Sprintf("The effect %s of the toleration %s of the deployment %s should be NoSchedule, PreferNoSchedule or NoExecute.",
+				toleration.Effect, toleration.Key, deployName))
+		}
+	}
+	return problems
+}
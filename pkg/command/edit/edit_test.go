@@ -0,0 +1,262 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package edit
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"knative.dev/kn-plugin-operator/pkg/command/common"
+	"knative.dev/kn-plugin-operator/pkg/command/testingUtil"
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+func TestValidateEditFlags(t *testing.T) {
+	testingUtil.AssertEqual(t, validateEditFlags(editFlags{Component: "serving"}), nil)
+	testingUtil.AssertEqual(t, validateEditFlags(editFlags{Component: "test"}).Error(),
+		"You need to specify the component for Knative: serving or eventing.")
+}
+
+func TestGetEditor(t *testing.T) {
+	t.Setenv("KUBE_EDITOR", "")
+	t.Setenv("EDITOR", "")
+	testingUtil.AssertDeepEqual(t, getEditor(), []string{"vi"})
+	t.Setenv("EDITOR", "code --wait")
+	testingUtil.AssertDeepEqual(t, getEditor(), []string{"code", "--wait"})
+	t.Setenv("KUBE_EDITOR", "nano")
+	testingUtil.AssertDeepEqual(t, getEditor(), []string{"nano"})
+}
+
+func TestRemoveEditHeader(t *testing.T) {
+	content := addEditHeader("serving", "config:\n  autoscaler:\n    _example: |\n# example\n", []string{"The spec is wrong.\nReally."})
+	testingUtil.AssertEqual(t, strings.Contains(content, "# - The spec is wrong.\n#   Really.\n"), true)
+	testingUtil.AssertEqual(t, removeEditHeader(content), "config:\n  autoscaler:\n    _example: |\n# example\n")
+}
+
+func TestParseCommonSpec(t *testing.T) {
+	commonSpec, problems := parseCommonSpec("version: \"1.13\"\nworkloads:\n- name: controller\n  replicas: 2\n")
+	testingUtil.AssertEqual(t, len(problems), 0)
+	testingUtil.AssertEqual(t, commonSpec.Version, "1.13")
+	testingUtil.AssertEqual(t, *commonSpec.Workloads[0].Replicas, int32(2))
+
+	_, problems = parseCommonSpec("workload:\n- name: controller\n")
+	testingUtil.AssertEqual(t, len(problems), 1)
+	testingUtil.AssertEqual(t, strings.Contains(problems[0], `unknown field "workload"`), true)
+
+	_, problems = parseCommonSpec("workloads:\n- name: controller\n  resources:\n  - container: controller\n    limits:\n      cpu: one\n")
+	testingUtil.AssertEqual(t, len(problems), 1)
+}
+
+func TestValidate(t *testing.T) {
+	validator := &specValidator{
+		component:   "serving",
+		version:     "1.12.0",
+		existing:    &base.CommonSpec{},
+		deployments: []string{"activator", "autoscaler", "controller", "webhook"},
+		catalogue: []common.ConfigMapEntry{{
+			Component: "serving",
+			Name:      "config-autoscaler",
+			Keys: []common.ConfigMapKey{{
+				Name: "enable-scale-to-zero",
+				Type: common.BoolType,
+			}},
+		}},
+	}
+	commonSpec := &base.CommonSpec{
+		Config: base.ConfigMapData{
+			"autoscaler": {"enable-scale-to-zero": "no"},
+			"network":    {"ingress-class": "kourier.ingress.networking.knative.dev"},
+		},
+		Workloads: []base.WorkloadOverride{{
+			Name: "controler",
+			Resources: []base.ResourceRequirementsOverride{{
+				Container: "controller",
+				ResourceRequirements: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+					Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+				},
+			}},
+			Tolerations: []corev1.Toleration{{
+				Key:      "dedicated",
+				Operator: "In",
+				Effect:   corev1.TaintEffectNoSchedule,
+			}, {
+				Key:      "knative",
+				Operator: corev1.TolerationOpExists,
+				Value:    "true",
+				Effect:   "Never",
+			}},
+		}},
+		ClusterProfileRef: &base.ClusterProfileReference{Name: "remote", Namespace: "fleet"},
+	}
+	testingUtil.AssertDeepEqual(t, validator.validate(commonSpec), []string{
+		"Knative serving already exists in the local cluster, and spec.clusterProfileRef cannot be added after creation. Please uninstall it first.",
+		"The value no of the key enable-scale-to-zero should be of the type bool.",
+		"The deployment controler in spec.workloads is unknown in Knative serving. Did you mean controller?",
+		"The cpu request 2 of the container controller in the deployment controler should not exceed the limit 500m.",
+		"The operator In of the toleration dedicated of the deployment controler should be Equal or Exists.",
+		"The toleration knative of the deployment controler should have no value with the operator Exists.",
+		"The effect Never of the toleration knative of the deployment controler should be NoSchedule, PreferNoSchedule or NoExecute.",
+	})
+
+	testingUtil.AssertDeepEqual(t, validator.validate(&base.CommonSpec{
+		Workloads: []base.WorkloadOverride{{Name: "webhook"}},
+	}), []string{})
+}
+
+func TestValidateConfigMaps(t *testing.T) {
+	validator := &specValidator{
+		component: "serving",
+		existing: &base.CommonSpec{
+			Config: base.ConfigMapData{
+				"config-autoscaler": {"enable-scale-to-zero": "no", "experimental": "true"},
+			},
+		},
+		catalogue: []common.ConfigMapEntry{{
+			Component: "serving",
+			Name:      "config-autoscaler",
+			Keys: []common.ConfigMapKey{{
+				Name: "enable-scale-to-zero",
+				Type: common.BoolType,
+			}, {
+				Name: "stable-window",
+				Type: common.DurationType,
+			}},
+		}},
+	}
+
+	// The existing invalid value and unknown key do not block the edit
+	commonSpec := &base.CommonSpec{
+		Config: base.ConfigMapData{
+			"autoscaler": {"enable-scale-to-zero": "no", "experimental": "true", "stable-window": "60s", "stable-windw": "60s"},
+		},
+	}
+	testingUtil.AssertDeepEqual(t, validator.validate(commonSpec), []string{})
+	testingUtil.AssertDeepEqual(t, validator.warnings, []string{
+		"The key stable-windw is unknown in the ConfigMap config-autoscaler. Did you mean stable-window?",
+	})
+
+	commonSpec.Config["autoscaler"]["stable-window"] = "60"
+	testingUtil.AssertDeepEqual(t, validator.validate(commonSpec), []string{
+		"The value 60 of the key stable-window should be of the type duration.",
+	})
+
+	validator.force = true
+	testingUtil.AssertDeepEqual(t, validator.validate(commonSpec), []string{})
+	testingUtil.AssertDeepEqual(t, validator.warnings, []string{
+		"The value 60 of the key stable-window should be of the type duration.",
+		"The key stable-windw is unknown in the ConfigMap config-autoscaler. Did you mean stable-window?",
+	})
+}
+
+func TestValidateWorkloadNames(t *testing.T) {
+	validator := &specValidator{
+		component:   "serving",
+		deployments: []string{"activator", "autoscaler", "controller", "webhook"},
+		existing: &base.CommonSpec{
+			Workloads:          []base.WorkloadOverride{{Name: "net-kourier-controller"}},
+			DeploymentOverride: []base.WorkloadOverride{{Name: "redis"}},
+		},
+	}
+
+	// The existing overrides for the workloads not installed as deployments do not block the edit
+	commonSpec := &base.CommonSpec{
+		Workloads:          []base.WorkloadOverride{{Name: "net-kourier-controller"}, {Name: "redis"}},
+		DeploymentOverride: []base.WorkloadOverride{{Name: "redis"}},
+	}
+	testingUtil.AssertDeepEqual(t, validator.validate(commonSpec), []string{})
+	testingUtil.AssertDeepEqual(t, validator.warnings, []string{})
+
+	commonSpec.Workloads = append(commonSpec.Workloads, base.WorkloadOverride{Name: "activtor"})
+	testingUtil.AssertDeepEqual(t, validator.validate(commonSpec), []string{
+		"The deployment activtor in spec.workloads is unknown in Knative serving. Did you mean activator?",
+	})
+
+	validator.force = true
+	testingUtil.AssertDeepEqual(t, validator.validate(commonSpec), []string{})
+	testingUtil.AssertDeepEqual(t, validator.warnings, []string{
+		"The deployment activtor in spec.workloads is unknown in Knative serving. Did you mean activator?",
+	})
+}
+
+func TestSaveEditedSpec(t *testing.T) {
+	path, err := saveEditedSpec(&base.CommonSpec{Version: "1.13"})
+	testingUtil.AssertEqual(t, err, nil)
+	defer os.Remove(path)
+	content, err := os.ReadFile(path)
+	testingUtil.AssertEqual(t, err, nil)
+	testingUtil.AssertEqual(t, string(content), "registry: {}\nversion: \"1.13\"\n")
+}
+
+func TestEditCommonSpec(t *testing.T) {
+	defer func(editor func(string) error) { openEditor = editor }(openEditor)
+	validate := func(commonSpec *base.CommonSpec) []string {
+		if commonSpec.Version == "invalid" {
+			return []string{"The version is invalid."}
+		}
+		return nil
+	}
+
+	for _, tt := range []struct {
+		name            string
+		edits           []string
+		expectedVersion string
+		expectedHeaders []bool
+	}{{
+		name:  "Cancel with an empty file",
+		edits: []string{""},
+	}, {
+		name:  "No change",
+		edits: []string{"registry: {}\nversion: \"1.12\"\n"},
+	}, {
+		name:            "Valid edit",
+		edits:           []string{"version: \"1.13\"\n"},
+		expectedVersion: "1.13",
+	}, {
+		name:            "Invalid edit reopened",
+		edits:           []string{"version: invalid\n", "versions: \"1.13\"\n", "version: \"1.13\"\n"},
+		expectedVersion: "1.13",
+		expectedHeaders: []bool{false, true, true},
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			openEditor = func(path string) error {
+				b, err := os.ReadFile(path)
+				if err != nil {
+					return err
+				}
+				if tt.expectedHeaders != nil {
+					testingUtil.AssertEqual(t, strings.Contains(string(b), "# The spec is invalid:"), tt.expectedHeaders[calls])
+				}
+				err = os.WriteFile(path, []byte(tt.edits[calls]), 0600)
+				calls++
+				return err
+			}
+			result, err := editCommonSpec("serving", &base.CommonSpec{Version: "1.12"}, validate)
+			testingUtil.AssertEqual(t, err, nil)
+			testingUtil.AssertEqual(t, calls, len(tt.edits))
+			if tt.expectedVersion == "" {
+				testingUtil.AssertEqual(t, result == nil, true)
+			} else {
+				testingUtil.AssertEqual(t, result.Version, tt.expectedVersion)
+			}
+		})
+	}
+}
@@ -29,11 +29,14 @@ func NewEnableCommand(p *pkg.OperatorParams) *cobra.Command {
   # Enable the ingress istio for Knative Serving
   kn-operator enable ingress --istio --namespace knative-serving
   # Enable the eventing source github for Knative Eventing
-  kn-operator enable eventing-source --github --namespace knative-eventing`,
+  kn-operator enable eventing-source --github --namespace knative-eventing
+  # Enable the security guard for Knative Serving
+  kn-operator enable security-guard --namespace knative-serving`,
 	}
 
 	enableCmd.AddCommand(newIngressCommand(p))
 	enableCmd.AddCommand(newEventingSourcesCommand(p))
+	enableCmd.AddCommand(newSecurityGuardCommand(p))
 
 	return enableCmd
 }
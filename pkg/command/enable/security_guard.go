@@ -0,0 +1,79 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enable
+
+import (
+	_ "embed"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"knative.dev/kn-plugin-operator/pkg"
+	"knative.dev/kn-plugin-operator/pkg/command/common"
+)
+
+//go:embed overlay/ks_security_guard.yaml
+var securityGuardOverlayContent string
+
+type securityGuardFlags struct {
+	Namespace string
+}
+
+var securityGuardCmdFlags securityGuardFlags
+
+// newSecurityGuardCommand represents the enable command for the security guard of Knative Serving
+func newSecurityGuardCommand(p *pkg.OperatorParams) *cobra.Command {
+	var enableSecurityGuardCmd = &cobra.Command{
+		Use:   "security-guard",
+		Short: "Enable the security guard for Knative Serving",
+		Example: `
+  # Enable the security guard for Knative Serving
+  kn-operator enable security-guard --namespace knative-serving`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if securityGuardCmdFlags.Namespace == "" {
+				securityGuardCmdFlags.Namespace = common.DefaultKnativeServingNamespace
+			}
+
+			if err := enableSecurityGuard(securityGuardCmdFlags, p); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "The security guard was enabled in the namespace '%s'.\n", securityGuardCmdFlags.Namespace)
+			return common.ReportSecurityGuardStatus(cmd.OutOrStdout(), securityGuardCmdFlags.Namespace, true, p)
+		},
+	}
+
+	enableSecurityGuardCmd.Flags().StringVarP(&securityGuardCmdFlags.Namespace, "namespace", "n", "", "The namespace of Knative Serving")
+
+	return enableSecurityGuardCmd
+}
+
+func enableSecurityGuard(securityGuardCMDFlags securityGuardFlags, p *pkg.OperatorParams) error {
+	// Generate the CR template
+	yamlTemplateString, err := common.GenerateOperatorCRString(common.ServingComponent, securityGuardCMDFlags.Namespace, p)
+	if err != nil {
+		return err
+	}
+
+	valuesYaml := getSecurityGuardValuesContent(securityGuardCMDFlags)
+
+	if err = common.ApplyManifests(yamlTemplateString, securityGuardOverlayContent, valuesYaml, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+func getSecurityGuardValuesContent(securityGuardCMDFlags securityGuardFlags) string {
+	return fmt.Sprintf("#@data/values\n---\nnamespace: %s\nenabled: true", securityGuardCMDFlags.Namespace)
+}
@@ -0,0 +1,118 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package explain
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"knative.dev/kn-plugin-operator/pkg"
+	"knative.dev/kn-plugin-operator/pkg/command/common"
+)
+
+type explainFlags struct {
+	Component string
+	Version   string
+}
+
+var explainCmdFlags explainFlags
+
+// NewExplainCommand represents the command to describe the known keys of the Knative ConfigMaps
+func NewExplainCommand(p *pkg.OperatorParams) *cobra.Command {
+	var explainCmd = &cobra.Command{
+		Use:   "explain [CONFIGMAP]",
+		Short: "Describe the known keys of the Knative ConfigMaps",
+		Long: `Describe the known keys of a Knative ConfigMap, which can be configured with kn operator configure configmaps,
+with their types, default values and descriptions. All the known ConfigMaps are listed, if no ConfigMap is specified.`,
+		Example: `
+  # List the known ConfigMaps of Knative Serving and Eventing
+  kn operator explain
+  # Describe the keys of the ConfigMap config-autoscaler
+  kn operator explain config-autoscaler
+  # Describe the keys of the ConfigMap config-features of Knative Eventing available in the version 1.12
+  kn operator explain config-features -c eventing --version 1.12`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateExplainFlags(explainCmdFlags); err != nil {
+				return err
+			}
+
+			catalogue, err := common.GetConfigMapCatalogue()
+			if err != nil {
+				return err
+			}
+			if len(args) == 0 {
+				fmt.Fprint(cmd.OutOrStdout(), formatConfigMapList(catalogue, explainCmdFlags.Component))
+				return nil
+			}
+
+			content, err := explainConfigMap(catalogue, args[0], explainCmdFlags)
+			if err != nil {
+				return err
+			}
+			fmt.Fprint(cmd.OutOrStdout(), content)
+			return nil
+		},
+	}
+
+	explainCmd.Flags().StringVarP(&explainCmdFlags.Component, "component", "c", "", "The name of the Knative Component: serving or eventing")
+	explainCmd.Flags().StringVar(&explainCmdFlags.Version, "version", "", "The version of Knative to show the available keys for")
+
+	return explainCmd
+}
+
+func validateExplainFlags(explainCmdFlags explainFlags) error {
+	if explainCmdFlags.Component != "" && !strings.EqualFold(explainCmdFlags.Component, common.ServingComponent) &&
+		!strings.EqualFold(explainCmdFlags.Component, common.EventingComponent) {
+		return fmt.Errorf("You need to specify the component for Knative: serving or eventing.")
+	}
+	return nil
+}
+
+// explainConfigMap returns the description of the ConfigMap for every component having it
+func explainConfigMap(catalogue []common.ConfigMapEntry, name string, explainCmdFlags explainFlags) (string, error) {
+	entries := common.FindConfigMapEntries(catalogue, explainCmdFlags.Component, name)
+	if len(entries) == 0 {
+		message := fmt.Sprintf("The ConfigMap %s is not in the catalogue.", name)
+		names := []string{}
+		for _, entry := range catalogue {
+			names = append(names, entry.Name)
+		}
+		if suggestion := common.SuggestName(names, name); suggestion != "" {
+			message = fmt.Sprintf("%s Did you mean %s?", message, suggestion)
+		}
+		return "", fmt.Errorf("%s Run kn operator explain to list the known ConfigMaps.", message)
+	}
+
+	contentArray := []string{}
+	for _, entry := range entries {
+		contentArray = append(contentArray, common.FormatConfigMapEntry(entry, explainCmdFlags.Version))
+	}
+	return strings.Join(contentArray, "\n"), nil
+}
+
+// formatConfigMapList returns the names and the descriptions of the known ConfigMaps of the component
+func formatConfigMapList(catalogue []common.ConfigMapEntry, component string) string {
+	contentArray := []string{}
+	for _, entry := range catalogue {
+		if component != "" && !strings.EqualFold(entry.Component, component) {
+			continue
+		}
+		contentArray = append(contentArray, fmt.Sprintf("%s (Knative %s): %s", entry.Name, entry.Component, entry.Description))
+	}
+	return fmt.Sprintf("%s\n", strings.Join(contentArray, "\n"))
+}
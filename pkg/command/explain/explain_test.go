@@ -0,0 +1,118 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package explain
+
+import (
+	"fmt"
+	"testing"
+
+	"knative.dev/kn-plugin-operator/pkg/command/common"
+	"knative.dev/kn-plugin-operator/pkg/command/testingUtil"
+)
+
+var testCatalogue = []common.ConfigMapEntry{{
+	Component:   "serving",
+	Name:        "config-features",
+	Description: "The feature flags of Knative Serving.",
+	Keys: []common.ConfigMapKey{{
+		Name:        "multi-container",
+		Type:        common.EnumType,
+		Values:      []string{"enabled", "disabled"},
+		Default:     "enabled",
+		Description: "Whether a revision can have multiple containers.",
+	}},
+}, {
+	Component:   "eventing",
+	Name:        "config-features",
+	Description: "The feature flags of Knative Eventing.",
+	Keys: []common.ConfigMapKey{{
+		Name:        "authentication-oidc",
+		Type:        common.EnumType,
+		Values:      []string{"enabled", "disabled"},
+		Since:       "v1.13",
+		Description: "Whether the deliveries are authenticated with OIDC.",
+	}},
+}}
+
+func TestValidateExplainFlags(t *testing.T) {
+	testingUtil.AssertEqual(t, validateExplainFlags(explainFlags{Component: "eventing"}), nil)
+	testingUtil.AssertEqual(t, validateExplainFlags(explainFlags{}), nil)
+	testingUtil.AssertEqual(t, validateExplainFlags(explainFlags{Component: "test"}).Error(),
+		"You need to specify the component for Knative: serving or eventing.")
+}
+
+func TestExplainConfigMap(t *testing.T) {
+	for _, tt := range []struct {
+		name            string
+		cmName          string
+		explainCmdFlags explainFlags
+		expectedResult  string
+		expectedErr     error
+	}{{
+		name:            "ConfigMap of one component",
+		cmName:          "features",
+		explainCmdFlags: explainFlags{Component: "serving"},
+		expectedResult: `config-features (Knative serving)
+  The feature flags of Knative Serving.
+
+KEYS:
+  multi-container
+    type: enum (enabled, disabled)
+    default: enabled
+    Whether a revision can have multiple containers.
+`,
+	}, {
+		name:            "ConfigMap of both components in a version",
+		cmName:          "config-features",
+		explainCmdFlags: explainFlags{Version: "1.12"},
+		expectedResult: `config-features (Knative serving)
+  The feature flags of Knative Serving.
+
+KEYS:
+  multi-container
+    type: enum (enabled, disabled)
+    default: enabled
+    Whether a revision can have multiple containers.
+
+config-features (Knative eventing)
+  The feature flags of Knative Eventing.
+
+KEYS:
+`,
+	}, {
+		name:        "Unknown ConfigMap",
+		cmName:      "config-feature",
+		expectedErr: fmt.Errorf("The ConfigMap config-feature is not in the catalogue. Did you mean config-features? Run kn operator explain to list the known ConfigMaps."),
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := explainConfigMap(testCatalogue, tt.cmName, tt.explainCmdFlags)
+			if tt.expectedErr == nil {
+				testingUtil.AssertEqual(t, err, nil)
+				testingUtil.AssertEqual(t, result, tt.expectedResult)
+			} else {
+				testingUtil.AssertEqual(t, err.Error(), tt.expectedErr.Error())
+			}
+		})
+	}
+}
+
+func TestFormatConfigMapList(t *testing.T) {
+	testingUtil.AssertEqual(t, formatConfigMapList(testCatalogue, ""), `config-features (Knative serving): The feature flags of Knative Serving.
+config-features (Knative eventing): The feature flags of Knative Eventing.
+`)
+	testingUtil.AssertEqual(t, formatConfigMapList(testCatalogue, "eventing"), `config-features (Knative eventing): The feature flags of Knative Eventing.
+`)
+}
@@ -36,6 +36,7 @@ import (
 
 	"knative.dev/kn-plugin-operator/pkg"
 	"knative.dev/kn-plugin-operator/pkg/command/common"
+	"knative.dev/operator/pkg/apis/operator/base"
 	"knative.dev/operator/pkg/apis/operator/v1beta1"
 	operatorv1beta1 "knative.dev/operator/pkg/client/clientset/versioned/typed/operator/v1beta1"
 	"knative.dev/pkg/test/logging"
@@ -59,6 +60,12 @@ var operatorCRDsOverlay string
 //go:embed overlay/ks_ingress.yaml
 var servingWithIngressOverlay string
 
+//go:embed overlay/ks_cluster_profile.yaml
+var servingClusterProfileOverlay string
+
+//go:embed overlay/ke_cluster_profile.yaml
+var eventingClusterProfileOverlay string
+
 type installCmdFlags struct {
 	Component      string
 	IstioNamespace string
@@ -68,6 +75,7 @@ type installCmdFlags struct {
 	Istio          bool
 	Kourier        bool
 	Contour        bool
+	ClusterProfile string
 }
 
 var (
@@ -118,7 +126,9 @@ func NewInstallCommand(p *pkg.OperatorParams) *cobra.Command {
 		Short: "Install Knative Operator or Knative components",
 		Example: `
   # Install Knative Serving under the namespace knative-serving
-  kn-operator install -c serving --namespace knative-serving`,
+  kn-operator install -c serving --namespace knative-serving
+  # Install Knative Serving into the remote cluster referenced by the ClusterProfile fleet-system/spoke-1
+  kn-operator install -c serving --namespace knative-serving --cluster-profile fleet-system/spoke-1`,
 
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Fill in the default values for the empty fields
@@ -136,6 +146,10 @@ func NewInstallCommand(p *pkg.OperatorParams) *cobra.Command {
 
 			fmt.Fprintf(cmd.OutOrStdout(), "Knative %s of the '%s' version was created in the namespace '%s'.\n",
 				component, installFlags.Version, installFlags.Namespace)
+			if installFlags.ClusterProfile != "" && installFlags.Component != "" {
+				fmt.Fprintf(cmd.OutOrStdout(), "It is deployed into the remote cluster referenced by the ClusterProfile '%s'.\n",
+					installFlags.ClusterProfile)
+			}
 			return nil
 		},
 	}
@@ -148,6 +162,7 @@ func NewInstallCommand(p *pkg.OperatorParams) *cobra.Command {
 	installCmd.Flags().BoolVar(&installFlags.Istio, "istio", false, "The flag to enable the ingress istio")
 	installCmd.Flags().BoolVar(&installFlags.Kourier, "kourier", false, "The flag to enable the ingress kourier")
 	installCmd.Flags().BoolVar(&installFlags.Contour, "contour", false, "The flag to enable the ingress contour")
+	installCmd.Flags().StringVar(&installFlags.ClusterProfile, "cluster-profile", "", "The ClusterProfile of the remote cluster to install the Knative component into, in the format of namespace/name. It cannot be changed after installation.")
 
 	return installCmd
 }
@@ -162,6 +177,11 @@ func RunInstallationCommand(installFlags *installCmdFlags, p *pkg.OperatorParams
 		return err
 	}
 
+	clusterProfileRef, err := validateClusterProfileFlags(installFlags)
+	if err != nil {
+		return err
+	}
+
 	// Fill in the default values for the empty fields
 	installFlags.fill_defaults()
 
@@ -181,8 +201,27 @@ func RunInstallationCommand(installFlags *installCmdFlags, p *pkg.OperatorParams
 			component = common.ServingComponent
 		}
 
+		ksCR, err := common.GetKnativeOperatorCR(p)
+		if err != nil {
+			return err
+		}
+		state, err := ksCR.GetKnativeCRState(component, installFlags.Namespace)
+		if err != nil {
+			return err
+		}
+		if state != nil && clusterProfileRef != nil {
+			if err = common.ValidateClusterProfileRefChange(component, state.ClusterProfileRef, clusterProfileRef); err != nil {
+				return err
+			}
+		}
+
 		currentVersion := ""
-		if exists, ns, version, err := deploy.CheckIfKnativeInstalled(installFlags.Component); err != nil {
+		if clusterProfileRef != nil || (state != nil && state.ClusterProfileRef != nil) {
+			// Knative runs in the remote cluster, so the version comes from the status of the custom resource
+			if state != nil {
+				currentVersion = state.Version
+			}
+		} else if exists, ns, version, err := deploy.CheckIfKnativeInstalled(installFlags.Component); err != nil {
 			return err
 		} else if exists {
 			// Check if the namespace is consistent
@@ -193,7 +232,7 @@ func RunInstallationCommand(installFlags *installCmdFlags, p *pkg.OperatorParams
 			currentVersion = version
 		}
 		// Install serving or eventing
-		versions, err := generateVersionStages(currentVersion, installFlags.Version)
+		versions, err := GenerateVersionStages(currentVersion, installFlags.Version)
 		if err != nil {
 			return err
 		}
@@ -261,6 +300,16 @@ func validateIngressFlags(installFlags *installCmdFlags) error {
 	return nil
 }
 
+func validateClusterProfileFlags(installFlags *installCmdFlags) (*base.ClusterProfileReference, error) {
+	if installFlags.ClusterProfile == "" {
+		return nil, nil
+	}
+	if installFlags.Component == "" {
+		return nil, fmt.Errorf("You can only specify the ClusterProfile for Knative Serving or Knative Eventing.")
+	}
+	return common.ParseClusterProfileRef(installFlags.ClusterProfile)
+}
+
 func getBaseURL(version, base string) (string, error) {
 	versionSanitized := strings.ToLower(version)
 	URL := "https://github.com/knative/operator/releases/latest/download/" + base
@@ -312,6 +361,13 @@ func getOverlayYamlContent(installFlags *installCmdFlags) string {
 	if overlayContent == "" {
 		return ""
 	}
+	if installFlags.ClusterProfile != "" {
+		if strings.EqualFold(installFlags.Component, common.ServingComponent) {
+			overlayContent = fmt.Sprintf("%s\n%s", overlayContent, servingClusterProfileOverlay)
+		} else if strings.EqualFold(installFlags.Component, common.EventingComponent) {
+			overlayContent = fmt.Sprintf("%s\n%s", overlayContent, eventingClusterProfileOverlay)
+		}
+	}
 	if installFlags.Component == "" && (strings.EqualFold(installFlags.Version, common.Latest) || strings.EqualFold(installFlags.Version, common.Nightly) || versionWebhook(installFlags.Version)) {
 		overlayContent = fmt.Sprintf("%s\n%s", overlayContent, operatorCRDsOverlay)
 	}
@@ -344,6 +400,12 @@ func getYamlValuesContent(installFlags *installCmdFlags) string {
 		content = fmt.Sprintf("#@data/values\n---\nnamespace: %s", installFlags.Namespace)
 	}
 
+	if content != "" && installFlags.Component != "" && installFlags.ClusterProfile != "" {
+		if ref, err := common.ParseClusterProfileRef(installFlags.ClusterProfile); err == nil {
+			content = fmt.Sprintf("%s\nclusterProfileRef: {name: %q, namespace: %q}", content, ref.Name, ref.Namespace)
+		}
+	}
+
 	if !strings.EqualFold(installFlags.Component, common.ServingComponent) || installFlags.Istio {
 		return content
 	}
@@ -389,7 +451,17 @@ func installKnativeComponent(installFlags *installCmdFlags, p *pkg.OperatorParam
 		}
 	}
 
-	err := createNamspaceIfNecessary(installFlags.Namespace, p)
+	ksCR, err := common.GetKnativeOperatorCR(p)
+	if err != nil {
+		return err
+	}
+	// Create the namespace with the labels and annotations configured in the existing custom resource
+	nsConfig, err := ksCR.GetNamespaceConfiguration(installFlags.Component, installFlags.Namespace)
+	if err != nil {
+		return err
+	}
+
+	err = createNamspaceIfNecessary(installFlags.Namespace, nsConfig, p)
 	if err != nil {
 		return err
 	}
@@ -406,7 +478,7 @@ func installKnativeComponent(installFlags *installCmdFlags, p *pkg.OperatorParam
 	}
 
 	// Make sure all the deployment resources are up and running
-	err = ensureKnativeComponentReady(installFlags, p)
+	err = EnsureKnativeComponentReady(installFlags.Component, installFlags.Namespace, installFlags.Version, p)
 	if err != nil {
 		return err
 	}
@@ -414,7 +486,10 @@ func installKnativeComponent(installFlags *installCmdFlags, p *pkg.OperatorParam
 	return nil
 }
 
-func ensureKnativeComponentReady(installFlags *installCmdFlags, p *pkg.OperatorParams) error {
+// EnsureKnativeComponentReady waits for the key deployments and the custom resource of the Knative component to be
+// ready with the version. If the custom resource targets a remote cluster via a ClusterProfile, the key deployments
+// are not available in the local cluster, and only the status conditions of the custom resource are checked.
+func EnsureKnativeComponentReady(component, namespace, version string, p *pkg.OperatorParams) error {
 	client, err := p.NewKubeClient()
 	if err != nil {
 		return fmt.Errorf("cannot get source cluster kube config, please use --kubeconfig or export environment variable KUBECONFIG to set\n")
@@ -425,26 +500,40 @@ func ensureKnativeComponentReady(installFlags *installCmdFlags, p *pkg.OperatorP
 		return fmt.Errorf("cannot get source cluster kube config, please use --kubeconfig or export environment variable KUBECONFIG to set\n")
 	}
 
-	if strings.EqualFold(installFlags.Component, common.ServingComponent) {
-		err := WaitForKnativeDeploymentState(client, installFlags.Namespace, installFlags.Version, ServingKeyDeployments,
-			IsKnativeDeploymentReady)
-		if err != nil {
-			return err
+	ksCR, err := common.GetKnativeOperatorCR(p)
+	if err != nil {
+		return err
+	}
+	state, err := ksCR.GetKnativeCRState(component, namespace)
+	if err != nil {
+		return err
+	}
+	remote := state != nil && state.ClusterProfileRef != nil
+
+	if strings.EqualFold(component, common.ServingComponent) {
+		if !remote {
+			err := WaitForKnativeDeploymentState(client, namespace, version, ServingKeyDeployments,
+				IsKnativeDeploymentReady)
+			if err != nil {
+				return err
+			}
 		}
-		_, err = WaitForKnativeServingState(operatorClient.OperatorV1beta1().KnativeServings(installFlags.Namespace), common.KnativeServingName,
-			installFlags.Version, IsKnativeServingReady)
+		_, err = WaitForKnativeServingState(operatorClient.OperatorV1beta1().KnativeServings(namespace), common.KnativeServingName,
+			version, IsKnativeServingReady)
 
 		if err != nil {
 			return err
 		}
-	} else if strings.EqualFold(installFlags.Component, common.EventingComponent) {
-		err := WaitForKnativeDeploymentState(client, installFlags.Namespace, installFlags.Version, EventingKeyDeployments,
-			IsKnativeDeploymentReady)
-		if err != nil {
-			return err
+	} else if strings.EqualFold(component, common.EventingComponent) {
+		if !remote {
+			err := WaitForKnativeDeploymentState(client, namespace, version, EventingKeyDeployments,
+				IsKnativeDeploymentReady)
+			if err != nil {
+				return err
+			}
 		}
-		_, err = WaitForKnativeEventingState(operatorClient.OperatorV1beta1().KnativeEventings(installFlags.Namespace), common.KnativeEventingName,
-			installFlags.Version, IsKnativeEventingReady)
+		_, err = WaitForKnativeEventingState(operatorClient.OperatorV1beta1().KnativeEventings(namespace), common.KnativeEventingName,
+			version, IsKnativeEventingReady)
 
 		if err != nil {
 			return err
@@ -455,7 +544,7 @@ func ensureKnativeComponentReady(installFlags *installCmdFlags, p *pkg.OperatorP
 }
 
 func installOperator(installFlags *installCmdFlags, p *pkg.OperatorParams) error {
-	err := createNamspaceIfNecessary(installFlags.Namespace, p)
+	err := createNamspaceIfNecessary(installFlags.Namespace, nil, p)
 	if err != nil {
 		return err
 	}
@@ -485,15 +574,18 @@ func installOperator(installFlags *installCmdFlags, p *pkg.OperatorParams) error
 	return applyOverlayValuesOnTemplate(yamlTemplateString, installFlags, p)
 }
 
-func createNamspaceIfNecessary(namespace string, p *pkg.OperatorParams) error {
+func createNamspaceIfNecessary(namespace string, nsConfig *base.NamespaceConfiguration, p *pkg.OperatorParams) error {
 	client, err := p.NewKubeClient()
 	if err != nil {
 		return fmt.Errorf("cannot get source cluster kube config, please use --kubeconfig or export environment variable KUBECONFIG to set\n")
 	}
 
 	ns := common.Namespace{
-		Client:    client,
-		Component: namespace,
+		Client: client,
+	}
+	if nsConfig != nil {
+		ns.Labels = nsConfig.Labels
+		ns.Annotations = nsConfig.Annotations
 	}
 	if err = ns.CreateNamespace(namespace); err != nil {
 		return err
@@ -511,7 +603,9 @@ func applyOverlayValuesOnTemplate(yamlTemplateString string, installFlags *insta
 	return nil
 }
 
-func generateVersionStages(source, target string) ([]string, error) {
+// GenerateVersionStages returns the versions to install one after another to migrate from the source version to the
+// target version, without skipping any minor version
+func GenerateVersionStages(source, target string) ([]string, error) {
 	stringArray := ""
 
 	if strings.HasPrefix(source, "v") {
@@ -22,6 +22,7 @@ import (
 
 	"knative.dev/kn-plugin-operator/pkg/command/common"
 	"knative.dev/kn-plugin-operator/pkg/command/testingUtil"
+	"knative.dev/operator/pkg/apis/operator/base"
 )
 
 func TestGetOperatorURL(t *testing.T) {
@@ -157,6 +158,20 @@ func TestGetOverlayYamlContent(t *testing.T) {
 			Component: "eventing",
 		},
 		expectedFile: "testdata/overlay/ke.yaml",
+	}, {
+		name: "Knative Serving with ClusterProfile",
+		installFlags: installCmdFlags{
+			Component:      "serving",
+			ClusterProfile: "fleet-system/spoke-1",
+		},
+		expectedFile: "testdata/overlay/ks_cluster_profile.yaml",
+	}, {
+		name: "Knative Eventing with ClusterProfile",
+		installFlags: installCmdFlags{
+			Component:      "eventing",
+			ClusterProfile: "fleet-system/spoke-1",
+		},
+		expectedFile: "testdata/overlay/ke_cluster_profile.yaml",
 	}, {
 		name: "Knative Operator",
 		installFlags: installCmdFlags{
@@ -295,6 +310,37 @@ version: 'latest'`,
 name: knative-eventing
 namespace: knative-eventing
 version: '1.0'`,
+	}, {
+		name: "Knative Eventing with ClusterProfile",
+		installFlags: installCmdFlags{
+			Version:        "1.0",
+			Component:      "eventing",
+			ClusterProfile: "fleet-system/spoke-1",
+		},
+		expectedResult: `#@data/values
+---
+name: knative-eventing
+namespace: knative-eventing
+version: '1.0'
+clusterProfileRef: {name: "spoke-1", namespace: "fleet-system"}`,
+	}, {
+		name: "Knative Serving with ClusterProfile and ingress",
+		installFlags: installCmdFlags{
+			Version:        "1.0",
+			Component:      "serving",
+			Kourier:        true,
+			ClusterProfile: "fleet-system/spoke-1",
+		},
+		expectedResult: `#@data/values
+---
+name: knative-serving
+namespace: knative-serving
+version: '1.0'
+clusterProfileRef: {name: "spoke-1", namespace: "fleet-system"}
+kourier: true
+istio: false
+contour: false
+ingressClass: kourier.ingress.networking.knative.dev`,
 	}, {
 		name: "Knative unknown component",
 		installFlags: installCmdFlags{
@@ -327,6 +373,50 @@ namespace: test`,
 	}
 }
 
+func TestValidateClusterProfileFlags(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		installFlags   installCmdFlags
+		expectedResult *base.ClusterProfileReference
+		expectedErr    error
+	}{{
+		name: "Knative Serving without ClusterProfile",
+		installFlags: installCmdFlags{
+			Component: "serving",
+		},
+	}, {
+		name: "Knative Serving with ClusterProfile",
+		installFlags: installCmdFlags{
+			Component:      "serving",
+			ClusterProfile: "fleet-system/spoke-1",
+		},
+		expectedResult: &base.ClusterProfileReference{Namespace: "fleet-system", Name: "spoke-1"},
+	}, {
+		name: "Knative Operator with ClusterProfile",
+		installFlags: installCmdFlags{
+			ClusterProfile: "fleet-system/spoke-1",
+		},
+		expectedErr: fmt.Errorf("You can only specify the ClusterProfile for Knative Serving or Knative Eventing."),
+	}, {
+		name: "Knative Eventing with invalid ClusterProfile",
+		installFlags: installCmdFlags{
+			Component:      "eventing",
+			ClusterProfile: "spoke-1",
+		},
+		expectedErr: fmt.Errorf("The ClusterProfile spoke-1 should be in the format of namespace/name."),
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := validateClusterProfileFlags(&tt.installFlags)
+			if tt.expectedErr != nil {
+				testingUtil.AssertEqual(t, err.Error(), tt.expectedErr.Error())
+			} else {
+				testingUtil.AssertEqual(t, err, nil)
+				testingUtil.AssertDeepEqual(t, result, tt.expectedResult)
+			}
+		})
+	}
+}
+
 func TestVersionWebhook(t *testing.T) {
 	for _, tt := range []struct {
 		name           string
@@ -446,7 +536,7 @@ func TestGenerateVersionStages(t *testing.T) {
 		expectedErr:    fmt.Errorf("minor number of the target version v1.q.1 should be an integer"),
 	}} {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := generateVersionStages(tt.source, tt.target)
+			result, err := GenerateVersionStages(tt.source, tt.target)
 			if tt.expectedErr == nil {
 				testingUtil.AssertEqual(t, err, nil)
 			} else {
@@ -0,0 +1,339 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc" // from https://github.com/kubernetes/client-go/issues/345
+	"k8s.io/client-go/util/retry"
+
+	"knative.dev/kn-plugin-operator/pkg"
+	"knative.dev/kn-plugin-operator/pkg/command/common"
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+type migrateSpecFlags struct {
+	Component string
+	Namespace string
+	DryRun    bool
+}
+
+var migrateSpecCmdFlags migrateSpecFlags
+
+// NewMigrateSpecCommand represents the command to migrate the deprecated fields of the Knative custom resources
+func NewMigrateSpecCommand(p *pkg.OperatorParams) *cobra.Command {
+	var migrateSpecCmd = &cobra.Command{
+		Use:   "migrate-spec",
+		Short: "Migrate the deprecated fields of the Knative Serving or Eventing custom resource",
+		Long: `Migrate the deprecated fields of the Knative Serving or Eventing custom resource to their current equivalents.
+
+The overrides under spec.deployments are merged into spec.workloads, and the resources under spec.resources are
+merged into spec.workloads for every deployment running the container. The overrides of the same container are
+merged per environment variable, per resource request and limit, and per probe field. The existing values under
+spec.workloads take precedence over the deprecated ones.`,
+		Example: `
+  # Show the changes to migrate the deprecated fields of Knative Serving
+  kn operator migrate-spec -c serving --namespace knative-serving --dry-run
+  # Migrate the deprecated fields of Knative Eventing
+  kn operator migrate-spec -c eventing --namespace knative-eventing`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateMigrateSpecFlags(migrateSpecCmdFlags); err != nil {
+				return err
+			}
+
+			if migrateSpecCmdFlags.Namespace == "" {
+				migrateSpecCmdFlags.Namespace = common.DefaultKnativeServingNamespace
+				if strings.EqualFold(migrateSpecCmdFlags.Component, common.EventingComponent) {
+					migrateSpecCmdFlags.Namespace = common.DefaultKnativeEventingNamespace
+				}
+			}
+
+			return migrateSpec(cmd, migrateSpecCmdFlags, p)
+		},
+	}
+
+	migrateSpecCmd.Flags().StringVarP(&migrateSpecCmdFlags.Component, "component", "c", "", "The name of the Knative Component: serving or eventing")
+	migrateSpecCmd.Flags().StringVarP(&migrateSpecCmdFlags.Namespace, "namespace", "n", "", "The namespace of the Knative component")
+	migrateSpecCmd.Flags().BoolVar(&migrateSpecCmdFlags.DryRun, "dry-run", false, "The flag to only print the changes without applying them")
+
+	return migrateSpecCmd
+}
+
+func validateMigrateSpecFlags(migrateSpecCMDFlags migrateSpecFlags) error {
+	if !strings.EqualFold(migrateSpecCMDFlags.Component, common.ServingComponent) &&
+		!strings.EqualFold(migrateSpecCMDFlags.Component, common.EventingComponent) {
+		return fmt.Errorf("You need to specify the component for Knative: serving or eventing.")
+	}
+	return nil
+}
+
+func migrateSpec(cmd *cobra.Command, migrateSpecCMDFlags migrateSpecFlags, p *pkg.OperatorParams) error {
+	ksCR, err := common.GetKnativeOperatorCR(p)
+	if err != nil {
+		return err
+	}
+
+	client, err := p.NewKubeClient()
+	if err != nil {
+		return fmt.Errorf("cannot get source cluster kube config, please use --kubeconfig or export environment variable KUBECONFIG to set\n")
+	}
+	deploy := common.Deployment{
+		Client: client,
+	}
+	containerDeployments, err := deploy.GetContainerDeployments(migrateSpecCMDFlags.Namespace)
+	if err != nil {
+		return err
+	}
+
+	var diff string
+	var warnings []string
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		commonSpec, err := ksCR.GetCommonSpec(migrateSpecCMDFlags.Component, migrateSpecCMDFlags.Namespace)
+		if err != nil {
+			return err
+		}
+
+		var migrated *base.CommonSpec
+		migrated, warnings = migrateCommonSpec(commonSpec, containerDeployments)
+		diff, err = getSpecDiff(commonSpec, migrated)
+		if err != nil || diff == "" || migrateSpecCMDFlags.DryRun {
+			return err
+		}
+		return ksCR.UpdateCommonSpec(migrateSpecCMDFlags.Component, migrateSpecCMDFlags.Namespace, migrated)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, warning := range warnings {
+		fmt.Fprintf(cmd.OutOrStdout(), "Warning: %s\n", warning)
+	}
+	if diff == "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "There are no deprecated fields to migrate in the namespace '%s'.\n", migrateSpecCMDFlags.Namespace)
+		return nil
+	}
+	if migrateSpecCMDFlags.DryRun {
+		fmt.Fprint(cmd.OutOrStdout(), diff)
+		return nil
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "The deprecated fields have been migrated in the namespace '%s'.\n", migrateSpecCMDFlags.Namespace)
+	return nil
+}
+
+// migrateCommonSpec moves the deprecated deployment overrides and resources into the workload overrides. The
+// resources of the containers not found in any deployment are kept in place, and reported as warnings.
+func migrateCommonSpec(commonSpec *base.CommonSpec, containerDeployments map[string][]string) (*base.CommonSpec, []string) {
+	migrated := commonSpec.DeepCopy()
+	warnings := []string{}
+
+	// The operator applies the deprecated resources first, then the deprecated deployment overrides and the workload
+	// overrides, so the overrides applied later take precedence.
+	workloads := migrated.Workloads
+	for _, deploymentOverride := range migrated.DeploymentOverride {
+		workloads = mergeWorkloadOverride(workloads, deploymentOverride)
+	}
+	migrated.DeploymentOverride = nil
+
+	var unresolved []base.ResourceRequirementsOverride
+	for _, resource := range migrated.DeprecatedResources {
+		deployments := containerDeployments[resource.Container]
+		if len(deployments) == 0 {
+			unresolved = append(unresolved, resource)
+			warnings = append(warnings, fmt.Sprintf("The container %s is not found in any deployment. Its resources are kept under spec.resources.",
+				resource.Container))
+			continue
+		}
+		for _, name := range deployments {
+			workloads = mergeWorkloadOverride(workloads, base.WorkloadOverride{
+				Name:      name,
+				Resources: []base.ResourceRequirementsOverride{resource},
+			})
+		}
+	}
+	migrated.DeprecatedResources = unresolved
+	migrated.Workloads = workloads
+	return migrated, warnings
+}
+
+// mergeWorkloadOverride merges the fallback into the workload override with the same name. The fields already set
+// in the workload override take precedence.
+func mergeWorkloadOverride(workloads []base.WorkloadOverride, fallback base.WorkloadOverride) []base.WorkloadOverride {
+	for i := range workloads {
+		if workloads[i].Name != fallback.Name {
+			continue
+		}
+		workload := &workloads[i]
+		workload.Labels = mergeMaps(fallback.Labels, workload.Labels)
+		workload.Annotations = mergeMaps(fallback.Annotations, workload.Annotations)
+		workload.NodeSelector = mergeMaps(fallback.NodeSelector, workload.NodeSelector)
+		if workload.Replicas == nil {
+			workload.Replicas = fallback.Replicas
+		}
+		if len(workload.TopologySpreadConstraints) == 0 {
+			workload.TopologySpreadConstraints = fallback.TopologySpreadConstraints
+		}
+		if len(workload.Tolerations) == 0 {
+			workload.Tolerations = fallback.Tolerations
+		}
+		if workload.Affinity == nil {
+			workload.Affinity = fallback.Affinity
+		}
+		workload.Resources = mergeResources(fallback.Resources, workload.Resources)
+		workload.Env = mergeEnv(fallback.Env, workload.Env)
+		workload.ReadinessProbes = mergeProbes(fallback.ReadinessProbes, workload.ReadinessProbes)
+		workload.LivenessProbes = mergeProbes(fallback.LivenessProbes, workload.LivenessProbes)
+		if workload.HostNetwork == nil {
+			workload.HostNetwork = fallback.HostNetwork
+		}
+		if workload.Version == "" {
+			workload.Version = fallback.Version
+		}
+		if len(workload.VolumeMounts) == 0 {
+			workload.VolumeMounts = fallback.VolumeMounts
+		}
+		return workloads
+	}
+	return append(workloads, fallback)
+}
+
+func mergeMaps(fallback, values map[string]string) map[string]string {
+	if len(fallback) == 0 {
+		return values
+	}
+	result := map[string]string{}
+	for key, value := range fallback {
+		result[key] = value
+	}
+	for key, value := range values {
+		result[key] = value
+	}
+	return result
+}
+
+// mergeResources merges the fallback resources into the resources of the same container. The requests and limits are
+// merged per resource name, and the values already set take precedence.
+func mergeResources(fallback, values []base.ResourceRequirementsOverride) []base.ResourceRequirementsOverride {
+	for _, resource := range fallback {
+		found := false
+		for i := range values {
+			if values[i].Container == resource.Container {
+				values[i].Requests = mergeResourceLists(resource.Requests, values[i].Requests)
+				values[i].Limits = mergeResourceLists(resource.Limits, values[i].Limits)
+				found = true
+				break
+			}
+		}
+		if !found {
+			values = append(values, resource)
+		}
+	}
+	return values
+}
+
+func mergeResourceLists(fallback, values corev1.ResourceList) corev1.ResourceList {
+	if len(fallback) == 0 {
+		return values
+	}
+	result := corev1.ResourceList{}
+	for name, quantity := range fallback {
+		result[name] = quantity.DeepCopy()
+	}
+	for name, quantity := range values {
+		result[name] = quantity.DeepCopy()
+	}
+	return result
+}
+
+// mergeEnv merges the fallback environment variables into the ones of the same container. The variables are merged
+// by name, and the variables already set take precedence.
+func mergeEnv(fallback, values []base.EnvRequirementsOverride) []base.EnvRequirementsOverride {
+	for _, env := range fallback {
+		found := false
+		for i := range values {
+			if values[i].Container == env.Container {
+				values[i].EnvVars = mergeEnvVars(env.EnvVars, values[i].EnvVars)
+				found = true
+				break
+			}
+		}
+		if !found {
+			values = append(values, env)
+		}
+	}
+	return values
+}
+
+func mergeEnvVars(fallback, values []corev1.EnvVar) []corev1.EnvVar {
+	for _, envVar := range fallback {
+		found := false
+		for _, value := range values {
+			if value.Name == envVar.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			values = append(values, envVar)
+		}
+	}
+	return values
+}
+
+// mergeProbes merges the fallback probes into the probes of the same container. The fields already set take
+// precedence.
+func mergeProbes(fallback, values []base.ProbesRequirementsOverride) []base.ProbesRequirementsOverride {
+	for _, probe := range fallback {
+		found := false
+		for i := range values {
+			if values[i].Container != probe.Container {
+				continue
+			}
+			value := &values[i]
+			if value.InitialDelaySeconds == 0 {
+				value.InitialDelaySeconds = probe.InitialDelaySeconds
+			}
+			if value.TimeoutSeconds == 0 {
+				value.TimeoutSeconds = probe.TimeoutSeconds
+			}
+			if value.PeriodSeconds == 0 {
+				value.PeriodSeconds = probe.PeriodSeconds
+			}
+			if value.SuccessThreshold == 0 {
+				value.SuccessThreshold = probe.SuccessThreshold
+			}
+			if value.FailureThreshold == 0 {
+				value.FailureThreshold = probe.FailureThreshold
+			}
+			if value.TerminationGracePeriodSeconds == nil {
+				value.TerminationGracePeriodSeconds = probe.TerminationGracePeriodSeconds
+			}
+			found = true
+			break
+		}
+		if !found {
+			values = append(values, probe)
+		}
+	}
+	return values
+}
+
+// getSpecDiff returns the unified diff between the YAML of the current spec and the migrated spec
+func getSpecDiff(current, migrated *base.CommonSpec) (string, error) {
+	return common.GetCommonSpecDiff(current, migrated, "current", "migrated")
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package migrate
+
+import (
+	"fmt"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"knative.dev/kn-plugin-operator/pkg/command/testingUtil"
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+func TestValidateMigrateSpecFlags(t *testing.T) {
+	for _, tt := range []struct {
+		name                string
+		migrateSpecCMDFlags migrateSpecFlags
+		expectedResult      error
+	}{{
+		name:                "Migrate spec flags for Knative Serving",
+		migrateSpecCMDFlags: migrateSpecFlags{Component: "serving"},
+		expectedResult:      nil,
+	}, {
+		name:                "Migrate spec flags without component",
+		migrateSpecCMDFlags: migrateSpecFlags{Namespace: "test-eventing"},
+		expectedResult:      fmt.Errorf("You need to specify the component for Knative: serving or eventing."),
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := validateMigrateSpecFlags(tt.migrateSpecCMDFlags)
+			if tt.expectedResult == nil {
+				testingUtil.AssertEqual(t, result, nil)
+			} else {
+				testingUtil.AssertEqual(t, result.Error(), tt.expectedResult.Error())
+			}
+		})
+	}
+}
+
+func TestMigrateCommonSpec(t *testing.T) {
+	one, three := int32(1), int32(3)
+	cpu := base.ResourceRequirementsOverride{
+		Container: "activator",
+		ResourceRequirements: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+		},
+	}
+	memory := base.ResourceRequirementsOverride{
+		Container: "activator",
+		ResourceRequirements: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+		},
+	}
+	webhook := base.ResourceRequirementsOverride{
+		Container: "webhook",
+		ResourceRequirements: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+		},
+	}
+	cpuAndMemory := base.ResourceRequirementsOverride{
+		Container: "activator",
+		ResourceRequirements: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1"),
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+			},
+		},
+	}
+	unknown := base.ResourceRequirementsOverride{Container: "unknown"}
+	containerDeployments := map[string][]string{
+		"activator": {"activator"},
+		"webhook":   {"webhook"},
+	}
+
+	for _, tt := range []struct {
+		name             string
+		commonSpec       *base.CommonSpec
+		expectedResult   *base.CommonSpec
+		expectedWarnings []string
+	}{{
+		name: "Spec without deprecated fields",
+		commonSpec: &base.CommonSpec{
+			Workloads: []base.WorkloadOverride{{Name: "activator", Replicas: &three}},
+		},
+		expectedResult: &base.CommonSpec{
+			Workloads: []base.WorkloadOverride{{Name: "activator", Replicas: &three}},
+		},
+		expectedWarnings: []string{},
+	}, {
+		name: "Spec with deployment overrides merged into workloads",
+		commonSpec: &base.CommonSpec{
+			DeploymentOverride: []base.WorkloadOverride{{
+				Name:      "activator",
+				Replicas:  &one,
+				Labels:    map[string]string{"a": "deprecated", "b": "deprecated"},
+				Resources: []base.ResourceRequirementsOverride{memory},
+			}, {
+				Name:     "webhook",
+				Replicas: &one,
+			}},
+			Workloads: []base.WorkloadOverride{{
+				Name:      "activator",
+				Replicas:  &three,
+				Labels:    map[string]string{"a": "current"},
+				Resources: []base.ResourceRequirementsOverride{cpu},
+			}},
+		},
+		expectedResult: &base.CommonSpec{
+			Workloads: []base.WorkloadOverride{{
+				Name:      "activator",
+				Replicas:  &three,
+				Labels:    map[string]string{"a": "current", "b": "deprecated"},
+				Resources: []base.ResourceRequirementsOverride{cpuAndMemory},
+			}, {
+				Name:     "webhook",
+				Replicas: &one,
+			}},
+		},
+		expectedWarnings: []string{},
+	}, {
+		name: "Spec with deprecated resources",
+		commonSpec: &base.CommonSpec{
+			DeprecatedResources: []base.ResourceRequirementsOverride{memory, webhook, unknown},
+			Workloads: []base.WorkloadOverride{{
+				Name:     "activator",
+				Replicas: &three,
+			}},
+		},
+		expectedResult: &base.CommonSpec{
+			DeprecatedResources: []base.ResourceRequirementsOverride{unknown},
+			Workloads: []base.WorkloadOverride{{
+				Name:      "activator",
+				Replicas:  &three,
+				Resources: []base.ResourceRequirementsOverride{memory},
+			}, {
+				Name:      "webhook",
+				Resources: []base.ResourceRequirementsOverride{webhook},
+			}},
+		},
+		expectedWarnings: []string{"The container unknown is not found in any deployment. Its resources are kept under spec.resources."},
+	}, {
+		name: "Spec with deprecated overrides of the same containers",
+		commonSpec: &base.CommonSpec{
+			DeprecatedResources: []base.ResourceRequirementsOverride{{
+				Container: "activator",
+				ResourceRequirements: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("60Mi")},
+					Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+				},
+			}},
+			DeploymentOverride: []base.WorkloadOverride{{
+				Name: "activator",
+				Env: []base.EnvRequirementsOverride{{
+					Container: "activator",
+					EnvVars:   []corev1.EnvVar{{Name: "A", Value: "deprecated"}, {Name: "B", Value: "deprecated"}},
+				}},
+				ReadinessProbes: []base.ProbesRequirementsOverride{{
+					Container:        "activator",
+					PeriodSeconds:    5,
+					FailureThreshold: 5,
+				}},
+			}},
+			Workloads: []base.WorkloadOverride{{
+				Name:      "activator",
+				Resources: []base.ResourceRequirementsOverride{cpu},
+				Env: []base.EnvRequirementsOverride{{
+					Container: "activator",
+					EnvVars:   []corev1.EnvVar{{Name: "A", Value: "current"}},
+				}},
+				ReadinessProbes: []base.ProbesRequirementsOverride{{
+					Container:     "activator",
+					PeriodSeconds: 10,
+				}},
+			}},
+		},
+		expectedResult: &base.CommonSpec{
+			Workloads: []base.WorkloadOverride{{
+				Name: "activator",
+				Resources: []base.ResourceRequirementsOverride{{
+					Container: "activator",
+					ResourceRequirements: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("60Mi")},
+						Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+					},
+				}},
+				Env: []base.EnvRequirementsOverride{{
+					Container: "activator",
+					EnvVars:   []corev1.EnvVar{{Name: "A", Value: "current"}, {Name: "B", Value: "deprecated"}},
+				}},
+				ReadinessProbes: []base.ProbesRequirementsOverride{{
+					Container:        "activator",
+					PeriodSeconds:    10,
+					FailureThreshold: 5,
+				}},
+			}},
+		},
+		expectedWarnings: []string{},
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result, warnings := migrateCommonSpec(tt.commonSpec, containerDeployments)
+			testingUtil.AssertDeepEqual(t, result, tt.expectedResult)
+			testingUtil.AssertDeepEqual(t, warnings, tt.expectedWarnings)
+		})
+	}
+}
+
+func TestGetSpecDiff(t *testing.T) {
+	three := int32(3)
+	current := &base.CommonSpec{
+		DeploymentOverride: []base.WorkloadOverride{{Name: "activator", Replicas: &three}},
+	}
+	migrated, _ := migrateCommonSpec(current, nil)
+	expectedResult := `--- current
++++ migrated
+@@ -1,4 +1,4 @@
+-deployments:
++registry: {}
++workloads:
+ - name: activator
+   replicas: 3
+-registry: {}
+`
+	result, err := getSpecDiff(current, migrated)
+	testingUtil.AssertEqual(t, err, nil)
+	testingUtil.AssertEqual(t, result, expectedResult)
+
+	result, err = getSpecDiff(migrated, migrated)
+	testingUtil.AssertEqual(t, err, nil)
+	testingUtil.AssertEqual(t, result, "")
+}
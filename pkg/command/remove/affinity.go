@@ -0,0 +1,134 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remove
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc" // from https://github.com/kubernetes/client-go/issues/345
+	"k8s.io/client-go/util/retry"
+
+	"knative.dev/kn-plugin-operator/pkg"
+	"knative.dev/kn-plugin-operator/pkg/command/common"
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+type AffinityFlags struct {
+	NodeAffinity    bool
+	PodAntiAffinity bool
+	Component       string
+	Namespace       string
+	DeployName      string
+}
+
+var affinityCMDFlags AffinityFlags
+
+// removeAffinityCommand represents the remove commands for the affinity in Knative Serving or Eventing
+func removeAffinityCommand(p *pkg.OperatorParams) *cobra.Command {
+	var removeAffinityCmd = &cobra.Command{
+		Use:   "affinity",
+		Short: "Remove the affinity for Knative Serving and Eventing deployments",
+		Example: `
+  # Remove the affinity for all Knative Serving deployments
+  kn operator remove affinity --component serving --namespace knative-serving
+  # Remove only the pod anti-affinity for the deployment activator
+  kn operator remove affinity --component serving --deployName activator --podAntiAffinity --namespace knative-serving`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateAffinityFlags(affinityCMDFlags); err != nil {
+				return err
+			}
+
+			err := deleteAffinity(affinityCMDFlags, p)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "The specified affinity has been removed in the namespace '%s'.\n",
+				affinityCMDFlags.Namespace)
+			return nil
+		},
+	}
+
+	removeAffinityCmd.Flags().BoolVar(&affinityCMDFlags.NodeAffinity, "nodeAffinity", false, "The flag to remove only the node affinity")
+	removeAffinityCmd.Flags().BoolVar(&affinityCMDFlags.PodAntiAffinity, "podAntiAffinity", false, "The flag to remove only the pod anti-affinity")
+	removeAffinityCmd.Flags().StringVar(&affinityCMDFlags.DeployName, "deployName", "", "The flag to specify the deployment name")
+	removeAffinityCmd.Flags().StringVarP(&affinityCMDFlags.Component, "component", "c", "", "The flag to specify the component name")
+	removeAffinityCmd.Flags().StringVarP(&affinityCMDFlags.Namespace, "namespace", "n", "", "The namespace of the Knative Operator or the Knative component")
+
+	return removeAffinityCmd
+}
+
+func validateAffinityFlags(affinityCMDFlags AffinityFlags) error {
+	if affinityCMDFlags.Component == "" {
+		return fmt.Errorf("You need to specify the component name.")
+	}
+	if affinityCMDFlags.Namespace == "" {
+		return fmt.Errorf("You need to specify the namespace.")
+	}
+	if !strings.EqualFold(affinityCMDFlags.Component, common.ServingComponent) && !strings.EqualFold(affinityCMDFlags.Component, common.EventingComponent) {
+		return fmt.Errorf("You need to specify the component for Knative: serving or eventing.")
+	}
+	return nil
+}
+
+func deleteAffinity(affinityCMDFlags AffinityFlags, p *pkg.OperatorParams) error {
+	ksCR, err := common.GetKnativeOperatorCR(p)
+	if err != nil {
+		return err
+	}
+
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		return ksCR.UpdateWorkloadOverrides(affinityCMDFlags.Component, affinityCMDFlags.Namespace, func(workloadOverrides []base.WorkloadOverride) []base.WorkloadOverride {
+			return removeAffinityFields(workloadOverrides, affinityCMDFlags)
+		})
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func removeAffinityFields(workloadOverrides []base.WorkloadOverride, affinityCMDFlags AffinityFlags) []base.WorkloadOverride {
+	for i, deploy := range workloadOverrides {
+		// If no deploy is specified, we will iterate all the deployments to remove the affinity configurations.
+		if affinityCMDFlags.DeployName != "" && deploy.Name != affinityCMDFlags.DeployName {
+			continue
+		}
+		if deploy.Affinity == nil {
+			continue
+		}
+		if !affinityCMDFlags.NodeAffinity && !affinityCMDFlags.PodAntiAffinity {
+			workloadOverrides[i].Affinity = nil
+			continue
+		}
+
+		if affinityCMDFlags.NodeAffinity {
+			workloadOverrides[i].Affinity.NodeAffinity = nil
+		}
+		if affinityCMDFlags.PodAntiAffinity {
+			workloadOverrides[i].Affinity.PodAntiAffinity = nil
+		}
+		if workloadOverrides[i].Affinity.NodeAffinity == nil && workloadOverrides[i].Affinity.PodAffinity == nil &&
+			workloadOverrides[i].Affinity.PodAntiAffinity == nil {
+			workloadOverrides[i].Affinity = nil
+		}
+	}
+
+	return workloadOverrides
+}
@@ -0,0 +1,183 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remove
+
+import (
+	"fmt"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/kn-plugin-operator/pkg/command/testingUtil"
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+func TestValidateAffinityFlags(t *testing.T) {
+	for _, tt := range []struct {
+		name             string
+		affinityCMDFlags AffinityFlags
+		expectedResult   error
+	}{{
+		name: "Affinity flags with correct component and namespace",
+		affinityCMDFlags: AffinityFlags{
+			Component: "serving",
+			Namespace: "test-serving",
+		},
+		expectedResult: nil,
+	}, {
+		name: "Affinity flags without component",
+		affinityCMDFlags: AffinityFlags{
+			Namespace:  "test-serving",
+			DeployName: "activator",
+		},
+		expectedResult: fmt.Errorf("You need to specify the component name."),
+	}, {
+		name: "Affinity flags without namespace",
+		affinityCMDFlags: AffinityFlags{
+			Component:  "serving",
+			DeployName: "activator",
+		},
+		expectedResult: fmt.Errorf("You need to specify the namespace."),
+	}, {
+		name: "Affinity flags with invalid component",
+		affinityCMDFlags: AffinityFlags{
+			Component: "test",
+			Namespace: "test-serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify the component for Knative: serving or eventing."),
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := validateAffinityFlags(tt.affinityCMDFlags)
+			if tt.expectedResult == nil {
+				testingUtil.AssertEqual(t, result, nil)
+			} else {
+				testingUtil.AssertEqual(t, result.Error(), tt.expectedResult.Error())
+			}
+		})
+	}
+}
+
+func testAffinity() *corev1.Affinity {
+	return &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{},
+		},
+		PodAntiAffinity: &corev1.PodAntiAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{{
+				TopologyKey: corev1.LabelTopologyZone,
+			}},
+		},
+	}
+}
+
+func testDeploymentForAffinity() []base.WorkloadOverride {
+	return []base.WorkloadOverride{
+		{
+			Name:     "activator",
+			Affinity: testAffinity(),
+		},
+		{
+			Name:     "webhook",
+			Affinity: testAffinity(),
+		},
+	}
+}
+
+func TestRemoveAffinityFields(t *testing.T) {
+	for _, tt := range []struct {
+		name             string
+		affinityCMDFlags AffinityFlags
+		input            []base.WorkloadOverride
+		expectedResult   []base.WorkloadOverride
+	}{{
+		name: "Affinity flags with correct component and namespace",
+		affinityCMDFlags: AffinityFlags{
+			Component: "serving",
+			Namespace: "test-serving",
+		},
+		input: testDeploymentForAffinity(),
+		expectedResult: []base.WorkloadOverride{
+			{
+				Name: "activator",
+			},
+			{
+				Name: "webhook",
+			},
+		},
+	}, {
+		name: "Affinity flags with the deployment name",
+		affinityCMDFlags: AffinityFlags{
+			Component:  "serving",
+			Namespace:  "test-serving",
+			DeployName: "activator",
+		},
+		input: testDeploymentForAffinity(),
+		expectedResult: []base.WorkloadOverride{
+			{
+				Name: "activator",
+			},
+			{
+				Name:     "webhook",
+				Affinity: testAffinity(),
+			},
+		},
+	}, {
+		name: "Affinity flags with the deployment name and the pod anti-affinity",
+		affinityCMDFlags: AffinityFlags{
+			Component:       "serving",
+			Namespace:       "test-serving",
+			DeployName:      "activator",
+			PodAntiAffinity: true,
+		},
+		input: testDeploymentForAffinity(),
+		expectedResult: []base.WorkloadOverride{
+			{
+				Name: "activator",
+				Affinity: &corev1.Affinity{
+					NodeAffinity: &corev1.NodeAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{},
+					},
+				},
+			},
+			{
+				Name:     "webhook",
+				Affinity: testAffinity(),
+			},
+		},
+	}, {
+		name: "Affinity flags with the node affinity and the pod anti-affinity",
+		affinityCMDFlags: AffinityFlags{
+			Component:       "serving",
+			Namespace:       "test-serving",
+			DeployName:      "webhook",
+			NodeAffinity:    true,
+			PodAntiAffinity: true,
+		},
+		input: testDeploymentForAffinity(),
+		expectedResult: []base.WorkloadOverride{
+			{
+				Name:     "activator",
+				Affinity: testAffinity(),
+			},
+			{
+				Name: "webhook",
+			},
+		},
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := removeAffinityFields(tt.input, tt.affinityCMDFlags)
+			testingUtil.AssertDeepEqual(t, result, tt.expectedResult)
+		})
+	}
+}
@@ -71,14 +71,10 @@ func deleteAnnotations(annotationCMDFlags common.KeyValueFlags, p *pkg.OperatorP
 	}
 
 	if annotationCMDFlags.DeployName != "" {
-		wordloads, err := ksCR.GetDeployments(annotationCMDFlags.Component, annotationCMDFlags.Namespace)
-		if err != nil {
-			return err
-		}
-
-		wordloads = removeAnnotationsDeployFields(wordloads, annotationCMDFlags)
 		if err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
-			return ksCR.UpdateDeployments(annotationCMDFlags.Component, annotationCMDFlags.Namespace, wordloads)
+			return ksCR.UpdateWorkloadOverrides(annotationCMDFlags.Component, annotationCMDFlags.Namespace, func(workloadOverrides []base.WorkloadOverride) []base.WorkloadOverride {
+				return removeAnnotationsDeployFields(workloadOverrides, annotationCMDFlags)
+			})
 		}); err != nil {
 			return err
 		}
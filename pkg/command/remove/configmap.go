@@ -16,7 +16,6 @@ package remove
 
 import (
 	"fmt"
-	"strings"
 
 	"k8s.io/client-go/util/retry"
 	"knative.dev/operator/pkg/apis/operator/base"
@@ -105,11 +104,11 @@ func removeCMsFields(cmData base.ConfigMapData, cmsCMDFlags common.CMsFlags) bas
 	} else if cmsCMDFlags.Key == "" {
 		// Remove the configurations for the CM named cmsCMDFlags.CMName.
 		dropMapKey(cmData, cmsCMDFlags.CMName)
-		key := addOrRemovePrefix(cmsCMDFlags.CMName, "config-")
+		key := common.AddOrRemovePrefix(cmsCMDFlags.CMName, common.ConfigMapPrefix)
 		dropMapKey(cmData, key)
 	} else if cmsCMDFlags.Key != "" {
 		dropMapConfigKey(cmData, cmsCMDFlags.CMName, cmsCMDFlags.Key)
-		key := addOrRemovePrefix(cmsCMDFlags.CMName, "config-")
+		key := common.AddOrRemovePrefix(cmsCMDFlags.CMName, common.ConfigMapPrefix)
 		dropMapConfigKey(cmData, key, cmsCMDFlags.Key)
 	}
 
@@ -129,13 +128,3 @@ func dropMapConfigKey(cmData base.ConfigMapData, key, configKey string) {
 		}
 	}
 }
-
-func addOrRemovePrefix(val, prefix string) string {
-	result := ""
-	if strings.HasPrefix(val, prefix) {
-		result = val[len(prefix):]
-	} else {
-		result = fmt.Sprintf("%s%s", prefix, val)
-	}
-	return result
-}
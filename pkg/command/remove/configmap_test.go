@@ -229,11 +229,3 @@ func TestRemoveCMsFields(t *testing.T) {
 		})
 	}
 }
-
-func TestAddOrRemovePrefix(t *testing.T) {
-	result := addOrRemovePrefix("test", "config-")
-	testingUtil.AssertDeepEqual(t, result, "config-test")
-
-	result = addOrRemovePrefix("config-test", "config-")
-	testingUtil.AssertDeepEqual(t, result, "test")
-}
@@ -0,0 +1,105 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remove
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc" // from https://github.com/kubernetes/client-go/issues/345
+	"k8s.io/client-go/util/retry"
+
+	"knative.dev/kn-plugin-operator/pkg"
+	"knative.dev/kn-plugin-operator/pkg/command/common"
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+type CustomCertsFlags struct {
+	Namespace string
+}
+
+var customCertsCMDFlags CustomCertsFlags
+
+// removeCustomCertsCommand represents the remove commands for the custom CA certificates of the Knative Serving controller
+func removeCustomCertsCommand(p *pkg.OperatorParams) *cobra.Command {
+	var removeCustomCertsCmd = &cobra.Command{
+		Use:   "custom-certs",
+		Short: "Remove the custom CA certificates for the Knative Serving controller",
+		Example: `
+  # Remove the custom CA certificates for the Knative Serving controller
+  kn operator remove custom-certs --namespace knative-serving`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateCustomCertsFlags(customCertsCMDFlags); err != nil {
+				return err
+			}
+
+			err := deleteCustomCerts(customCertsCMDFlags, p)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "The custom CA certificates have been removed in the namespace '%s'.\n",
+				customCertsCMDFlags.Namespace)
+			return nil
+		},
+	}
+
+	removeCustomCertsCmd.Flags().StringVarP(&customCertsCMDFlags.Namespace, "namespace", "n", "", "The namespace of Knative Serving")
+
+	return removeCustomCertsCmd
+}
+
+func validateCustomCertsFlags(customCertsCMDFlags CustomCertsFlags) error {
+	if customCertsCMDFlags.Namespace == "" {
+		return fmt.Errorf("You need to specify the namespace.")
+	}
+	return nil
+}
+
+func deleteCustomCerts(customCertsCMDFlags CustomCertsFlags, p *pkg.OperatorParams) error {
+	ksCR, err := common.GetKnativeOperatorCR(p)
+	if err != nil {
+		return err
+	}
+
+	var customCerts base.CustomCerts
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		ks, err := ksCR.GetKnativeServingInCluster(customCertsCMDFlags.Namespace)
+		if err != nil {
+			return err
+		}
+		customCerts = ks.Spec.ControllerCustomCerts
+		ks.Spec.ControllerCustomCerts = base.CustomCerts{}
+		_, err = ksCR.UpdateKnativeServing(ks)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if customCerts.Name == "" {
+		return nil
+	}
+
+	// Clean up the Secret or the ConfigMap, if it was created by the configure command
+	client, err := p.NewKubeClient()
+	if err != nil {
+		return fmt.Errorf("cannot get source cluster kube config, please use --kubeconfig or export environment variable KUBECONFIG to set\n")
+	}
+	kubeResource := common.KubeResource{
+		KubeClient: client,
+	}
+	return kubeResource.DeleteManagedResource(customCerts.Type, customCerts.Name, customCertsCMDFlags.Namespace)
+}
@@ -0,0 +1,49 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remove
+
+import (
+	"fmt"
+	"testing"
+
+	"knative.dev/kn-plugin-operator/pkg/command/testingUtil"
+)
+
+func TestValidateCustomCertsFlags(t *testing.T) {
+	for _, tt := range []struct {
+		name                string
+		customCertsCMDFlags CustomCertsFlags
+		expectedResult      error
+	}{{
+		name: "Custom certs flags with namespace",
+		customCertsCMDFlags: CustomCertsFlags{
+			Namespace: "test-serving",
+		},
+		expectedResult: nil,
+	}, {
+		name:                "Custom certs flags without namespace",
+		customCertsCMDFlags: CustomCertsFlags{},
+		expectedResult:      fmt.Errorf("You need to specify the namespace."),
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := validateCustomCertsFlags(tt.customCertsCMDFlags)
+			if tt.expectedResult == nil {
+				testingUtil.AssertEqual(t, result, nil)
+			} else {
+				testingUtil.AssertEqual(t, result.Error(), tt.expectedResult.Error())
+			}
+		})
+	}
+}
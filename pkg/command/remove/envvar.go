@@ -101,15 +101,10 @@ func removeEnvVars(envVarFlags EnvVarFlags, p *pkg.OperatorParams) error {
 		return err
 	}
 
-	workloadOverrides, err := ksCR.GetDeployments(envVarFlags.Component, envVarFlags.Namespace)
-	if err != nil {
-		return err
-	}
-
-	workloadOverrides = removeEnvVarsFields(workloadOverrides, envVarFlags)
-
 	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		return ksCR.UpdateDeployments(envVarFlags.Component, envVarFlags.Namespace, workloadOverrides)
+		return ksCR.UpdateWorkloadOverrides(envVarFlags.Component, envVarFlags.Namespace, func(workloadOverrides []base.WorkloadOverride) []base.WorkloadOverride {
+			return removeEnvVarsFields(workloadOverrides, envVarFlags)
+		})
 	})
 
 	if err != nil {
@@ -0,0 +1,110 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remove
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc" // from https://github.com/kubernetes/client-go/issues/345
+	"k8s.io/client-go/util/retry"
+
+	"knative.dev/kn-plugin-operator/pkg"
+	"knative.dev/kn-plugin-operator/pkg/command/common"
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+type HostNetworkFlags struct {
+	Component  string
+	Namespace  string
+	DeployName string
+}
+
+var hostNetworkCMDFlags HostNetworkFlags
+
+// removeHostNetworkCommand represents the remove commands for the host network in Knative Serving or Eventing
+func removeHostNetworkCommand(p *pkg.OperatorParams) *cobra.Command {
+	var removeHostNetworkCmd = &cobra.Command{
+		Use:   "host-network",
+		Short: "Remove the host network configuration for Knative Serving and Eventing deployments",
+		Example: `
+  # Remove the host network configuration for the deployment activator
+  kn operator remove host-network --component serving --deployName activator --namespace knative-serving`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateHostNetworkFlags(hostNetworkCMDFlags); err != nil {
+				return err
+			}
+
+			err := deleteHostNetwork(hostNetworkCMDFlags, p)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "The host network configuration has been removed in the namespace '%s'.\n",
+				hostNetworkCMDFlags.Namespace)
+			return nil
+		},
+	}
+
+	removeHostNetworkCmd.Flags().StringVar(&hostNetworkCMDFlags.DeployName, "deployName", "", "The flag to specify the deployment name")
+	removeHostNetworkCmd.Flags().StringVarP(&hostNetworkCMDFlags.Component, "component", "c", "", "The flag to specify the component name")
+	removeHostNetworkCmd.Flags().StringVarP(&hostNetworkCMDFlags.Namespace, "namespace", "n", "", "The namespace of the Knative Operator or the Knative component")
+
+	return removeHostNetworkCmd
+}
+
+func validateHostNetworkFlags(hostNetworkCMDFlags HostNetworkFlags) error {
+	if hostNetworkCMDFlags.Component == "" {
+		return fmt.Errorf("You need to specify the component name.")
+	}
+	if hostNetworkCMDFlags.Namespace == "" {
+		return fmt.Errorf("You need to specify the namespace.")
+	}
+	if !strings.EqualFold(hostNetworkCMDFlags.Component, common.ServingComponent) && !strings.EqualFold(hostNetworkCMDFlags.Component, common.EventingComponent) {
+		return fmt.Errorf("You need to specify the component for Knative: serving or eventing.")
+	}
+	return nil
+}
+
+func deleteHostNetwork(hostNetworkCMDFlags HostNetworkFlags, p *pkg.OperatorParams) error {
+	ksCR, err := common.GetKnativeOperatorCR(p)
+	if err != nil {
+		return err
+	}
+
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		return ksCR.UpdateWorkloadOverrides(hostNetworkCMDFlags.Component, hostNetworkCMDFlags.Namespace, func(workloadOverrides []base.WorkloadOverride) []base.WorkloadOverride {
+			return removeHostNetworkFields(workloadOverrides, hostNetworkCMDFlags)
+		})
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func removeHostNetworkFields(workloadOverrides []base.WorkloadOverride, hostNetworkCMDFlags HostNetworkFlags) []base.WorkloadOverride {
+	for i, deploy := range workloadOverrides {
+		// If no deploy is specified, we will iterate all the deployments to remove the host network configurations.
+		if hostNetworkCMDFlags.DeployName == "" || deploy.Name == hostNetworkCMDFlags.DeployName {
+			workloadOverrides[i].HostNetwork = nil
+		}
+	}
+
+	return workloadOverrides
+}
@@ -0,0 +1,123 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remove
+
+import (
+	"fmt"
+	"testing"
+
+	"knative.dev/kn-plugin-operator/pkg/command/testingUtil"
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+func TestValidateHostNetworkFlags(t *testing.T) {
+	for _, tt := range []struct {
+		name                string
+		hostNetworkCMDFlags HostNetworkFlags
+		expectedResult      error
+	}{{
+		name: "Host network flags with correct component and namespace",
+		hostNetworkCMDFlags: HostNetworkFlags{
+			Component: "serving",
+			Namespace: "test-serving",
+		},
+		expectedResult: nil,
+	}, {
+		name: "Host network flags without component",
+		hostNetworkCMDFlags: HostNetworkFlags{
+			Namespace: "test-serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify the component name."),
+	}, {
+		name: "Host network flags without namespace",
+		hostNetworkCMDFlags: HostNetworkFlags{
+			Component: "serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify the namespace."),
+	}, {
+		name: "Host network flags with invalid component",
+		hostNetworkCMDFlags: HostNetworkFlags{
+			Component: "test",
+			Namespace: "test-serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify the component for Knative: serving or eventing."),
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := validateHostNetworkFlags(tt.hostNetworkCMDFlags)
+			if tt.expectedResult == nil {
+				testingUtil.AssertEqual(t, result, nil)
+			} else {
+				testingUtil.AssertEqual(t, result.Error(), tt.expectedResult.Error())
+			}
+		})
+	}
+}
+
+func testDeploymentForHostNetwork() []base.WorkloadOverride {
+	enabled := true
+	return []base.WorkloadOverride{
+		{
+			Name:        "activator",
+			HostNetwork: &enabled,
+		},
+		{
+			Name:        "webhook",
+			HostNetwork: &enabled,
+		},
+	}
+}
+
+func TestRemoveHostNetworkFields(t *testing.T) {
+	for _, tt := range []struct {
+		name                string
+		hostNetworkCMDFlags HostNetworkFlags
+		input               []base.WorkloadOverride
+		expectedResult      []base.WorkloadOverride
+	}{{
+		name: "Host network flags with correct component and namespace",
+		hostNetworkCMDFlags: HostNetworkFlags{
+			Component: "serving",
+			Namespace: "test-serving",
+		},
+		input: testDeploymentForHostNetwork(),
+		expectedResult: []base.WorkloadOverride{
+			{
+				Name: "activator",
+			},
+			{
+				Name: "webhook",
+			},
+		},
+	}, {
+		name: "Host network flags with correct deploy, component and namespace",
+		hostNetworkCMDFlags: HostNetworkFlags{
+			Component:  "serving",
+			Namespace:  "test-serving",
+			DeployName: "activator",
+		},
+		input: testDeploymentForHostNetwork(),
+		expectedResult: []base.WorkloadOverride{
+			{
+				Name: "activator",
+			},
+			testDeploymentForHostNetwork()[1],
+		},
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := removeHostNetworkFields(tt.input, tt.hostNetworkCMDFlags)
+			testingUtil.AssertDeepEqual(t, result, tt.expectedResult)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remove
+
+import (
+	"github.com/spf13/cobra"
+	"knative.dev/kn-plugin-operator/pkg"
+)
+
+// removeIngressCommand represents the remove commands for the ingresses of Knative Serving
+func removeIngressCommand(p *pkg.OperatorParams) *cobra.Command {
+	var removeIngressCmd = &cobra.Command{
+		Use:   "ingress",
+		Short: "Remove the configuration of the ingress for Knative Serving",
+		Example: `
+  # Remove the override of knative-ingress-gateway for Knative Serving
+  kn operator remove ingress istio --gateway ingress --namespace knative-serving`,
+	}
+
+	removeIngressCmd.AddCommand(removeIstioCommand(p))
+
+	return removeIngressCmd
+}
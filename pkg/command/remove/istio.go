@@ -0,0 +1,143 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remove
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc" // from https://github.com/kubernetes/client-go/issues/345
+	"k8s.io/client-go/util/retry"
+
+	"knative.dev/kn-plugin-operator/pkg"
+	"knative.dev/kn-plugin-operator/pkg/command/common"
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+type IstioFlags struct {
+	Gateway      string
+	Ports        []uint
+	SelectorKeys []string
+	Namespace    string
+}
+
+var istioCMDFlags IstioFlags
+
+// removeIstioCommand represents the remove commands for the gateways of the ingress istio for Knative Serving
+func removeIstioCommand(p *pkg.OperatorParams) *cobra.Command {
+	var removeIstioCmd = &cobra.Command{
+		Use:   "istio",
+		Short: "Remove the override of the gateways of the ingress istio for Knative Serving",
+		Example: `
+  # Remove the override of knative-ingress-gateway for Knative Serving
+  kn operator remove ingress istio --gateway ingress --namespace knative-serving
+  # Remove the server listening on the port 443 of knative-ingress-gateway for Knative Serving
+  kn operator remove ingress istio --gateway ingress --port 443 --namespace knative-serving
+  # Remove the selector key istio of knative-local-gateway for Knative Serving
+  kn operator remove ingress istio --gateway local --selector-key istio --namespace knative-serving`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateIstioFlags(istioCMDFlags); err != nil {
+				return err
+			}
+
+			gatewayName, _ := common.GetIstioGatewayName(istioCMDFlags.Gateway)
+			err := removeIstioGateway(gatewayName, istioCMDFlags, p)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "The override of the gateway %s has been removed in the namespace '%s'.\n",
+				gatewayName, istioCMDFlags.Namespace)
+			return nil
+		},
+	}
+
+	removeIstioCmd.Flags().StringVar(&istioCMDFlags.Gateway, "gateway", "", "The gateway to remove the override: ingress or local")
+	removeIstioCmd.Flags().UintSliceVar(&istioCMDFlags.Ports, "port", []uint{}, "The port of the server to remove")
+	removeIstioCmd.Flags().StringSliceVar(&istioCMDFlags.SelectorKeys, "selector-key", []string{}, "The key of the selector to remove")
+	removeIstioCmd.Flags().StringVarP(&istioCMDFlags.Namespace, "namespace", "n", "", "The namespace of Knative Serving")
+
+	return removeIstioCmd
+}
+
+func validateIstioFlags(istioCMDFlags IstioFlags) error {
+	if _, err := common.GetIstioGatewayName(istioCMDFlags.Gateway); err != nil {
+		return err
+	}
+	if istioCMDFlags.Namespace == "" {
+		return fmt.Errorf("You need to specify the namespace.")
+	}
+	return nil
+}
+
+func removeIstioGateway(gatewayName string, istioCMDFlags IstioFlags, p *pkg.OperatorParams) error {
+	ksCR, err := common.GetKnativeOperatorCR(p)
+	if err != nil {
+		return err
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		ks, err := ksCR.GetKnativeServingInCluster(istioCMDFlags.Namespace)
+		if err != nil {
+			return err
+		}
+		override := common.GetIstioGatewayOverrideFromSpec(&ks.Spec, gatewayName)
+		common.SetIstioGatewayOverrideInSpec(&ks.Spec, gatewayName, removeIstioGatewayFields(override, istioCMDFlags))
+		_, err = ksCR.UpdateKnativeServing(ks)
+		return err
+	})
+}
+
+func removeIstioGatewayFields(override *base.IstioGatewayOverride, istioCMDFlags IstioFlags) *base.IstioGatewayOverride {
+	if override == nil || (len(istioCMDFlags.Ports) == 0 && len(istioCMDFlags.SelectorKeys) == 0) {
+		// If neither port nor selector key is specified, we will remove the whole override of the gateway.
+		return nil
+	}
+
+	overrideBack := override.DeepCopy()
+	for _, key := range istioCMDFlags.SelectorKeys {
+		delete(overrideBack.Selector, key)
+	}
+	if len(overrideBack.Selector) == 0 {
+		overrideBack.Selector = nil
+	}
+
+	if len(istioCMDFlags.Ports) > 0 {
+		servers := make([]base.IstioServer, 0, len(overrideBack.Servers))
+		for _, server := range overrideBack.Servers {
+			if server.Port == nil || !containsPort(istioCMDFlags.Ports, server.Port.Number) {
+				servers = append(servers, server)
+			}
+		}
+		overrideBack.Servers = servers
+	}
+	if len(overrideBack.Servers) == 0 {
+		overrideBack.Servers = nil
+	}
+
+	if overrideBack.Selector == nil && overrideBack.Servers == nil {
+		return nil
+	}
+	return overrideBack
+}
+
+func containsPort(ports []uint, port uint32) bool {
+	for _, p := range ports {
+		if uint32(p) == port {
+			return true
+		}
+	}
+	return false
+}
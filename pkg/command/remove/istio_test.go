@@ -0,0 +1,99 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remove
+
+import (
+	"testing"
+
+	"knative.dev/kn-plugin-operator/pkg/command/testingUtil"
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+func TestRemoveIstioGatewayFields(t *testing.T) {
+	httpsServer := base.IstioServer{
+		Port:  &base.IstioPort{Number: 443, Protocol: "HTTPS", Name: "https-443"},
+		Hosts: []string{"*.example.com"},
+		Tls:   &base.IstioServerTLSSettings{Mode: "SIMPLE", CredentialName: "example-cert"},
+	}
+	httpServer := base.IstioServer{
+		Port:  &base.IstioPort{Number: 80, Protocol: "HTTP", Name: "http-80"},
+		Hosts: []string{"*"},
+	}
+	override := &base.IstioGatewayOverride{
+		Selector: map[string]string{"istio": "custom", "app": "gateway"},
+		Servers:  []base.IstioServer{httpServer, httpsServer},
+	}
+	for _, tt := range []struct {
+		name           string
+		override       *base.IstioGatewayOverride
+		istioCMDFlags  IstioFlags
+		expectedResult *base.IstioGatewayOverride
+	}{{
+		name:     "Remove the whole override",
+		override: override,
+		istioCMDFlags: IstioFlags{
+			Gateway:   "ingress",
+			Namespace: "test-serving",
+		},
+		expectedResult: nil,
+	}, {
+		name:     "Remove the server on the port 443",
+		override: override,
+		istioCMDFlags: IstioFlags{
+			Gateway:   "ingress",
+			Ports:     []uint{443},
+			Namespace: "test-serving",
+		},
+		expectedResult: &base.IstioGatewayOverride{
+			Selector: map[string]string{"istio": "custom", "app": "gateway"},
+			Servers:  []base.IstioServer{httpServer},
+		},
+	}, {
+		name:     "Remove the selector key istio",
+		override: override,
+		istioCMDFlags: IstioFlags{
+			Gateway:      "ingress",
+			SelectorKeys: []string{"istio"},
+			Namespace:    "test-serving",
+		},
+		expectedResult: &base.IstioGatewayOverride{
+			Selector: map[string]string{"app": "gateway"},
+			Servers:  []base.IstioServer{httpServer, httpsServer},
+		},
+	}, {
+		name:     "Remove all the servers and the selector keys",
+		override: override,
+		istioCMDFlags: IstioFlags{
+			Gateway:      "ingress",
+			Ports:        []uint{80, 443},
+			SelectorKeys: []string{"istio", "app"},
+			Namespace:    "test-serving",
+		},
+		expectedResult: nil,
+	}, {
+		name: "Remove from the gateway without override",
+		istioCMDFlags: IstioFlags{
+			Gateway:   "local",
+			Ports:     []uint{443},
+			Namespace: "test-serving",
+		},
+		expectedResult: nil,
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := removeIstioGatewayFields(tt.override, tt.istioCMDFlags)
+			testingUtil.AssertDeepEqual(t, result, tt.expectedResult)
+		})
+	}
+}
@@ -93,14 +93,10 @@ func deleteLabels(labelCMDFlags common.KeyValueFlags, p *pkg.OperatorParams) err
 	}
 
 	if labelCMDFlags.DeployName != "" {
-		workloadOverrides, err := ksCR.GetDeployments(labelCMDFlags.Component, labelCMDFlags.Namespace)
-		if err != nil {
-			return err
-		}
-
-		workloadOverrides = removeLabelsDeployFields(workloadOverrides, labelCMDFlags)
 		if err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
-			return ksCR.UpdateDeployments(labelCMDFlags.Component, labelCMDFlags.Namespace, workloadOverrides)
+			return ksCR.UpdateWorkloadOverrides(labelCMDFlags.Component, labelCMDFlags.Namespace, func(workloadOverrides []base.WorkloadOverride) []base.WorkloadOverride {
+				return removeLabelsDeployFields(workloadOverrides, labelCMDFlags)
+			})
 		}); err != nil {
 			return err
 		}
@@ -153,6 +153,48 @@ func TestRemoveLabelsDeployFields(t *testing.T) {
 	}
 }
 
+func TestRemoveLabelsLegacyDeploymentOverride(t *testing.T) {
+	labelCMDFlags := common.KeyValueFlags{
+		Component:  "serving",
+		Namespace:  "test-serving",
+		DeployName: "net-istio-controller",
+		Key:        "test-key",
+	}
+	commonSpec := &base.CommonSpec{
+		DeploymentOverride: testDeploymentForLabels(),
+		Workloads: []base.WorkloadOverride{
+			{
+				Name:   "net-istio-controller",
+				Labels: map[string]string{"test-key": "v0.14.0"},
+			},
+		},
+	}
+	expectedResult := &base.CommonSpec{
+		DeploymentOverride: []base.WorkloadOverride{
+			{
+				Name:   "net-istio-controller",
+				Labels: map[string]string{"test-key-1": "test-val-1"},
+			},
+			{
+				Name: "net-istio-controller-1",
+				Labels: map[string]string{"test-key": "v0.13.0",
+					"test-key-1": "test-val-1"},
+			},
+		},
+		Workloads: []base.WorkloadOverride{
+			{
+				Name:   "net-istio-controller",
+				Labels: map[string]string{},
+			},
+		},
+	}
+
+	result := common.ApplyToWorkloadOverrides(commonSpec, func(workloadOverrides []base.WorkloadOverride) []base.WorkloadOverride {
+		return removeLabelsDeployFields(workloadOverrides, labelCMDFlags)
+	})
+	testingUtil.AssertDeepEqual(t, result, expectedResult)
+}
+
 func testServiceForLabels() []base.ServiceOverride {
 	return []base.ServiceOverride{
 		{
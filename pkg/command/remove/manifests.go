@@ -0,0 +1,161 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remove
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc" // from https://github.com/kubernetes/client-go/issues/345
+	"k8s.io/client-go/util/retry"
+
+	"knative.dev/kn-plugin-operator/pkg"
+	"knative.dev/kn-plugin-operator/pkg/command/common"
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+type ManifestsFlags struct {
+	URLs              []string
+	Names             []string
+	Replace           bool
+	Component         string
+	Namespace         string
+	OperatorNamespace string
+}
+
+var manifestsCMDFlags ManifestsFlags
+
+// removeManifestsCommand represents the remove commands for the manifests configured for Knative
+func removeManifestsCommand(p *pkg.OperatorParams) *cobra.Command {
+	var removeManifestsCmd = &cobra.Command{
+		Use:   "manifests",
+		Short: "Remove the manifests configured for Knative",
+		Long: `Remove the manifests configured for Knative.
+
+With --name, the custom manifests stored from the local files are removed from the ConfigMaps in the namespace of the
+Knative Operator. Once no custom manifests are left, the ConfigMaps are deleted and the mount path of the custom
+manifests is removed from the custom resource.`,
+		Example: `
+  # Remove the additional manifest at the URL for Knative Serving
+  kn operator remove manifests --url https://example.com/custom.yaml --component serving --namespace knative-serving
+  # Remove all the manifests replacing the default manifests of Knative Serving
+  kn operator remove manifests --replace --component serving --namespace knative-serving
+  # Remove the custom manifests named monitoring stored from the local files for Knative Serving
+  kn operator remove manifests --name monitoring --component serving --namespace knative-serving --operatorNamespace knative-operator`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateManifestsFlags(manifestsCMDFlags); err != nil {
+				return err
+			}
+
+			err := removeManifests(manifestsCMDFlags, p)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "The manifests have been removed in the namespace '%s'.\n",
+				manifestsCMDFlags.Namespace)
+			return nil
+		},
+	}
+
+	removeManifestsCmd.Flags().StringArrayVar(&manifestsCMDFlags.URLs, "url", []string{}, "The URL of the manifests to remove. It can be specified multiple times. All the manifests are removed, if it is not specified.")
+	removeManifestsCmd.Flags().StringArrayVar(&manifestsCMDFlags.Names, "name", []string{}, "The name of the custom manifests stored from the local files to remove. It can be specified multiple times.")
+	removeManifestsCmd.Flags().StringVar(&manifestsCMDFlags.OperatorNamespace, "operatorNamespace", "default", "The namespace of the Knative Operator storing the custom manifests")
+	removeManifestsCmd.Flags().BoolVar(&manifestsCMDFlags.Replace, "replace", false, "The flag to remove the manifests from spec.manifests instead of spec.additionalManifests")
+	removeManifestsCmd.Flags().StringVarP(&manifestsCMDFlags.Component, "component", "c", "", "The flag to specify the component name")
+	removeManifestsCmd.Flags().StringVarP(&manifestsCMDFlags.Namespace, "namespace", "n", "", "The namespace of the Knative component")
+
+	return removeManifestsCmd
+}
+
+func validateManifestsFlags(manifestsCMDFlags ManifestsFlags) error {
+	if !strings.EqualFold(manifestsCMDFlags.Component, common.ServingComponent) && !strings.EqualFold(manifestsCMDFlags.Component, common.EventingComponent) {
+		return fmt.Errorf("You need to specify the component for Knative: serving or eventing.")
+	}
+	if manifestsCMDFlags.Namespace == "" {
+		return fmt.Errorf("You need to specify the namespace.")
+	}
+	if len(manifestsCMDFlags.Names) > 0 && len(manifestsCMDFlags.URLs) > 0 {
+		return fmt.Errorf("You cannot specify --name and --url at the same time.")
+	}
+	return nil
+}
+
+func removeManifests(manifestsCMDFlags ManifestsFlags, p *pkg.OperatorParams) error {
+	ksCR, err := common.GetKnativeOperatorCR(p)
+	if err != nil {
+		return err
+	}
+
+	if len(manifestsCMDFlags.Names) > 0 {
+		// The stored custom manifests are shared by Knative Serving and Eventing, so they are only deleted by name
+		kubeClient, err := p.NewKubeClient()
+		if err != nil {
+			return fmt.Errorf("cannot get source cluster kube config, please use --kubeconfig or export environment variable KUBECONFIG to set\n")
+		}
+		kubeResource := common.KubeResource{
+			KubeClient: kubeClient,
+		}
+		customManifests, err := kubeResource.GetCustomManifests(manifestsCMDFlags.OperatorNamespace)
+		if err != nil {
+			return err
+		}
+		if customManifests, err = common.RemoveCustomManifests(customManifests, manifestsCMDFlags.Names); err != nil {
+			return err
+		}
+		cmNames, err := kubeResource.SaveCustomManifests(manifestsCMDFlags.OperatorNamespace, customManifests)
+		if err != nil {
+			return err
+		}
+		if len(cmNames) > 0 {
+			return kubeResource.UpdateOperatorDeployment(common.KnativeOperatorName, manifestsCMDFlags.OperatorNamespace, cmNames)
+		}
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		commonSpec, err := ksCR.GetCommonSpec(manifestsCMDFlags.Component, manifestsCMDFlags.Namespace)
+		if err != nil {
+			return err
+		}
+		if len(manifestsCMDFlags.Names) > 0 {
+			// No custom manifests are left to mount
+			commonSpec.Manifests = removeManifestsFields(commonSpec.Manifests, []string{common.MountPath})
+			commonSpec.AdditionalManifests = removeManifestsFields(commonSpec.AdditionalManifests, []string{common.MountPath})
+		} else if manifestsCMDFlags.Replace {
+			commonSpec.Manifests = removeManifestsFields(commonSpec.Manifests, manifestsCMDFlags.URLs)
+		} else {
+			commonSpec.AdditionalManifests = removeManifestsFields(commonSpec.AdditionalManifests, manifestsCMDFlags.URLs)
+		}
+		return ksCR.UpdateCommonSpec(manifestsCMDFlags.Component, manifestsCMDFlags.Namespace, commonSpec)
+	})
+}
+
+// removeManifestsFields removes the manifests with the URLs. All the manifests are removed, if no URL is specified.
+func removeManifestsFields(manifests []base.Manifest, urls []string) []base.Manifest {
+	if len(urls) == 0 {
+		return nil
+	}
+	result := []base.Manifest{}
+	for _, manifest := range manifests {
+		if !common.Contains(urls, manifest.Url) {
+			result = append(result, manifest)
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
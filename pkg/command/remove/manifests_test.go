@@ -0,0 +1,119 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remove
+
+import (
+	"fmt"
+	"testing"
+
+	"knative.dev/kn-plugin-operator/pkg/command/testingUtil"
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+func TestValidateManifestsFlags(t *testing.T) {
+	for _, tt := range []struct {
+		name              string
+		manifestsCMDFlags ManifestsFlags
+		expectedResult    error
+	}{{
+		name: "Knative Serving",
+		manifestsCMDFlags: ManifestsFlags{
+			URLs:      []string{"https://example.com/custom.yaml"},
+			Component: "serving",
+			Namespace: "knative-serving",
+		},
+		expectedResult: nil,
+	}, {
+		name: "Knative Eventing without URLs",
+		manifestsCMDFlags: ManifestsFlags{
+			Replace:   true,
+			Component: "eventing",
+			Namespace: "knative-eventing",
+		},
+		expectedResult: nil,
+	}, {
+		name: "Knative Serving without component",
+		manifestsCMDFlags: ManifestsFlags{
+			Namespace: "knative-serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify the component for Knative: serving or eventing."),
+	}, {
+		name: "Knative Serving without namespace",
+		manifestsCMDFlags: ManifestsFlags{
+			Component: "serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify the namespace."),
+	}, {
+		name: "Knative Serving with the names of the custom manifests",
+		manifestsCMDFlags: ManifestsFlags{
+			Names:             []string{"monitoring"},
+			Component:         "serving",
+			Namespace:         "knative-serving",
+			OperatorNamespace: "knative-operator",
+		},
+		expectedResult: nil,
+	}, {
+		name: "Knative Serving with both the names and the URLs",
+		manifestsCMDFlags: ManifestsFlags{
+			Names:     []string{"monitoring"},
+			URLs:      []string{"https://example.com/custom.yaml"},
+			Component: "serving",
+			Namespace: "knative-serving",
+		},
+		expectedResult: fmt.Errorf("You cannot specify --name and --url at the same time."),
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := validateManifestsFlags(tt.manifestsCMDFlags)
+			if tt.expectedResult == nil {
+				testingUtil.AssertEqual(t, result, nil)
+			} else {
+				testingUtil.AssertEqual(t, result.Error(), tt.expectedResult.Error())
+			}
+		})
+	}
+}
+
+func TestRemoveManifestsFields(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		manifests      []base.Manifest
+		urls           []string
+		expectedResult []base.Manifest
+	}{{
+		name:           "Remove all the manifests",
+		manifests:      []base.Manifest{{Url: "https://example.com/a.yaml"}, {Url: "https://example.com/b.yaml"}},
+		expectedResult: nil,
+	}, {
+		name:           "Remove one manifest",
+		manifests:      []base.Manifest{{Url: "https://example.com/a.yaml"}, {Url: "https://example.com/b.yaml"}},
+		urls:           []string{"https://example.com/a.yaml"},
+		expectedResult: []base.Manifest{{Url: "https://example.com/b.yaml"}},
+	}, {
+		name:           "Remove a manifest not configured",
+		manifests:      []base.Manifest{{Url: "https://example.com/a.yaml"}},
+		urls:           []string{"https://example.com/c.yaml"},
+		expectedResult: []base.Manifest{{Url: "https://example.com/a.yaml"}},
+	}, {
+		name:           "Remove the last manifest",
+		manifests:      []base.Manifest{{Url: "https://example.com/a.yaml"}},
+		urls:           []string{"https://example.com/a.yaml"},
+		expectedResult: nil,
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := removeManifestsFields(tt.manifests, tt.urls)
+			testingUtil.AssertDeepEqual(t, result, tt.expectedResult)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remove
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc" // from https://github.com/kubernetes/client-go/issues/345
+	"k8s.io/client-go/util/retry"
+
+	"knative.dev/kn-plugin-operator/pkg"
+	"knative.dev/kn-plugin-operator/pkg/command/common"
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+var namespaceLabelCMDFlags common.KeyValueFlags
+
+var namespaceAnnotationCMDFlags common.KeyValueFlags
+
+// removeNamespaceLabelCommand represents the remove commands to delete the labels for the Knative namespace
+func removeNamespaceLabelCommand(p *pkg.OperatorParams) *cobra.Command {
+	var removeNamespaceLabelsCmd = &cobra.Command{
+		Use:   "namespace-labels",
+		Short: "Remove the labels for the namespace of Knative Serving and Eventing",
+		Example: `
+  # Remove the label istio-injection for the namespace of Knative Serving
+  kn operator remove namespace-labels --component serving --key istio-injection --namespace knative-serving`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespaceLabelCMDFlags.Label = true
+			if err := validateNamespaceMetadataFlags(namespaceLabelCMDFlags); err != nil {
+				return err
+			}
+
+			err := deleteNamespaceMetadata(namespaceLabelCMDFlags, p)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "The specified labels have been removed for the namespace '%s'.\n",
+				namespaceLabelCMDFlags.Namespace)
+			return nil
+		},
+	}
+
+	removeNamespaceLabelsCmd.Flags().StringVar(&namespaceLabelCMDFlags.Key, "key", "", "The key of the label")
+	removeNamespaceLabelsCmd.Flags().StringVarP(&namespaceLabelCMDFlags.Component, "component", "c", "", "The flag to specify the component name")
+	removeNamespaceLabelsCmd.Flags().StringVarP(&namespaceLabelCMDFlags.Namespace, "namespace", "n", "", "The namespace of the Knative Operator or the Knative component")
+
+	return removeNamespaceLabelsCmd
+}
+
+// removeNamespaceAnnotationCommand represents the remove commands to delete the annotations for the Knative namespace
+func removeNamespaceAnnotationCommand(p *pkg.OperatorParams) *cobra.Command {
+	var removeNamespaceAnnotationsCmd = &cobra.Command{
+		Use:   "namespace-annotations",
+		Short: "Remove the annotations for the namespace of Knative Serving and Eventing",
+		Example: `
+  # Remove the annotation key for the namespace of Knative Eventing
+  kn operator remove namespace-annotations --component eventing --key key --namespace knative-eventing`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespaceAnnotationCMDFlags.Annotation = true
+			if err := validateNamespaceMetadataFlags(namespaceAnnotationCMDFlags); err != nil {
+				return err
+			}
+
+			err := deleteNamespaceMetadata(namespaceAnnotationCMDFlags, p)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "The specified annotations have been removed for the namespace '%s'.\n",
+				namespaceAnnotationCMDFlags.Namespace)
+			return nil
+		},
+	}
+
+	removeNamespaceAnnotationsCmd.Flags().StringVar(&namespaceAnnotationCMDFlags.Key, "key", "", "The key of the annotation")
+	removeNamespaceAnnotationsCmd.Flags().StringVarP(&namespaceAnnotationCMDFlags.Component, "component", "c", "", "The flag to specify the component name")
+	removeNamespaceAnnotationsCmd.Flags().StringVarP(&namespaceAnnotationCMDFlags.Namespace, "namespace", "n", "", "The namespace of the Knative Operator or the Knative component")
+
+	return removeNamespaceAnnotationsCmd
+}
+
+func validateNamespaceMetadataFlags(namespaceCMDFlags common.KeyValueFlags) error {
+	if namespaceCMDFlags.Namespace == "" {
+		return fmt.Errorf("You need to specify the namespace.")
+	}
+	if namespaceCMDFlags.Component == "" {
+		return fmt.Errorf("You need to specify the component for Knative.")
+	}
+	if !strings.EqualFold(namespaceCMDFlags.Component, common.ServingComponent) && !strings.EqualFold(namespaceCMDFlags.Component, common.EventingComponent) {
+		return fmt.Errorf("You need to specify the component for Knative: serving or eventing.")
+	}
+	return nil
+}
+
+func deleteNamespaceMetadata(namespaceCMDFlags common.KeyValueFlags, p *pkg.OperatorParams) error {
+	ksCR, err := common.GetKnativeOperatorCR(p)
+	if err != nil {
+		return err
+	}
+
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		commonSpec, err := ksCR.GetCommonSpec(namespaceCMDFlags.Component, namespaceCMDFlags.Namespace)
+		if err != nil {
+			return err
+		}
+		commonSpec.NamespaceConfiguration = removeNamespaceMetadataFields(commonSpec.NamespaceConfiguration, namespaceCMDFlags)
+		return ksCR.UpdateCommonSpec(namespaceCMDFlags.Component, namespaceCMDFlags.Namespace, commonSpec)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func removeNamespaceMetadataFields(nsConfig *base.NamespaceConfiguration, namespaceCMDFlags common.KeyValueFlags) *base.NamespaceConfiguration {
+	if nsConfig == nil {
+		return nil
+	}
+
+	if namespaceCMDFlags.Annotation {
+		nsConfig.Annotations = removeKey(nsConfig.Annotations, namespaceCMDFlags.Key)
+	} else {
+		nsConfig.Labels = removeKey(nsConfig.Labels, namespaceCMDFlags.Key)
+	}
+
+	if len(nsConfig.Labels) == 0 && len(nsConfig.Annotations) == 0 {
+		return nil
+	}
+	return nsConfig
+}
+
+// removeKey removes the key from the map. All the keys are removed, if no key is specified.
+func removeKey(data map[string]string, key string) map[string]string {
+	if key == "" {
+		return nil
+	}
+	result := make(map[string]string)
+	for k, value := range data {
+		if k != key {
+			result[k] = value
+		}
+	}
+	return result
+}
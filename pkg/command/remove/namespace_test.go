@@ -0,0 +1,140 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remove
+
+import (
+	"fmt"
+	"testing"
+
+	"knative.dev/kn-plugin-operator/pkg/command/common"
+	"knative.dev/kn-plugin-operator/pkg/command/testingUtil"
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+func TestValidateNamespaceMetadataFlags(t *testing.T) {
+	for _, tt := range []struct {
+		name              string
+		namespaceCMDFlags common.KeyValueFlags
+		expectedResult    error
+	}{{
+		name: "Namespace flags with correct component and namespace",
+		namespaceCMDFlags: common.KeyValueFlags{
+			Key:       "istio-injection",
+			Component: "serving",
+			Namespace: "test-serving",
+		},
+		expectedResult: nil,
+	}, {
+		name: "Namespace flags without namespace",
+		namespaceCMDFlags: common.KeyValueFlags{
+			Component: "serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify the namespace."),
+	}, {
+		name: "Namespace flags without component",
+		namespaceCMDFlags: common.KeyValueFlags{
+			Namespace: "test-serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify the component for Knative."),
+	}, {
+		name: "Namespace flags with invalid component",
+		namespaceCMDFlags: common.KeyValueFlags{
+			Component: "test",
+			Namespace: "test-serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify the component for Knative: serving or eventing."),
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := validateNamespaceMetadataFlags(tt.namespaceCMDFlags)
+			if tt.expectedResult == nil {
+				testingUtil.AssertEqual(t, result, nil)
+			} else {
+				testingUtil.AssertEqual(t, result.Error(), tt.expectedResult.Error())
+			}
+		})
+	}
+}
+
+func testNamespaceConfiguration() *base.NamespaceConfiguration {
+	return &base.NamespaceConfiguration{
+		Labels: map[string]string{
+			"istio-injection":                    "enabled",
+			"pod-security.kubernetes.io/enforce": "restricted",
+		},
+		Annotations: map[string]string{
+			"test-key": "test-value",
+		},
+	}
+}
+
+func TestRemoveNamespaceMetadataFields(t *testing.T) {
+	for _, tt := range []struct {
+		name              string
+		namespaceCMDFlags common.KeyValueFlags
+		input             *base.NamespaceConfiguration
+		expectedResult    *base.NamespaceConfiguration
+	}{{
+		name: "Remove the label by key",
+		namespaceCMDFlags: common.KeyValueFlags{
+			Key:   "istio-injection",
+			Label: true,
+		},
+		input: testNamespaceConfiguration(),
+		expectedResult: &base.NamespaceConfiguration{
+			Labels: map[string]string{
+				"pod-security.kubernetes.io/enforce": "restricted",
+			},
+			Annotations: map[string]string{
+				"test-key": "test-value",
+			},
+		},
+	}, {
+		name: "Remove all the labels",
+		namespaceCMDFlags: common.KeyValueFlags{
+			Label: true,
+		},
+		input: testNamespaceConfiguration(),
+		expectedResult: &base.NamespaceConfiguration{
+			Annotations: map[string]string{
+				"test-key": "test-value",
+			},
+		},
+	}, {
+		name: "Remove the last annotation without labels",
+		namespaceCMDFlags: common.KeyValueFlags{
+			Key:        "test-key",
+			Annotation: true,
+		},
+		input: &base.NamespaceConfiguration{
+			Annotations: map[string]string{
+				"test-key": "test-value",
+			},
+		},
+		expectedResult: nil,
+	}, {
+		name: "Remove the label without namespace configuration",
+		namespaceCMDFlags: common.KeyValueFlags{
+			Key:   "istio-injection",
+			Label: true,
+		},
+		input:          nil,
+		expectedResult: nil,
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := removeNamespaceMetadataFields(tt.input, tt.namespaceCMDFlags)
+			testingUtil.AssertDeepEqual(t, result, tt.expectedResult)
+		})
+	}
+}
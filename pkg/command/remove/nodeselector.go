@@ -85,14 +85,10 @@ func deleteNodeSelectors(nodeSelectorFlags common.KeyValueFlags, p *pkg.Operator
 		return err
 	}
 
-	workloadOverrides, err := ksCR.GetDeployments(nodeSelectorFlags.Component, nodeSelectorFlags.Namespace)
-	if err != nil {
-		return err
-	}
-
-	workloadOverrides = removeNodeSelectorsDeployFields(workloadOverrides, nodeSelectorFlags)
 	if err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		return ksCR.UpdateDeployments(nodeSelectorFlags.Component, nodeSelectorFlags.Namespace, workloadOverrides)
+		return ksCR.UpdateWorkloadOverrides(nodeSelectorFlags.Component, nodeSelectorFlags.Namespace, func(workloadOverrides []base.WorkloadOverride) []base.WorkloadOverride {
+			return removeNodeSelectorsDeployFields(workloadOverrides, nodeSelectorFlags)
+		})
 	}); err != nil {
 		return err
 	}
@@ -0,0 +1,119 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remove
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc" // from https://github.com/kubernetes/client-go/issues/345
+	"k8s.io/client-go/util/retry"
+
+	"knative.dev/kn-plugin-operator/pkg"
+	"knative.dev/kn-plugin-operator/pkg/command/common"
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+type PDBFlags struct {
+	Name      string
+	Component string
+	Namespace string
+}
+
+var pdbCMDFlags PDBFlags
+
+// removePDBCommand represents the remove commands for the PodDisruptionBudgets in Knative Serving or Eventing
+func removePDBCommand(p *pkg.OperatorParams) *cobra.Command {
+	var removePDBCmd = &cobra.Command{
+		Use:   "pdb",
+		Short: "Remove the PodDisruptionBudget configurations for Knative Serving and Eventing",
+		Example: `
+  # Remove all the PodDisruptionBudget configurations for Knative Serving
+  kn operator remove pdb --component serving --namespace knative-serving
+  # Remove the configuration of the PodDisruptionBudget activator-pdb
+  kn operator remove pdb --component serving --name activator-pdb --namespace knative-serving`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validatePDBFlags(pdbCMDFlags); err != nil {
+				return err
+			}
+
+			err := deletePDB(pdbCMDFlags, p)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "The specified PodDisruptionBudget configurations have been removed in the namespace '%s'.\n",
+				pdbCMDFlags.Namespace)
+			return nil
+		},
+	}
+
+	removePDBCmd.Flags().StringVar(&pdbCMDFlags.Name, "name", "", "The name of the PodDisruptionBudget")
+	removePDBCmd.Flags().StringVarP(&pdbCMDFlags.Component, "component", "c", "", "The flag to specify the component name")
+	removePDBCmd.Flags().StringVarP(&pdbCMDFlags.Namespace, "namespace", "n", "", "The namespace of the Knative Operator or the Knative component")
+
+	return removePDBCmd
+}
+
+func validatePDBFlags(pdbCMDFlags PDBFlags) error {
+	if pdbCMDFlags.Component == "" {
+		return fmt.Errorf("You need to specify the component name.")
+	}
+	if pdbCMDFlags.Namespace == "" {
+		return fmt.Errorf("You need to specify the namespace.")
+	}
+	if !strings.EqualFold(pdbCMDFlags.Component, common.ServingComponent) && !strings.EqualFold(pdbCMDFlags.Component, common.EventingComponent) {
+		return fmt.Errorf("You need to specify the component for Knative: serving or eventing.")
+	}
+	return nil
+}
+
+func deletePDB(pdbCMDFlags PDBFlags, p *pkg.OperatorParams) error {
+	ksCR, err := common.GetKnativeOperatorCR(p)
+	if err != nil {
+		return err
+	}
+
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		commonSpec, err := ksCR.GetCommonSpec(pdbCMDFlags.Component, pdbCMDFlags.Namespace)
+		if err != nil {
+			return err
+		}
+		commonSpec.PodDisruptionBudgetOverride = removePDBFields(commonSpec.PodDisruptionBudgetOverride, pdbCMDFlags)
+		return ksCR.UpdateCommonSpec(pdbCMDFlags.Component, pdbCMDFlags.Namespace, commonSpec)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func removePDBFields(pdbOverrides []base.PodDisruptionBudgetOverride, pdbCMDFlags PDBFlags) []base.PodDisruptionBudgetOverride {
+	if pdbCMDFlags.Name == "" {
+		// If no name is specified, we will remove all the PodDisruptionBudget configurations.
+		return nil
+	}
+
+	pdbOverridesBack := make([]base.PodDisruptionBudgetOverride, 0, len(pdbOverrides))
+	for _, pdb := range pdbOverrides {
+		if pdb.Name != pdbCMDFlags.Name {
+			pdbOverridesBack = append(pdbOverridesBack, pdb)
+		}
+	}
+	return pdbOverridesBack
+}
@@ -0,0 +1,132 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remove
+
+import (
+	"fmt"
+	"testing"
+
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"knative.dev/kn-plugin-operator/pkg/command/testingUtil"
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+func TestValidatePDBFlags(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		pdbCMDFlags    PDBFlags
+		expectedResult error
+	}{{
+		name: "PDB flags with correct component and namespace",
+		pdbCMDFlags: PDBFlags{
+			Component: "serving",
+			Namespace: "test-serving",
+		},
+		expectedResult: nil,
+	}, {
+		name: "PDB flags with name",
+		pdbCMDFlags: PDBFlags{
+			Name:      "activator-pdb",
+			Component: "serving",
+			Namespace: "test-serving",
+		},
+		expectedResult: nil,
+	}, {
+		name: "PDB flags without component",
+		pdbCMDFlags: PDBFlags{
+			Namespace: "test-serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify the component name."),
+	}, {
+		name: "PDB flags without namespace",
+		pdbCMDFlags: PDBFlags{
+			Component: "serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify the namespace."),
+	}, {
+		name: "PDB flags with invalid component",
+		pdbCMDFlags: PDBFlags{
+			Component: "test",
+			Namespace: "test-serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify the component for Knative: serving or eventing."),
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := validatePDBFlags(tt.pdbCMDFlags)
+			if tt.expectedResult == nil {
+				testingUtil.AssertEqual(t, result, nil)
+			} else {
+				testingUtil.AssertEqual(t, result.Error(), tt.expectedResult.Error())
+			}
+		})
+	}
+}
+
+func testPDBOverrides() []base.PodDisruptionBudgetOverride {
+	minAvailable := intstr.FromInt(2)
+	maxUnavailable := intstr.FromString("50%")
+	return []base.PodDisruptionBudgetOverride{{
+		Name: "activator-pdb",
+		PodDisruptionBudgetSpec: policyv1.PodDisruptionBudgetSpec{
+			MinAvailable: &minAvailable,
+		},
+	}, {
+		Name: "webhook-pdb",
+		PodDisruptionBudgetSpec: policyv1.PodDisruptionBudgetSpec{
+			MaxUnavailable: &maxUnavailable,
+		},
+	}}
+}
+
+func TestRemovePDBFields(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		pdbCMDFlags    PDBFlags
+		input          []base.PodDisruptionBudgetOverride
+		expectedResult []base.PodDisruptionBudgetOverride
+	}{{
+		name: "PDB flags without name",
+		pdbCMDFlags: PDBFlags{
+			Component: "serving",
+			Namespace: "test-serving",
+		},
+		input:          testPDBOverrides(),
+		expectedResult: nil,
+	}, {
+		name: "PDB flags with name",
+		pdbCMDFlags: PDBFlags{
+			Name:      "activator-pdb",
+			Component: "serving",
+			Namespace: "test-serving",
+		},
+		input:          testPDBOverrides(),
+		expectedResult: testPDBOverrides()[1:],
+	}, {
+		name: "PDB flags with unknown name",
+		pdbCMDFlags: PDBFlags{
+			Name:      "unknown-pdb",
+			Component: "serving",
+			Namespace: "test-serving",
+		},
+		input:          testPDBOverrides(),
+		expectedResult: testPDBOverrides(),
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := removePDBFields(tt.input, tt.pdbCMDFlags)
+			testingUtil.AssertDeepEqual(t, result, tt.expectedResult)
+		})
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remove
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc" // from https://github.com/kubernetes/client-go/issues/345
+	"k8s.io/client-go/util/retry"
+	"knative.dev/kn-plugin-operator/pkg"
+	"knative.dev/kn-plugin-operator/pkg/command/common"
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+type ProbeFlags struct {
+	Type          string
+	Component     string
+	Namespace     string
+	DeployName    string
+	ContainerName string
+}
+
+var probeCMDFlags ProbeFlags
+
+// removeProbesCommand represents the remove commands for the readiness and liveness probes in Knative Serving or Eventing
+func removeProbesCommand(p *pkg.OperatorParams) *cobra.Command {
+	var removeProbesCmd = &cobra.Command{
+		Use:   "probes",
+		Short: "Remove the readiness and liveness probes for Knative Serving and Eventing deployments",
+		Example: `
+  # Remove the readiness probe for the container webhook of the deployment webhook
+  kn operator remove probes --component serving --deployName webhook --container webhook --type readiness --namespace knative-serving`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateProbeFlags(probeCMDFlags); err != nil {
+				return err
+			}
+
+			err := deleteProbes(probeCMDFlags, p)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "The specified probes have been deleted in the namespace '%s'.\n",
+				probeCMDFlags.Namespace)
+			return nil
+		},
+	}
+
+	removeProbesCmd.Flags().StringVar(&probeCMDFlags.Type, "type", "", "The type of the probe: readiness or liveness. Both are removed, if it is not specified")
+	removeProbesCmd.Flags().StringVar(&probeCMDFlags.DeployName, "deployName", "", "The flag to specify the deployment name")
+	removeProbesCmd.Flags().StringVar(&probeCMDFlags.ContainerName, "container", "", "The flag to specify the container name")
+	removeProbesCmd.Flags().StringVarP(&probeCMDFlags.Component, "component", "c", "", "The flag to specify the component name")
+	removeProbesCmd.Flags().StringVarP(&probeCMDFlags.Namespace, "namespace", "n", "", "The namespace of the Knative Operator or the Knative component")
+
+	return removeProbesCmd
+}
+
+func validateProbeFlags(probeCMDFlags ProbeFlags) error {
+	if probeCMDFlags.Component == "" {
+		return fmt.Errorf("You need to specify the component name.")
+	}
+	if probeCMDFlags.Namespace == "" {
+		return fmt.Errorf("You need to specify the namespace.")
+	}
+	if probeCMDFlags.Type != "" && !strings.EqualFold(probeCMDFlags.Type, "readiness") && !strings.EqualFold(probeCMDFlags.Type, "liveness") {
+		return fmt.Errorf("You need to specify the type to one of the following values: readiness or liveness.")
+	}
+	if probeCMDFlags.ContainerName != "" && probeCMDFlags.DeployName == "" {
+		return fmt.Errorf("You need to specify the deployment name for the container.")
+	}
+
+	return nil
+}
+
+func deleteProbes(probeCMDFlags ProbeFlags, p *pkg.OperatorParams) error {
+	ksCR, err := common.GetKnativeOperatorCR(p)
+	if err != nil {
+		return err
+	}
+
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		return ksCR.UpdateWorkloadOverrides(probeCMDFlags.Component, probeCMDFlags.Namespace, func(workloadOverrides []base.WorkloadOverride) []base.WorkloadOverride {
+			return removeProbesFields(workloadOverrides, probeCMDFlags)
+		})
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func removeProbesFields(workloadOverrides []base.WorkloadOverride, probeCMDFlags ProbeFlags) []base.WorkloadOverride {
+	removeReadiness := probeCMDFlags.Type == "" || strings.EqualFold(probeCMDFlags.Type, "readiness")
+	removeLiveness := probeCMDFlags.Type == "" || strings.EqualFold(probeCMDFlags.Type, "liveness")
+	for i, deploy := range workloadOverrides {
+		// If no deploy is specified, we will iterate all the deployments to remove the probes.
+		if probeCMDFlags.DeployName != "" && deploy.Name != probeCMDFlags.DeployName {
+			continue
+		}
+		if removeReadiness {
+			workloadOverrides[i].ReadinessProbes = removeContainerProbes(deploy.ReadinessProbes, probeCMDFlags.ContainerName)
+		}
+		if removeLiveness {
+			workloadOverrides[i].LivenessProbes = removeContainerProbes(deploy.LivenessProbes, probeCMDFlags.ContainerName)
+		}
+	}
+
+	return workloadOverrides
+}
+
+// removeContainerProbes removes the probes of the container. All the probes are removed, if no container is specified.
+func removeContainerProbes(probes []base.ProbesRequirementsOverride, containerName string) []base.ProbesRequirementsOverride {
+	if containerName == "" {
+		return nil
+	}
+	probesBack := make([]base.ProbesRequirementsOverride, 0, len(probes))
+	for _, probe := range probes {
+		if probe.Container != containerName {
+			probesBack = append(probesBack, probe)
+		}
+	}
+	return probesBack
+}
@@ -0,0 +1,183 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remove
+
+import (
+	"fmt"
+	"testing"
+
+	"knative.dev/kn-plugin-operator/pkg/command/testingUtil"
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+func TestValidateProbeFlags(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		probeCMDFlags  ProbeFlags
+		expectedResult error
+	}{{
+		name: "Probe flags with correct component and namespace",
+		probeCMDFlags: ProbeFlags{
+			Component: "serving",
+			Namespace: "test-serving",
+		},
+		expectedResult: nil,
+	}, {
+		name: "Probe flags with correct type, container, deploy name, component and namespace",
+		probeCMDFlags: ProbeFlags{
+			Type:          "liveness",
+			Component:     "serving",
+			Namespace:     "test-serving",
+			DeployName:    "webhook",
+			ContainerName: "webhook",
+		},
+		expectedResult: nil,
+	}, {
+		name: "Probe flags without component",
+		probeCMDFlags: ProbeFlags{
+			Namespace: "test-serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify the component name."),
+	}, {
+		name: "Probe flags without namespace",
+		probeCMDFlags: ProbeFlags{
+			Component: "serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify the namespace."),
+	}, {
+		name: "Probe flags with invalid type",
+		probeCMDFlags: ProbeFlags{
+			Type:      "startup",
+			Component: "serving",
+			Namespace: "test-serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify the type to one of the following values: readiness or liveness."),
+	}, {
+		name: "Probe flags without deploy name for the container",
+		probeCMDFlags: ProbeFlags{
+			Component:     "serving",
+			Namespace:     "test-serving",
+			ContainerName: "webhook",
+		},
+		expectedResult: fmt.Errorf("You need to specify the deployment name for the container."),
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := validateProbeFlags(tt.probeCMDFlags)
+			if tt.expectedResult == nil {
+				testingUtil.AssertEqual(t, result, nil)
+			} else {
+				testingUtil.AssertEqual(t, result.Error(), tt.expectedResult.Error())
+			}
+		})
+	}
+}
+
+func testDeploymentForProbes() []base.WorkloadOverride {
+	return []base.WorkloadOverride{
+		{
+			Name: "webhook",
+			ReadinessProbes: []base.ProbesRequirementsOverride{{
+				Container:     "webhook",
+				PeriodSeconds: 5,
+			}, {
+				Container:     "sidecar",
+				PeriodSeconds: 10,
+			}},
+			LivenessProbes: []base.ProbesRequirementsOverride{{
+				Container:        "webhook",
+				FailureThreshold: 6,
+			}},
+		},
+		{
+			Name: "controller",
+			ReadinessProbes: []base.ProbesRequirementsOverride{{
+				Container:     "controller",
+				PeriodSeconds: 5,
+			}},
+		},
+	}
+}
+
+func TestRemoveProbesFields(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		probeCMDFlags  ProbeFlags
+		input          []base.WorkloadOverride
+		expectedResult []base.WorkloadOverride
+	}{{
+		name: "Probe flags with correct component and namespace",
+		probeCMDFlags: ProbeFlags{
+			Component: "serving",
+			Namespace: "test-serving",
+		},
+		input: testDeploymentForProbes(),
+		expectedResult: []base.WorkloadOverride{
+			{
+				Name: "webhook",
+			},
+			{
+				Name: "controller",
+			},
+		},
+	}, {
+		name: "Probe flags with the readiness type and deploy name",
+		probeCMDFlags: ProbeFlags{
+			Type:       "readiness",
+			Component:  "serving",
+			Namespace:  "test-serving",
+			DeployName: "webhook",
+		},
+		input: testDeploymentForProbes(),
+		expectedResult: []base.WorkloadOverride{
+			{
+				Name: "webhook",
+				LivenessProbes: []base.ProbesRequirementsOverride{{
+					Container:        "webhook",
+					FailureThreshold: 6,
+				}},
+			},
+			testDeploymentForProbes()[1],
+		},
+	}, {
+		name: "Probe flags with the readiness type, deploy name and container name",
+		probeCMDFlags: ProbeFlags{
+			Type:          "readiness",
+			Component:     "serving",
+			Namespace:     "test-serving",
+			DeployName:    "webhook",
+			ContainerName: "webhook",
+		},
+		input: testDeploymentForProbes(),
+		expectedResult: []base.WorkloadOverride{
+			{
+				Name: "webhook",
+				ReadinessProbes: []base.ProbesRequirementsOverride{{
+					Container:     "sidecar",
+					PeriodSeconds: 10,
+				}},
+				LivenessProbes: []base.ProbesRequirementsOverride{{
+					Container:        "webhook",
+					FailureThreshold: 6,
+				}},
+			},
+			testDeploymentForProbes()[1],
+		},
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := removeProbesFields(tt.input, tt.probeCMDFlags)
+			testingUtil.AssertDeepEqual(t, result, tt.expectedResult)
+		})
+	}
+}
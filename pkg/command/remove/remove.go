@@ -44,6 +44,16 @@ func NewRemoveCommand(p *pkg.OperatorParams) *cobra.Command {
 	removeCmd.AddCommand(removeAnnotationCommand(p))
 	removeCmd.AddCommand(removeNodeSelectorCommand(p))
 	removeCmd.AddCommand(removeSelectorCommand(p))
+	removeCmd.AddCommand(removeAffinityCommand(p))
+	removeCmd.AddCommand(removeTopologySpreadCommand(p))
+	removeCmd.AddCommand(removeProbesCommand(p))
+	removeCmd.AddCommand(removePDBCommand(p))
+	removeCmd.AddCommand(removeHostNetworkCommand(p))
+	removeCmd.AddCommand(removeNamespaceLabelCommand(p))
+	removeCmd.AddCommand(removeNamespaceAnnotationCommand(p))
+	removeCmd.AddCommand(removeCustomCertsCommand(p))
+	removeCmd.AddCommand(removeIngressCommand(p))
+	removeCmd.AddCommand(removeManifestsCommand(p))
 
 	return removeCmd
 }
@@ -91,14 +91,10 @@ func removeResources(resourcesCMDFlags ResourcesFlags, p *pkg.OperatorParams) er
 		return err
 	}
 
-	workloadOverrides, err := ksCR.GetDeployments(resourcesCMDFlags.Component, resourcesCMDFlags.Namespace)
-	if err != nil {
-		return err
-	}
-
-	workloadOverrides = removeResourcesFields(workloadOverrides, resourcesCMDFlags)
 	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		return ksCR.UpdateDeployments(resourcesCMDFlags.Component, resourcesCMDFlags.Namespace, workloadOverrides)
+		return ksCR.UpdateWorkloadOverrides(resourcesCMDFlags.Component, resourcesCMDFlags.Namespace, func(workloadOverrides []base.WorkloadOverride) []base.WorkloadOverride {
+			return removeResourcesFields(workloadOverrides, resourcesCMDFlags)
+		})
 	})
 
 	if err != nil {
@@ -91,14 +91,10 @@ func deleteTolerations(tolerationsCMDFlags TolerationsFlags, p *pkg.OperatorPara
 		return err
 	}
 
-	workloadOverrides, err := ksCR.GetDeployments(tolerationsCMDFlags.Component, tolerationsCMDFlags.Namespace)
-	if err != nil {
-		return err
-	}
-
-	workloadOverrides = removeTolerationsFields(workloadOverrides, tolerationsCMDFlags)
 	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		return ksCR.UpdateDeployments(tolerationsCMDFlags.Component, tolerationsCMDFlags.Namespace, workloadOverrides)
+		return ksCR.UpdateWorkloadOverrides(tolerationsCMDFlags.Component, tolerationsCMDFlags.Namespace, func(workloadOverrides []base.WorkloadOverride) []base.WorkloadOverride {
+			return removeTolerationsFields(workloadOverrides, tolerationsCMDFlags)
+		})
 	})
 
 	if err != nil {
@@ -0,0 +1,133 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remove
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/spf13/cobra"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc" // from https://github.com/kubernetes/client-go/issues/345
+	"k8s.io/client-go/util/retry"
+	"knative.dev/kn-plugin-operator/pkg"
+	"knative.dev/kn-plugin-operator/pkg/command/common"
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+type TopologySpreadFlags struct {
+	TopologyKey string
+	Component   string
+	Namespace   string
+	DeployName  string
+}
+
+var topologySpreadCMDFlags TopologySpreadFlags
+
+// removeTopologySpreadCommand represents the remove commands for the topology spread constraints in Knative Serving or Eventing
+func removeTopologySpreadCommand(p *pkg.OperatorParams) *cobra.Command {
+	var removeTopologySpreadCmd = &cobra.Command{
+		Use:   "topology-spread",
+		Short: "Remove the topology spread constraints for Knative Serving and Eventing deployments",
+		Example: `
+  # Remove the topology spread constraint with the key topology.kubernetes.io/zone for the deployment activator
+  kn operator remove topology-spread --component serving --deployName activator --topologyKey topology.kubernetes.io/zone --namespace knative-serving`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateTopologySpreadFlags(topologySpreadCMDFlags); err != nil {
+				return err
+			}
+
+			err := deleteTopologySpread(topologySpreadCMDFlags, p)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "The specified topology spread constraints have been deleted in the namespace '%s'.\n",
+				topologySpreadCMDFlags.Namespace)
+			return nil
+		},
+	}
+
+	removeTopologySpreadCmd.Flags().StringVar(&topologySpreadCMDFlags.TopologyKey, "topologyKey", "", "The flag to specify the topology key")
+	removeTopologySpreadCmd.Flags().StringVar(&topologySpreadCMDFlags.DeployName, "deployName", "", "The flag to specify the deployment name")
+	removeTopologySpreadCmd.Flags().StringVarP(&topologySpreadCMDFlags.Component, "component", "c", "", "The flag to specify the component name")
+	removeTopologySpreadCmd.Flags().StringVarP(&topologySpreadCMDFlags.Namespace, "namespace", "n", "", "The namespace of the Knative Operator or the Knative component")
+
+	return removeTopologySpreadCmd
+}
+
+func validateTopologySpreadFlags(topologySpreadCMDFlags TopologySpreadFlags) error {
+	if topologySpreadCMDFlags.Component == "" {
+		return fmt.Errorf("You need to specify the component name.")
+	}
+	if topologySpreadCMDFlags.Namespace == "" {
+		return fmt.Errorf("You need to specify the namespace.")
+	}
+	if topologySpreadCMDFlags.TopologyKey != "" && topologySpreadCMDFlags.DeployName == "" {
+		return fmt.Errorf("You need to specify the deployment name for the topology spread constraint.")
+	}
+
+	return nil
+}
+
+func deleteTopologySpread(topologySpreadCMDFlags TopologySpreadFlags, p *pkg.OperatorParams) error {
+	ksCR, err := common.GetKnativeOperatorCR(p)
+	if err != nil {
+		return err
+	}
+
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		return ksCR.UpdateWorkloadOverrides(topologySpreadCMDFlags.Component, topologySpreadCMDFlags.Namespace, func(workloadOverrides []base.WorkloadOverride) []base.WorkloadOverride {
+			return removeTopologySpreadFields(workloadOverrides, topologySpreadCMDFlags)
+		})
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func removeTopologySpreadFields(workloadOverrides []base.WorkloadOverride, topologySpreadCMDFlags TopologySpreadFlags) []base.WorkloadOverride {
+	if topologySpreadCMDFlags.DeployName == "" {
+		// If no deploy is specified, we will iterate all the deployments to remove all topology spread constraints.
+		for i := range workloadOverrides {
+			workloadOverrides[i].TopologySpreadConstraints = nil
+		}
+	} else if topologySpreadCMDFlags.TopologyKey == "" {
+		for i, deploy := range workloadOverrides {
+			if deploy.Name == topologySpreadCMDFlags.DeployName {
+				workloadOverrides[i].TopologySpreadConstraints = nil
+			}
+		}
+	} else {
+		for i, deploy := range workloadOverrides {
+			if deploy.Name != topologySpreadCMDFlags.DeployName {
+				continue
+			}
+			constraints := make([]corev1.TopologySpreadConstraint, 0, len(deploy.TopologySpreadConstraints))
+			for _, constraint := range deploy.TopologySpreadConstraints {
+				if constraint.TopologyKey != topologySpreadCMDFlags.TopologyKey {
+					constraints = append(constraints, constraint)
+				}
+			}
+			workloadOverrides[i].TopologySpreadConstraints = constraints
+			break
+		}
+	}
+
+	return workloadOverrides
+}
@@ -0,0 +1,165 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remove
+
+import (
+	"fmt"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/kn-plugin-operator/pkg/command/testingUtil"
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+func TestValidateTopologySpreadFlags(t *testing.T) {
+	for _, tt := range []struct {
+		name                   string
+		topologySpreadCMDFlags TopologySpreadFlags
+		expectedResult         error
+	}{{
+		name: "Topology spread flags with correct component and namespace",
+		topologySpreadCMDFlags: TopologySpreadFlags{
+			Component: "serving",
+			Namespace: "test-serving",
+		},
+		expectedResult: nil,
+	}, {
+		name: "Topology spread flags with correct component, namespace, deploy name and topology key",
+		topologySpreadCMDFlags: TopologySpreadFlags{
+			Component:   "serving",
+			Namespace:   "test-serving",
+			DeployName:  "activator",
+			TopologyKey: "topology.kubernetes.io/zone",
+		},
+		expectedResult: nil,
+	}, {
+		name: "Topology spread flags without component",
+		topologySpreadCMDFlags: TopologySpreadFlags{
+			Namespace: "test-serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify the component name."),
+	}, {
+		name: "Topology spread flags without namespace",
+		topologySpreadCMDFlags: TopologySpreadFlags{
+			Component: "serving",
+		},
+		expectedResult: fmt.Errorf("You need to specify the namespace."),
+	}, {
+		name: "Topology spread flags without deploy name for the topology key",
+		topologySpreadCMDFlags: TopologySpreadFlags{
+			Component:   "serving",
+			Namespace:   "test-serving",
+			TopologyKey: "topology.kubernetes.io/zone",
+		},
+		expectedResult: fmt.Errorf("You need to specify the deployment name for the topology spread constraint."),
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := validateTopologySpreadFlags(tt.topologySpreadCMDFlags)
+			if tt.expectedResult == nil {
+				testingUtil.AssertEqual(t, result, nil)
+			} else {
+				testingUtil.AssertEqual(t, result.Error(), tt.expectedResult.Error())
+			}
+		})
+	}
+}
+
+func testDeploymentForTopologySpread() []base.WorkloadOverride {
+	return []base.WorkloadOverride{
+		{
+			Name: "activator",
+			TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{
+				TopologyKey:       "topology.kubernetes.io/zone",
+				MaxSkew:           1,
+				WhenUnsatisfiable: corev1.DoNotSchedule,
+			}, {
+				TopologyKey:       "kubernetes.io/hostname",
+				MaxSkew:           1,
+				WhenUnsatisfiable: corev1.ScheduleAnyway,
+			}},
+		},
+		{
+			Name: "webhook",
+			TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{
+				TopologyKey:       "topology.kubernetes.io/zone",
+				MaxSkew:           1,
+				WhenUnsatisfiable: corev1.DoNotSchedule,
+			}},
+		},
+	}
+}
+
+func TestRemoveTopologySpreadFields(t *testing.T) {
+	for _, tt := range []struct {
+		name                   string
+		topologySpreadCMDFlags TopologySpreadFlags
+		input                  []base.WorkloadOverride
+		expectedResult         []base.WorkloadOverride
+	}{{
+		name: "Topology spread flags with correct component and namespace",
+		topologySpreadCMDFlags: TopologySpreadFlags{
+			Component: "serving",
+			Namespace: "test-serving",
+		},
+		input: testDeploymentForTopologySpread(),
+		expectedResult: []base.WorkloadOverride{
+			{
+				Name: "activator",
+			},
+			{
+				Name: "webhook",
+			},
+		},
+	}, {
+		name: "Topology spread flags with correct deploy, component and namespace",
+		topologySpreadCMDFlags: TopologySpreadFlags{
+			Component:  "serving",
+			Namespace:  "test-serving",
+			DeployName: "activator",
+		},
+		input: testDeploymentForTopologySpread(),
+		expectedResult: []base.WorkloadOverride{
+			{
+				Name: "activator",
+			},
+			testDeploymentForTopologySpread()[1],
+		},
+	}, {
+		name: "Topology spread flags with correct topology key, deploy, component and namespace",
+		topologySpreadCMDFlags: TopologySpreadFlags{
+			Component:   "serving",
+			Namespace:   "test-serving",
+			DeployName:  "activator",
+			TopologyKey: "topology.kubernetes.io/zone",
+		},
+		input: testDeploymentForTopologySpread(),
+		expectedResult: []base.WorkloadOverride{
+			{
+				Name: "activator",
+				TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{
+					TopologyKey:       "kubernetes.io/hostname",
+					MaxSkew:           1,
+					WhenUnsatisfiable: corev1.ScheduleAnyway,
+				}},
+			},
+			testDeploymentForTopologySpread()[1],
+		},
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := removeTopologySpreadFields(tt.input, tt.topologySpreadCMDFlags)
+			testingUtil.AssertDeepEqual(t, result, tt.expectedResult)
+		})
+	}
+}
@@ -0,0 +1,304 @@
+// Copyright 2022 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reset
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc" // from https://github.com/kubernetes/client-go/issues/345
+	"k8s.io/client-go/util/retry"
+
+	"knative.dev/kn-plugin-operator/pkg"
+	"knative.dev/kn-plugin-operator/pkg/command/common"
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+// SnapshotAnnotation is the annotation of the Knative custom resource keeping the overrides dropped by the latest reset
+const SnapshotAnnotation = "operator.knative.dev/reset-snapshot"
+
+type resetFlags struct {
+	Component  string
+	Namespace  string
+	DeployName string
+	All        bool
+	Undo       bool
+}
+
+// resetSnapshot keeps the overrides dropped by a reset, so that the reset can be undone
+type resetSnapshot struct {
+	DeployName  string                  `json:"deployName,omitempty"`
+	Workloads   []base.WorkloadOverride `json:"workloads,omitempty"`
+	Deployments []base.WorkloadOverride `json:"deployments,omitempty"`
+	Services    []base.ServiceOverride  `json:"services,omitempty"`
+	Config      base.ConfigMapData      `json:"config,omitempty"`
+	Registry    *base.Registry          `json:"registry,omitempty"`
+}
+
+var resetCmdFlags resetFlags
+
+// NewResetCommand represents the command to reset the overrides of the Knative custom resources
+func NewResetCommand(p *pkg.OperatorParams) *cobra.Command {
+	var resetCmd = &cobra.Command{
+		Use:   "reset",
+		Short: "Reset the overrides of a deployment or of Knative Serving or Eventing",
+		Long: `Reset the overrides of a deployment or of Knative Serving or Eventing.
+
+With --deployName, the whole override of the deployment is dropped: the labels, annotations, resources, tolerations,
+node selectors, environment variables, replicas and the rest. With --all, the overrides of all the deployments and
+services, the ConfigMaps and the registry are dropped.
+
+The dropped overrides are kept in the annotation operator.knative.dev/reset-snapshot of the custom resource. Run the
+command with --undo to restore them. Only the latest reset can be undone.`,
+		Example: `
+  # Reset the overrides of the deployment activator of Knative Serving
+  kn operator reset -c serving --deployName activator --namespace knative-serving
+  # Reset the overrides of the deployments, services, ConfigMaps and registry of Knative Eventing
+  kn operator reset -c eventing --all --namespace knative-eventing
+  # Undo the latest reset of Knative Serving
+  kn operator reset -c serving --undo --namespace knative-serving`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateResetFlags(resetCmdFlags); err != nil {
+				return err
+			}
+
+			if resetCmdFlags.Namespace == "" {
+				resetCmdFlags.Namespace = common.DefaultKnativeServingNamespace
+				if strings.EqualFold(resetCmdFlags.Component, common.EventingComponent) {
+					resetCmdFlags.Namespace = common.DefaultKnativeEventingNamespace
+				}
+			}
+
+			if resetCmdFlags.Undo {
+				return undoReset(cmd, resetCmdFlags, p)
+			}
+			return reset(cmd, resetCmdFlags, p)
+		},
+	}
+
+	resetCmd.Flags().StringVarP(&resetCmdFlags.Component, "component", "c", "", "The flag to specify the component name: serving or eventing")
+	resetCmd.Flags().StringVarP(&resetCmdFlags.Namespace, "namespace", "n", "", "The namespace of the Knative Serving or Eventing custom resource")
+	resetCmd.Flags().StringVar(&resetCmdFlags.DeployName, "deployName", "", "The flag to specify the deployment to reset")
+	resetCmd.Flags().BoolVar(&resetCmdFlags.All, "all", false, "The flag to reset the overrides of all the deployments and services, the ConfigMaps and the registry")
+	resetCmd.Flags().BoolVar(&resetCmdFlags.Undo, "undo", false, "The flag to undo the latest reset")
+
+	return resetCmd
+}
+
+func validateResetFlags(resetCmdFlags resetFlags) error {
+	if !strings.EqualFold(resetCmdFlags.Component, common.ServingComponent) &&
+		!strings.EqualFold(resetCmdFlags.Component, common.EventingComponent) {
+		return fmt.Errorf("You need to specify the component for Knative: serving or eventing.")
+	}
+	count := 0
+	for _, set := range []bool{resetCmdFlags.DeployName != "", resetCmdFlags.All, resetCmdFlags.Undo} {
+		if set {
+			count++
+		}
+	}
+	if count != 1 {
+		return fmt.Errorf("You need to specify one of --deployName, --all or --undo.")
+	}
+	return nil
+}
+
+func getComponent(resetCmdFlags resetFlags) string {
+	if strings.EqualFold(resetCmdFlags.Component, common.EventingComponent) {
+		return common.EventingComponent
+	}
+	return common.ServingComponent
+}
+
+func reset(cmd *cobra.Command, resetCmdFlags resetFlags, p *pkg.OperatorParams) error {
+	component := getComponent(resetCmdFlags)
+	ksCR, err := common.GetKnativeOperatorCR(p)
+	if err != nil {
+		return err
+	}
+
+	var snapshot *resetSnapshot
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		commonSpec, err := ksCR.GetCommonSpec(component, resetCmdFlags.Namespace)
+		if err != nil {
+			return err
+		}
+		if resetCmdFlags.All {
+			snapshot = resetAll(commonSpec)
+		} else if snapshot, err = resetDeployment(commonSpec, resetCmdFlags.DeployName); err != nil {
+			return err
+		}
+		if snapshot == nil {
+			return nil
+		}
+		value, err := json.Marshal(snapshot)
+		if err != nil {
+			return err
+		}
+		return ksCR.UpdateCommonSpecWithAnnotation(component, resetCmdFlags.Namespace, commonSpec, SnapshotAnnotation, string(value))
+	})
+	if err != nil {
+		return err
+	}
+
+	if snapshot == nil {
+		fmt.Fprintf(cmd.OutOrStdout(), "There are no overrides to reset for Knative %s in the namespace '%s'.\n", component, resetCmdFlags.Namespace)
+		return nil
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Knative %s has been reset in the namespace '%s'. Run the command with --undo to restore the overrides.\n",
+		component, resetCmdFlags.Namespace)
+	return nil
+}
+
+func undoReset(cmd *cobra.Command, resetCmdFlags resetFlags, p *pkg.OperatorParams) error {
+	component := getComponent(resetCmdFlags)
+	ksCR, err := common.GetKnativeOperatorCR(p)
+	if err != nil {
+		return err
+	}
+
+	var warnings []string
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		value, err := ksCR.GetAnnotation(component, resetCmdFlags.Namespace, SnapshotAnnotation)
+		if err != nil {
+			return err
+		}
+		if value == "" {
+			return fmt.Errorf("There is no reset to undo for Knative %s in the namespace '%s'.", component, resetCmdFlags.Namespace)
+		}
+		snapshot := &resetSnapshot{}
+		if err = json.Unmarshal([]byte(value), snapshot); err != nil {
+			return fmt.Errorf("The annotation %s cannot be parsed: %w", SnapshotAnnotation, err)
+		}
+		commonSpec, err := ksCR.GetCommonSpec(component, resetCmdFlags.Namespace)
+		if err != nil {
+			return err
+		}
+		warnings = restoreSnapshot(commonSpec, snapshot)
+		return ksCR.UpdateCommonSpecWithAnnotation(component, resetCmdFlags.Namespace, commonSpec, SnapshotAnnotation, "")
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, warning := range warnings {
+		fmt.Fprintf(cmd.OutOrStdout(), "Warning: %s\n", warning)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "The latest reset of Knative %s has been undone in the namespace '%s'.\n", component, resetCmdFlags.Namespace)
+	return nil
+}
+
+// resetDeployment drops the overrides of the deployment from the spec, and returns them in the snapshot
+func resetDeployment(commonSpec *base.CommonSpec, deployName string) (*resetSnapshot, error) {
+	snapshot := &resetSnapshot{DeployName: deployName}
+	commonSpec.Workloads, snapshot.Workloads = splitWorkloads(commonSpec.Workloads, deployName)
+	commonSpec.DeploymentOverride, snapshot.Deployments = splitWorkloads(commonSpec.DeploymentOverride, deployName)
+	if len(snapshot.Workloads) == 0 && len(snapshot.Deployments) == 0 {
+		return nil, fmt.Errorf("There are no overrides for the deployment %s.", deployName)
+	}
+	return snapshot, nil
+}
+
+// splitWorkloads returns the workloads not named deployName, and the workloads named deployName
+func splitWorkloads(workloads []base.WorkloadOverride, deployName string) ([]base.WorkloadOverride, []base.WorkloadOverride) {
+	var kept, dropped []base.WorkloadOverride
+	for _, workload := range workloads {
+		if workload.Name == deployName {
+			dropped = append(dropped, workload)
+		} else {
+			kept = append(kept, workload)
+		}
+	}
+	return kept, dropped
+}
+
+// resetAll drops the overrides of the deployments and services, the ConfigMaps and the registry from the spec, and
+// returns them in the snapshot. It returns nil, if there is nothing to reset.
+func resetAll(commonSpec *base.CommonSpec) *resetSnapshot {
+	snapshot := &resetSnapshot{
+		Workloads:   commonSpec.Workloads,
+		Deployments: commonSpec.DeploymentOverride,
+		Services:    commonSpec.ServiceOverride,
+		Config:      commonSpec.Config,
+	}
+	if !isRegistryEmpty(commonSpec.Registry) {
+		registry := commonSpec.Registry
+		snapshot.Registry = &registry
+	}
+	if len(snapshot.Workloads) == 0 && len(snapshot.Deployments) == 0 && len(snapshot.Services) == 0 &&
+		len(snapshot.Config) == 0 && snapshot.Registry == nil {
+		return nil
+	}
+	commonSpec.Workloads = nil
+	commonSpec.DeploymentOverride = nil
+	commonSpec.ServiceOverride = nil
+	commonSpec.Config = nil
+	commonSpec.Registry = base.Registry{}
+	return snapshot
+}
+
+func isRegistryEmpty(registry base.Registry) bool {
+	return registry.Default == "" && len(registry.Override) == 0 && len(registry.ImagePullSecrets) == 0
+}
+
+// restoreSnapshot restores the overrides in the snapshot into the spec. It returns the warnings for the overrides
+// configured after the reset, which are replaced.
+func restoreSnapshot(commonSpec *base.CommonSpec, snapshot *resetSnapshot) []string {
+	warnings := []string{}
+	if snapshot.DeployName != "" {
+		var workloadsReplaced, deploymentsReplaced bool
+		commonSpec.Workloads, workloadsReplaced = restoreWorkloads(commonSpec.Workloads, snapshot.Workloads, snapshot.DeployName)
+		commonSpec.DeploymentOverride, deploymentsReplaced = restoreWorkloads(commonSpec.DeploymentOverride, snapshot.Deployments, snapshot.DeployName)
+		if workloadsReplaced || deploymentsReplaced {
+			warnings = append(warnings, fmt.Sprintf("The overrides configured for the deployment %s after the reset are replaced.", snapshot.DeployName))
+		}
+		return warnings
+	}
+
+	for _, field := range []struct {
+		name       string
+		configured bool
+	}{
+		{"spec.workloads", len(commonSpec.Workloads) > 0},
+		{"spec.deployments", len(commonSpec.DeploymentOverride) > 0},
+		{"spec.services", len(commonSpec.ServiceOverride) > 0},
+		{"spec.config", len(commonSpec.Config) > 0},
+		{"spec.registry", !isRegistryEmpty(commonSpec.Registry)},
+	} {
+		if field.configured {
+			warnings = append(warnings, fmt.Sprintf("The %s configured after the reset is replaced.", field.name))
+		}
+	}
+	commonSpec.Workloads = snapshot.Workloads
+	commonSpec.DeploymentOverride = snapshot.Deployments
+	commonSpec.ServiceOverride = snapshot.Services
+	commonSpec.Config = snapshot.Config
+	commonSpec.Registry = base.Registry{}
+	if snapshot.Registry != nil {
+		commonSpec.Registry = *snapshot.Registry
+	}
+	return warnings
+}
+
+// restoreWorkloads replaces the workloads named deployName with the ones in the snapshot. It returns whether workloads
+// configured after the reset are replaced.
+func restoreWorkloads(workloads, snapshotWorkloads []base.WorkloadOverride, deployName string) ([]base.WorkloadOverride, bool) {
+	if len(snapshotWorkloads) == 0 {
+		return workloads, false
+	}
+	kept, replaced := splitWorkloads(workloads, deployName)
+	return append(kept, snapshotWorkloads...), len(replaced) > 0
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2022 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package reset
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"knative.dev/kn-plugin-operator/pkg/command/testingUtil"
+	"knative.dev/operator/pkg/apis/operator/base"
+)
+
+func TestValidateResetFlags(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		flags       resetFlags
+		expectedErr error
+	}{{
+		name:  "Reset a deployment",
+		flags: resetFlags{Component: "serving", DeployName: "activator"},
+	}, {
+		name:  "Reset all",
+		flags: resetFlags{Component: "eventing", All: true},
+	}, {
+		name:  "Undo",
+		flags: resetFlags{Component: "Serving", Undo: true},
+	}, {
+		name:        "Invalid component",
+		flags:       resetFlags{Component: "test", All: true},
+		expectedErr: fmt.Errorf("You need to specify the component for Knative: serving or eventing."),
+	}, {
+		name:        "No scope",
+		flags:       resetFlags{Component: "serving"},
+		expectedErr: fmt.Errorf("You need to specify one of --deployName, --all or --undo."),
+	}, {
+		name:        "Several scopes",
+		flags:       resetFlags{Component: "serving", DeployName: "activator", Undo: true},
+		expectedErr: fmt.Errorf("You need to specify one of --deployName, --all or --undo."),
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateResetFlags(tt.flags)
+			if tt.expectedErr != nil {
+				testingUtil.AssertEqual(t, err.Error(), tt.expectedErr.Error())
+			} else {
+				testingUtil.AssertEqual(t, err, nil)
+			}
+		})
+	}
+}
+
+func getTestCommonSpec() *base.CommonSpec {
+	replicas := int32(2)
+	return &base.CommonSpec{
+		Config: base.ConfigMapData{"autoscaler": {"min-scale": "1"}},
+		Registry: base.Registry{
+			Default: "example.com/knative/${NAME}:latest",
+		},
+		Workloads: []base.WorkloadOverride{{
+			Name:     "activator",
+			Replicas: &replicas,
+			Labels:   map[string]string{"team": "serving"},
+		}, {
+			Name:     "webhook",
+			Replicas: &replicas,
+		}},
+		DeploymentOverride: []base.WorkloadOverride{{
+			Name:        "activator",
+			Annotations: map[string]string{"key": "value"},
+		}},
+		ServiceOverride: []base.ServiceOverride{{
+			Name:   "activator-service",
+			Labels: map[string]string{"team": "serving"},
+		}},
+		Version: "1.8",
+	}
+}
+
+func TestResetDeployment(t *testing.T) {
+	commonSpec := getTestCommonSpec()
+	expected := getTestCommonSpec()
+
+	snapshot, err := resetDeployment(commonSpec, "activator")
+	testingUtil.AssertEqual(t, err, nil)
+	testingUtil.AssertDeepEqual(t, snapshot, &resetSnapshot{
+		DeployName:  "activator",
+		Workloads:   expected.Workloads[:1],
+		Deployments: expected.DeploymentOverride,
+	})
+	testingUtil.AssertDeepEqual(t, commonSpec.Workloads, expected.Workloads[1:])
+	testingUtil.AssertEqual(t, len(commonSpec.DeploymentOverride), 0)
+	testingUtil.AssertDeepEqual(t, commonSpec.Config, expected.Config)
+	testingUtil.AssertDeepEqual(t, commonSpec.ServiceOverride, expected.ServiceOverride)
+
+	// The snapshot is stored as JSON in the annotation
+	value, err := json.Marshal(snapshot)
+	testingUtil.AssertEqual(t, err, nil)
+	restored := &resetSnapshot{}
+	testingUtil.AssertEqual(t, json.Unmarshal(value, restored), nil)
+
+	warnings := restoreSnapshot(commonSpec, restored)
+	testingUtil.AssertDeepEqual(t, warnings, []string{})
+	testingUtil.AssertDeepEqual(t, commonSpec.Workloads, []base.WorkloadOverride{expected.Workloads[1], expected.Workloads[0]})
+	testingUtil.AssertDeepEqual(t, commonSpec.DeploymentOverride, expected.DeploymentOverride)
+
+	_, err = resetDeployment(commonSpec, "controller")
+	testingUtil.AssertEqual(t, err.Error(), "There are no overrides for the deployment controller.")
+}
+
+func TestRestoreDeploymentConfiguredAfterReset(t *testing.T) {
+	commonSpec := getTestCommonSpec()
+	expected := getTestCommonSpec()
+	snapshot, err := resetDeployment(commonSpec, "activator")
+	testingUtil.AssertEqual(t, err, nil)
+
+	commonSpec.Workloads = append(commonSpec.Workloads, base.WorkloadOverride{Name: "activator"})
+	warnings := restoreSnapshot(commonSpec, snapshot)
+	testingUtil.AssertDeepEqual(t, warnings, []string{"The overrides configured for the deployment activator after the reset are replaced."})
+	testingUtil.AssertDeepEqual(t, commonSpec.Workloads, []base.WorkloadOverride{expected.Workloads[1], expected.Workloads[0]})
+
+	// The workloads are kept, if the snapshot has no workloads for the deployment
+	commonSpec.Workloads = []base.WorkloadOverride{{Name: "activator"}}
+	warnings = restoreSnapshot(commonSpec, &resetSnapshot{DeployName: "activator", Deployments: expected.DeploymentOverride})
+	testingUtil.AssertDeepEqual(t, warnings, []string{"The overrides configured for the deployment activator after the reset are replaced."})
+	testingUtil.AssertDeepEqual(t, commonSpec.Workloads, []base.WorkloadOverride{{Name: "activator"}})
+}
+
+func TestResetAll(t *testing.T) {
+	commonSpec := getTestCommonSpec()
+	expected := getTestCommonSpec()
+
+	snapshot := resetAll(commonSpec)
+	testingUtil.AssertDeepEqual(t, commonSpec, &base.CommonSpec{Version: "1.8"})
+	testingUtil.AssertDeepEqual(t, snapshot, &resetSnapshot{
+		Workloads:   expected.Workloads,
+		Deployments: expected.DeploymentOverride,
+		Services:    expected.ServiceOverride,
+		Config:      expected.Config,
+		Registry:    &expected.Registry,
+	})
+	testingUtil.AssertEqual(t, resetAll(commonSpec) == nil, true)
+
+	commonSpec.Config = base.ConfigMapData{"network": {"ingress-class": "kourier.ingress.networking.knative.dev"}}
+	warnings := restoreSnapshot(commonSpec, snapshot)
+	testingUtil.AssertDeepEqual(t, warnings, []string{"The spec.config configured after the reset is replaced."})
+	testingUtil.AssertDeepEqual(t, commonSpec, expected)
+}